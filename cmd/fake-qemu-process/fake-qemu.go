@@ -33,12 +33,20 @@ import (
 func main() {
 	uuid := pflag.String("uuid", "", "the UUID of the fake qemu process")
 	pidFile := pflag.String("pidfile", "", "the path of the PID file to create")
+	ignoreSIGTERM := pflag.Bool("ignore-sigterm", false, "ignore SIGTERM like a hanging qemu process")
+	exitCode := pflag.Int("exit-code", 0, "the exit code of the fake qemu process")
+	pflag.Parse()
+
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt,
-		syscall.SIGTERM,
-	)
+	if *ignoreSIGTERM {
+		signal.Ignore(syscall.SIGTERM)
+		signal.Notify(c, os.Interrupt)
+	} else {
+		signal.Notify(c, os.Interrupt,
+			syscall.SIGTERM,
+		)
+	}
 
-	pflag.Parse()
 	fmt.Printf("Started fake qemu process with uuid %s and pidfile %s\n", *uuid, *pidFile)
 
 	if *pidFile != "" {
@@ -58,4 +66,5 @@ func main() {
 	}
 
 	fmt.Printf("Exit fake qemu process\n")
+	os.Exit(*exitCode)
 }
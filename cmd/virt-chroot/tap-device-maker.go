@@ -44,11 +44,38 @@ func createTapDevice(name string, owner uint, group uint, queueNumber int, mtu i
 		return fmt.Errorf("failed to set MTU on tap device named %s. Reason: %v", name, err)
 	}
 
+	if err := verifyTapDeviceOwnership(name, owner, group, netlink.LinkByName); err != nil {
+		return err
+	}
+
 	fmt.Printf("Successfully created tap device %s, attempt %d\n", name, attempt)
 
 	return nil
 }
 
+// verifyTapDeviceOwnership reads back the tap device and makes sure it is owned by the
+// requested user and group; otherwise the (non-root) qemu process is unable to open it.
+func verifyTapDeviceOwnership(name string, owner uint, group uint, linkByName func(string) (netlink.Link, error)) error {
+	link, err := linkByName(name)
+	if err != nil {
+		return fmt.Errorf("failed to read back tap device named %s. Reason: %v", name, err)
+	}
+
+	tap, ok := link.(*netlink.Tuntap)
+	if !ok {
+		return fmt.Errorf("device named %s is not a tap device, found type %s", name, link.Type())
+	}
+
+	if tap.Owner != uint32(owner) || tap.Group != uint32(group) {
+		return fmt.Errorf(
+			"tap device named %s is owned by %d:%d instead of the requested %d:%d; verify the user %d exists in the launcher image",
+			name, tap.Owner, tap.Group, owner, group, owner,
+		)
+	}
+
+	return nil
+}
+
 func NewCreateTapCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "create-tap",
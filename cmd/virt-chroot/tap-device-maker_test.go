@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+)
+
+var _ = Describe("Tap device ownership verification", func() {
+	const (
+		tapName      = "tap0"
+		nonRootOwner = 107
+	)
+
+	fakeLinkByName := func(link netlink.Link, err error) func(string) (netlink.Link, error) {
+		return func(name string) (netlink.Link, error) {
+			Expect(name).To(Equal(tapName))
+			return link, err
+		}
+	}
+
+	newTap := func(owner, group uint32) *netlink.Tuntap {
+		return &netlink.Tuntap{LinkAttrs: netlink.LinkAttrs{Name: tapName}, Owner: owner, Group: group}
+	}
+
+	It("should succeed when the tap is owned by the requested user and group", func() {
+		Expect(verifyTapDeviceOwnership(tapName, nonRootOwner, nonRootOwner, fakeLinkByName(newTap(nonRootOwner, nonRootOwner), nil))).To(Succeed())
+	})
+
+	It("should fail with a descriptive error when the tap is owned by someone else", func() {
+		err := verifyTapDeviceOwnership(tapName, nonRootOwner, nonRootOwner, fakeLinkByName(newTap(0, 0), nil))
+		Expect(err).To(MatchError(ContainSubstring("tap device named tap0 is owned by 0:0 instead of the requested 107:107")))
+	})
+
+	It("should fail when the device is not a tap", func() {
+		err := verifyTapDeviceOwnership(tapName, nonRootOwner, nonRootOwner, fakeLinkByName(&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: tapName}}, nil))
+		Expect(err).To(MatchError(ContainSubstring("is not a tap device")))
+	})
+
+	It("should fail when the device cannot be read back", func() {
+		err := verifyTapDeviceOwnership(tapName, nonRootOwner, nonRootOwner, fakeLinkByName(nil, errors.New("link not found")))
+		Expect(err).To(MatchError(ContainSubstring("failed to read back tap device named tap0")))
+	})
+})
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestVirtChroot(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -49,7 +49,6 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/certificate"
 	"k8s.io/client-go/util/flowcontrol"
-	"libvirt.org/go/libvirtxml"
 
 	"kubevirt.io/kubevirt/pkg/safepath"
 
@@ -71,6 +70,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/controller"
 	clientmetrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/common/client"
 	metrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-handler"
+	"kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-handler/domainstats"
 	metricshandler "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-handler/handler"
 	"kubevirt.io/kubevirt/pkg/monitoring/profiler"
 	"kubevirt.io/kubevirt/pkg/network/netbinding"
@@ -137,6 +137,8 @@ type virtHandlerApp struct {
 	WatchdogTimeoutDuration   time.Duration
 	MaxDevices                int
 	MaxRequestsInFlight       int
+	MetricsMaxScrapes         int
+	MetricsGuestLowMemoryKB   uint64
 	domainResyncPeriodSeconds int
 	gracefulShutdownSeconds   int
 
@@ -302,20 +304,17 @@ func (app *virtHandlerApp) Run() {
 	app.clusterConfig.SetConfigModifiedCallback(vsockConfigCallback)
 
 	migrationProxy := migrationproxy.NewMigrationProxyManager(app.serverTLSConfig, app.clientTLSConfig, app.clusterConfig)
+	migrationProxy.SetConnectionObserver(metrics.MigrationProxyObserver{})
 
 	stop := make(chan struct{})
 	defer close(stop)
-	var capabilities libvirtxml.Caps
 	var hostCpuModel string
 
-	hostCapsFile, err := os.ReadFile(filepath.Join(nodelabeller.NodeLabellerVolumePath, "capabilities.xml"))
+	capabilitiesCache, err := virthandler.NewCapabilitiesCache(filepath.Join(nodelabeller.NodeLabellerVolumePath, "capabilities.xml"))
 	if err != nil {
 		panic(err)
 	}
-
-	if err := capabilities.Unmarshal(string(hostCapsFile)); err != nil {
-		panic(err)
-	}
+	capabilities := capabilitiesCache.GetCapabilities()
 
 	nodeLabellerrecorder := broadcaster.NewRecorder(scheme.Scheme, k8sv1.EventSource{Component: "node-labeller", Host: app.HostOverride})
 	nodeLabellerController, err := nodelabeller.NewNodeLabeller(app.clusterConfig,
@@ -359,7 +358,7 @@ func (app *virtHandlerApp) Run() {
 		podIsolationDetector,
 		migrationProxy,
 		downwardMetricsManager,
-		&capabilities,
+		capabilitiesCache,
 		hostCpuModel,
 		netsetup.NewNetConf(),
 		netsetup.NewNetStat(),
@@ -409,6 +408,10 @@ func (app *virtHandlerApp) Run() {
 
 	cache.WaitForCacheSync(stop, vmiSourceInformer.HasSynced, factory.CRD().HasSynced, factory.KubeVirt().HasSynced)
 
+	app.shouldChangeVMILabelsConfig()
+	app.clusterConfig.SetConfigModifiedCallback(app.shouldChangeVMILabelsConfig)
+	domainstats.SetGuestLowMemoryThresholdKB(app.MetricsGuestLowMemoryKB)
+	domainstats.SetMaxConcurrentScrapes(app.MetricsMaxScrapes)
 	if err := metrics.SetupMetrics(app.VirtShareDir, app.HostOverride, app.MaxRequestsInFlight, vmiSourceInformer); err != nil {
 		panic(err)
 	}
@@ -504,6 +507,11 @@ func (app *virtHandlerApp) shouldChangeRateLimiter() {
 	log.Log.V(2).Infof("setting rate limiter to %v QPS and %v Burst", qps, burst)
 }
 
+// Update the VMI labels propagated into the metric labels
+func (app *virtHandlerApp) shouldChangeVMILabelsConfig() {
+	domainstats.SetVMILabelsConfig(domainstats.NewVMILabelsConfig(app.clusterConfig.GetMetricsConfiguration()))
+}
+
 // Install the SELinux policy when the feature gate that disables it gets removed
 func (app *virtHandlerApp) shouldInstallSELinuxPolicy() {
 	app.semoduleLock.Lock()
@@ -574,6 +582,7 @@ func (app *virtHandlerApp) runServer(errCh chan error, consoleHandler *rest.Cons
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/guestosinfo").To(lifecycleHandler.GetGuestInfo).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestAgentInfo{}))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/userlist").To(lifecycleHandler.GetUsers).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestOSUserList{}))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/filesystemlist").To(lifecycleHandler.GetFilesystems).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceFileSystemList{}))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/launchinfo").To(lifecycleHandler.GetLaunchInfo).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", cmdclient.LaunchInfo{}))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/vsock").Param(restful.QueryParameter("port", "Target VSOCK port")).To(consoleHandler.VSOCKHandler))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/sev/fetchcertchain").To(lifecycleHandler.SEVFetchCertChainHandler).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.SEVPlatformInfo{}))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/sev/querylaunchmeasurement").To(lifecycleHandler.SEVQueryLaunchMeasurementHandler).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.SEVMeasurementInfo{}))
@@ -648,6 +657,12 @@ func (app *virtHandlerApp) AddFlags() {
 	flag.IntVar(&app.MaxRequestsInFlight, "max-metric-requests", maxRequestsInFlight,
 		"Number of concurrent requests to the metrics endpoint")
 
+	flag.IntVar(&app.MetricsMaxScrapes, "metrics-max-concurrent-scrapes", 0,
+		"Number of VMIs scraped at the same time while collecting the domain stats metrics. All of them are scraped at the same time when 0")
+
+	flag.Uint64Var(&app.MetricsGuestLowMemoryKB, "metrics-guest-low-memory-threshold-kb", domainstats.DefaultGuestLowMemoryThresholdKB,
+		"Guest usable memory, in kilobytes, under which the guest is counted as low on memory in the node metrics")
+
 	flag.IntVar(&app.consoleServerPort, "console-server-port", defaultConsoleServerPort,
 		"The port virt-handler listens on for console requests")
 
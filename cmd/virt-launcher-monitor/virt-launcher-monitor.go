@@ -40,6 +40,7 @@ import (
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/util"
+	exitstatus "kubevirt.io/kubevirt/pkg/virt-launcher/exit-status"
 )
 
 const (
@@ -189,16 +190,7 @@ func RunAndMonitor(containerDiskDir, uid string) (int, error) {
 		for sig := range sigs {
 			switch sig {
 			case syscall.SIGCHLD:
-				var wstatus syscall.WaitStatus
-				wpid, err := syscall.Wait4(-1, &wstatus, syscall.WNOHANG, nil)
-				if err != nil {
-					log.Log.Reason(err).Errorf("Failed to reap process %d", wpid)
-				}
-
-				log.Log.Infof("Reaped pid %d with status %d", wpid, int(wstatus))
-				if wpid == cmd.Process.Pid {
-					exitStatus <- wstatus.ExitStatus()
-				}
+				reapChildren(cmd.Process.Pid, exitStatus)
 
 			default:
 				log.Log.V(3).Log("signalling virt-launcher to shut down")
@@ -376,6 +368,39 @@ func istioProxyPresent(httpClient *http.Client) bool {
 	return err == nil
 }
 
+// reapChildren reaps the terminated children, among which are the orphans reparented to us like qemu.
+// How qemu ended is recorded for virt-launcher. qemu is told apart by its command name, which can only be
+// read before it is reaped.
+func reapChildren(launcherPid int, launcherExitStatus chan<- int) {
+	zombies, err := exitstatus.ZombieChildren(os.Getpid())
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to list the terminated children")
+		// still reap any child, not knowing whether it is qemu
+		zombies = map[int]string{-1: ""}
+	}
+
+	for pid, command := range zombies {
+		var wstatus syscall.WaitStatus
+		wpid, err := syscall.Wait4(pid, &wstatus, syscall.WNOHANG, nil)
+		if err != nil {
+			log.Log.Reason(err).Errorf("Failed to reap process %d", pid)
+			continue
+		}
+		if wpid <= 0 {
+			continue
+		}
+
+		log.Log.Infof("Reaped pid %d with status %d", wpid, int(wstatus))
+		if wpid == launcherPid {
+			launcherExitStatus <- wstatus.ExitStatus()
+		} else if exitstatus.IsQemu(command) {
+			if err := exitstatus.Write(exitstatus.ReapedPath(exitstatus.ReapedDir, wpid), exitstatus.FromWaitStatus(wstatus)); err != nil {
+				log.Log.Reason(err).Errorf("Failed to record the exit status of pid %d", wpid)
+			}
+		}
+	}
+}
+
 func findPid(commandNamePrefix string) (int, error) {
 	entries, err := filepath.Glob("/proc/*/cmdline")
 	if err != nil {
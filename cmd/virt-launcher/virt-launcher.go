@@ -50,6 +50,7 @@ import (
 	putil "kubevirt.io/kubevirt/pkg/util"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	virtlauncher "kubevirt.io/kubevirt/pkg/virt-launcher"
+	exitstatus "kubevirt.io/kubevirt/pkg/virt-launcher/exit-status"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/metadata"
 	notifyclient "kubevirt.io/kubevirt/pkg/virt-launcher/notify-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap"
@@ -343,6 +344,7 @@ func main() {
 	uid := pflag.String("uid", "", "UID of the VirtualMachineInstance")
 	namespace := pflag.String("namespace", "", "Namespace of the VirtualMachineInstance")
 	gracePeriodSeconds := pflag.Int("grace-period-seconds", 30, "Grace period to observe before sending SIGTERM to vmi process")
+	killGracePeriodSeconds := pflag.Int("kill-grace-period-seconds", 10, "Grace period to observe after the grace period expired before sending SIGKILL to vmi process")
 	allowEmulation := pflag.Bool("allow-emulation", false, "Allow use of software emulation as fallback")
 	runWithNonRoot := pflag.Bool("run-as-nonroot", false, "Run virtqemud with the 'virt' user")
 	hookSidecars := pflag.Uint("hook-sidecars", 0, "Number of requested hook sidecars, virt-launcher will wait for all of them to become available")
@@ -431,10 +433,19 @@ func main() {
 		panic(err)
 	}
 
+	var pidDir string
+	if *runWithNonRoot {
+		pidDir = "/run/libvirt/qemu/run"
+	} else {
+		pidDir = "/run/libvirt/qemu"
+	}
+
 	// Start the virt-launcher command service.
 	// Clients can use this service to tell virt-launcher
 	// to start/stop virtual machines
-	options := cmdserver.NewServerOptions(*allowEmulation)
+	options := cmdserver.NewServerOptions(*allowEmulation, func() (int, error) {
+		return virtlauncher.FindPid(domainName, pidDir)
+	})
 	cmdclient.SetBaseDir(*virtShareDir)
 	cmdServerDone := startCmdServer(cmdclient.UninitializedSocketOnGuest(), domainManager, stopChan, options)
 
@@ -481,21 +492,20 @@ func main() {
 
 	domain := waitForDomainUUID(*qemuTimeout, events, signalStopChan, domainManager)
 	if domain != nil {
-		var pidDir string
-		if *runWithNonRoot {
-			pidDir = "/run/libvirt/qemu/run"
-		} else {
-			pidDir = "/run/libvirt/qemu"
-		}
 		mon := virtlauncher.NewProcessMonitor(domainName,
 			pidDir,
+			filepath.Join(cmdclient.SocketsDirectory(), exitstatus.FileName),
+			filepath.Join(cmdclient.SocketsDirectory(), cmdclient.StandardLauncherHeartbeatFileName),
 			*gracePeriodSeconds,
+			*killGracePeriodSeconds,
 			finalShutdownCallback,
 			gracefulShutdownCallback)
 
 		// This is a wait loop that monitors the qemu pid. When the pid
 		// exits, the wait loop breaks.
-		mon.RunForever(*qemuTimeout, signalStopChan)
+		if status := mon.RunForever(*qemuTimeout, signalStopChan); status != nil && status.Failed() {
+			log.Log.Warningf("QEMU of domain %s did not exit cleanly: %s", domainName, status)
+		}
 
 		// Allow hooks to gracefully shutdown
 		hookManager.Shutdown()
@@ -25,17 +25,23 @@ import (
 	"fmt"
 	"sync"
 
+	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 
 	virtv1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 )
 
 type BaseControllerRefManager struct {
@@ -191,10 +197,84 @@ func (m *BaseControllerRefManager) ClaimObject(obj metav1.Object, match func(met
 	}
 }
 
+// claimObjects is the core shared by the typed claim methods. It runs ClaimObject on each of the
+// objects and returns the ones which are owned by the controller afterwards.
+func claimObjects[T metav1.Object](m *BaseControllerRefManager, objs []T, match func(T) bool, adopt, release func(T) error) ([]T, error) {
+	var claimed []T
+	var errlist []error
+
+	matchObject := func(obj metav1.Object) bool {
+		return match(obj.(T))
+	}
+	adoptObject := func(obj metav1.Object) error {
+		return adopt(obj.(T))
+	}
+	releaseObject := func(obj metav1.Object) error {
+		return release(obj.(T))
+	}
+
+	for _, obj := range objs {
+		ok, err := m.ClaimObject(obj, matchObject, adoptObject, releaseObject)
+		if err != nil {
+			errlist = append(errlist, err)
+			continue
+		}
+		if ok {
+			claimed = append(claimed, obj)
+		}
+	}
+	return claimed, utilerrors.NewAggregate(errlist)
+}
+
+// matchSelectorAndFilters matches the objects whose labels match the selector and which all the filters accept.
+func matchSelectorAndFilters[T metav1.Object](selector labels.Selector, filters []func(T) bool) func(T) bool {
+	return func(obj T) bool {
+		// Check selector first so filters only run on potentially matching objects.
+		if !selector.Matches(labels.Set(obj.GetLabels())) {
+			return false
+		}
+		for _, filter := range filters {
+			if !filter(obj) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+const (
+	// AdoptedReason is the reason of the event emitted on an object adopted by a controller
+	AdoptedReason = "Adopted"
+	// ReleasedReason is the reason of the event emitted on an object released by a controller
+	ReleasedReason = "Released"
+)
+
+// ControllerRefMetricsProvider counts the adoptions and releases of the ref managers, by kind of the owned object
+type ControllerRefMetricsProvider interface {
+	IncAdoptions(kind string)
+	IncReleases(kind string)
+}
+
+type noopControllerRefMetricsProvider struct{}
+
+func (noopControllerRefMetricsProvider) IncAdoptions(string) {}
+func (noopControllerRefMetricsProvider) IncReleases(string)  {}
+
+var controllerRefMetrics ControllerRefMetricsProvider = noopControllerRefMetricsProvider{}
+
+// SetControllerRefMetricsProvider sets the provider counting the adoptions and releases of all the ref managers
+func SetControllerRefMetricsProvider(provider ControllerRefMetricsProvider) {
+	controllerRefMetrics = provider
+}
+
+// releaseVirtualMachinesWorkers bounds the number of release patches sent in parallel by ReleaseVirtualMachines
+const releaseVirtualMachinesWorkers = 16
+
 type VirtualMachineControllerRefManager struct {
 	BaseControllerRefManager
 	controllerKind        schema.GroupVersionKind
 	virtualMachineControl VirtualMachineControlInterface
+	recorder              record.EventRecorder
 }
 
 // NewVirtualMachineControllerRefManager returns a VirtualMachineControllerRefManager that exposes
@@ -227,6 +307,26 @@ func NewVirtualMachineControllerRefManager(
 	}
 }
 
+// WithEventRecorder makes the manager emit an event on every object it adopts or releases
+func (m *VirtualMachineControllerRefManager) WithEventRecorder(recorder record.EventRecorder) *VirtualMachineControllerRefManager {
+	m.recorder = recorder
+	return m
+}
+
+func (m *VirtualMachineControllerRefManager) recordAdoption(obj runtime.Object, kind string) {
+	controllerRefMetrics.IncAdoptions(kind)
+	if m.recorder != nil {
+		m.recorder.Eventf(obj, k8sv1.EventTypeNormal, AdoptedReason, "Adopted by %s %s", m.controllerKind.Kind, m.Controller.GetName())
+	}
+}
+
+func (m *VirtualMachineControllerRefManager) recordRelease(obj runtime.Object, kind string) {
+	controllerRefMetrics.IncReleases(kind)
+	if m.recorder != nil {
+		m.recorder.Eventf(obj, k8sv1.EventTypeNormal, ReleasedReason, "Released by %s %s", m.controllerKind.Kind, m.Controller.GetName())
+	}
+}
+
 // ClaimVirtualMachineInstances tries to take ownership of a list of VirtualMachineInstances.
 //
 // It will reconcile the following:
@@ -243,40 +343,8 @@ func NewVirtualMachineControllerRefManager(
 // If the error is nil, either the reconciliation succeeded, or no
 // reconciliation was necessary. The list of VirtualMachines that you now own is returned.
 func (m *VirtualMachineControllerRefManager) ClaimVirtualMachineInstances(vmis []*virtv1.VirtualMachineInstance, filters ...func(machine *virtv1.VirtualMachineInstance) bool) ([]*virtv1.VirtualMachineInstance, error) {
-	var claimed []*virtv1.VirtualMachineInstance
-	var errlist []error
-
-	match := func(obj metav1.Object) bool {
-		vmi := obj.(*virtv1.VirtualMachineInstance)
-		// Check selector first so filters only run on potentially matching VirtualMachines.
-		if !m.Selector.Matches(labels.Set(vmi.Labels)) {
-			return false
-		}
-		for _, filter := range filters {
-			if !filter(vmi) {
-				return false
-			}
-		}
-		return true
-	}
-	adopt := func(obj metav1.Object) error {
-		return m.AdoptVirtualMachineInstance(obj.(*virtv1.VirtualMachineInstance))
-	}
-	release := func(obj metav1.Object) error {
-		return m.ReleaseVirtualMachineInstance(obj.(*virtv1.VirtualMachineInstance))
-	}
-
-	for _, vmi := range vmis {
-		ok, err := m.ClaimObject(vmi, match, adopt, release)
-		if err != nil {
-			errlist = append(errlist, err)
-			continue
-		}
-		if ok {
-			claimed = append(claimed, vmi)
-		}
-	}
-	return claimed, utilerrors.NewAggregate(errlist)
+	return claimObjects(&m.BaseControllerRefManager, vmis, matchSelectorAndFilters(m.Selector, filters),
+		m.AdoptVirtualMachineInstance, m.ReleaseVirtualMachineInstance)
 }
 
 // ReleaseDetachedVirtualMachines removes ownership of detached VMs.
@@ -284,13 +352,14 @@ func (m *VirtualMachineControllerRefManager) ClaimVirtualMachineInstances(vmis [
 // It will reconcile the following:
 //   - Release owned objects if the selector no longer matches.
 //
+// The detached VMs are released in parallel, see ReleaseVirtualMachines.
+//
 // List of Owned VMs is returned.
 func (m *VirtualMachineControllerRefManager) ReleaseDetachedVirtualMachines(vms []*virtv1.VirtualMachine, filters ...func(machine *virtv1.VirtualMachine) bool) ([]*virtv1.VirtualMachine, error) {
 	var owned []*virtv1.VirtualMachine
-	var errlist []error
+	var detached []*virtv1.VirtualMachine
 
-	match := func(obj metav1.Object) bool {
-		vm := obj.(*virtv1.VirtualMachine)
+	match := func(vm *virtv1.VirtualMachine) bool {
 		// Check selector first so filters only run on potentially matching VirtualMachines.
 		if !m.Selector.Matches(labels.Set(vm.Labels)) {
 			return false
@@ -302,21 +371,56 @@ func (m *VirtualMachineControllerRefManager) ReleaseDetachedVirtualMachines(vms
 		}
 		return true
 	}
-	release := func(obj metav1.Object) error {
-		return m.ReleaseVirtualMachine(obj.(*virtv1.VirtualMachine))
-	}
 
 	for _, vm := range vms {
-		isOwner, err := m.ReleaseDetachedObject(vm, match, release)
-		if err != nil {
-			errlist = append(errlist, err)
+		if !m.isOwned(vm) {
 			continue
 		}
-		if isOwner {
+		if match(vm) {
 			owned = append(owned, vm)
+			continue
+		}
+		// Try to release, unless we're being deleted.
+		if m.Controller.GetDeletionTimestamp() == nil {
+			detached = append(detached, vm)
 		}
 	}
-	return owned, utilerrors.NewAggregate(errlist)
+	return owned, m.ReleaseVirtualMachines(detached)
+}
+
+// ReleaseVirtualMachines releases the vms with at most releaseVirtualMachinesWorkers patches in flight.
+// A failing release doesn't stop the others, the errors of all the failed releases are aggregated.
+func (m *VirtualMachineControllerRefManager) ReleaseVirtualMachines(vms []*virtv1.VirtualMachine) error {
+	errs := make([]error, len(vms))
+	workqueue.ParallelizeUntil(context.Background(), releaseVirtualMachinesWorkers, len(vms), func(i int) {
+		if err := m.ReleaseVirtualMachine(vms[i]); err != nil {
+			errs[i] = fmt.Errorf("failed to release VirtualMachine %s/%s: %v", vms[i].Namespace, vms[i].Name, err)
+		}
+	})
+	return utilerrors.NewAggregate(errs)
+}
+
+// ClaimDataVolumes tries to take ownership of a list of DataVolumes.
+//
+// It will reconcile the following:
+//   - Adopt orphans if the selector matches.
+//   - Release owned objects if the selector no longer matches.
+//
+// Optional: If one or more filters are specified, a DataVolume will only be claimed if
+// all filters return true.
+//
+// Unlike ClaimMatchedDataVolumes, which expects DataVolumes that were already matched by
+// the caller, the DataVolumes are matched against the selector of the manager.
+//
+// A non-nil error is returned if some form of reconciliation was attempted and
+// failed. Usually, controllers should try again later in case reconciliation
+// is still needed.
+//
+// If the error is nil, either the reconciliation succeeded, or no
+// reconciliation was necessary. The list of DataVolumes that you now own is returned.
+func (m *VirtualMachineControllerRefManager) ClaimDataVolumes(dataVolumes []*cdiv1.DataVolume, filters ...func(dataVolume *cdiv1.DataVolume) bool) ([]*cdiv1.DataVolume, error) {
+	return claimObjects(&m.BaseControllerRefManager, dataVolumes, matchSelectorAndFilters(m.Selector, filters),
+		m.AdoptDataVolume, m.ReleaseDataVolume)
 }
 
 // ClaimMatchedDataVolumes tries to take ownership of a list of DataVolumes.
@@ -420,7 +524,11 @@ func (m *VirtualMachineControllerRefManager) AdoptVirtualMachineInstance(vmi *vi
 		`{"metadata":{"ownerReferences":[{"apiVersion":"%s","kind":"%s","name":"%s","uid":"%s","controller":true,"blockOwnerDeletion":true}],"uid":"%s"}}`,
 		m.controllerKind.GroupVersion(), m.controllerKind.Kind,
 		m.Controller.GetName(), m.Controller.GetUID(), vmi.UID)
-	return m.virtualMachineControl.PatchVirtualMachineInstance(vmi.Namespace, vmi.Name, []byte(addControllerPatch))
+	if err := m.virtualMachineControl.PatchVirtualMachineInstance(vmi.Namespace, vmi.Name, []byte(addControllerPatch)); err != nil {
+		return err
+	}
+	m.recordAdoption(vmi, "VirtualMachineInstance")
+	return nil
 }
 
 // ReleaseVirtualMachineInstance sends a patch to free the virtual machine from the control of the controller.
@@ -431,65 +539,96 @@ func (m *VirtualMachineControllerRefManager) ReleaseVirtualMachineInstance(vmi *
 	// TODO CRDs don't support strategic merge, therefore replace the onwerReferences list with a merge patch
 	deleteOwnerRefPatch := fmt.Sprint(`{"metadata":{"ownerReferences":[]}}`)
 	err := m.virtualMachineControl.PatchVirtualMachineInstance(vmi.Namespace, vmi.Name, []byte(deleteOwnerRefPatch))
-	if err != nil {
-		if errors.IsNotFound(err) {
-			// If the vmi no longer exists, ignore it.
-			return nil
-		}
-		if errors.IsInvalid(err) {
-			// Invalid error will be returned in two cases: 1. the vmi
-			// has no owner reference, 2. the uid of the vmi doesn't
-			// match, which means the vmi is deleted and then recreated.
-			// In both cases, the error can be ignored.
-
-			// TODO: If the vmi has owner references, but none of them
-			// has the owner.UID, server will silently ignore the patch.
-			// Investigate why.
-			return nil
-		}
+	if err == nil {
+		m.recordRelease(vmi, "VirtualMachineInstance")
+		return nil
+	}
+	if errors.IsNotFound(err) {
+		// If the vmi no longer exists, ignore it.
+		return nil
+	}
+	if errors.IsInvalid(err) {
+		// Invalid error will be returned in two cases: 1. the vmi
+		// has no owner reference, 2. the uid of the vmi doesn't
+		// match, which means the vmi is deleted and then recreated.
+		// In both cases, the error can be ignored.
+
+		// TODO: If the vmi has owner references, but none of them
+		// has the owner.UID, server will silently ignore the patch.
+		// Investigate why.
+		return nil
 	}
 	return err
 }
 
-// AdoptVirtualMachine sends a patch to take control of the vm. It returns the error if
-// the patching fails.
+// AdoptVirtualMachine sends a JSON patch adding the controllerRef to the vm. The patch is guarded by
+// a test on the vm UID, so a vm deleted and re-created with the same name is never adopted, and it
+// keeps the other ownerReferences of the vm. It returns the error if the patching fails.
 func (m *VirtualMachineControllerRefManager) AdoptVirtualMachine(vm *virtv1.VirtualMachine) error {
 	if err := m.CanAdopt(); err != nil {
 		return fmt.Errorf("can't adopt VirtualMachine %v/%v (%v): %v", vm.Namespace, vm.Name, vm.UID, err)
 	}
 	// Note that ValidateOwnerReferences() will reject this patch if another
 	// OwnerReference exists with controller=true.
-	addControllerPatch := fmt.Sprintf(
-		`{"metadata":{"ownerReferences":[{"apiVersion":"%s","kind":"%s","name":"%s","uid":"%s","controller":true,"blockOwnerDeletion":true}],"uid":"%s"}}`,
-		m.controllerKind.GroupVersion(), m.controllerKind.Kind,
-		m.Controller.GetName(), m.Controller.GetUID(), vm.UID)
-	return m.virtualMachineControl.PatchVirtualMachine(vm.Namespace, vm.Name, []byte(addControllerPatch))
+	controllerRef := metav1.NewControllerRef(m.Controller, m.controllerKind)
+
+	patchSet := patch.New(patch.WithTest("/metadata/uid", vm.UID))
+	if len(vm.OwnerReferences) == 0 {
+		patchSet.AddOption(patch.WithAdd("/metadata/ownerReferences", []metav1.OwnerReference{*controllerRef}))
+	} else {
+		patchSet.AddOption(patch.WithAdd("/metadata/ownerReferences/-", controllerRef))
+	}
+	addControllerPatch, err := patchSet.GeneratePayload()
+	if err != nil {
+		return err
+	}
+	if err := m.virtualMachineControl.PatchVirtualMachine(vm.Namespace, vm.Name, types.JSONPatchType, addControllerPatch); err != nil {
+		return err
+	}
+	m.recordAdoption(vm, "VirtualMachine")
+	return nil
 }
 
-// ReleaseVirtualMachine sends a patch to free the virtual machine from the control of the controller.
+// ReleaseVirtualMachine sends a JSON patch removing the controllerRef from the vm, leaving its other
+// ownerReferences untouched. The patch is guarded by tests on the vm UID and on the removed reference.
 // It returns the error if the patching fails. 404 and 422 errors are ignored.
 func (m *VirtualMachineControllerRefManager) ReleaseVirtualMachine(vm *virtv1.VirtualMachine) error {
+	refIndex := -1
+	for i, ref := range vm.OwnerReferences {
+		if ref.UID == m.Controller.GetUID() {
+			refIndex = i
+			break
+		}
+	}
+	if refIndex == -1 {
+		return nil
+	}
+
 	log.Log.V(2).Object(vm).Infof("patching vm to remove its controllerRef to %s/%s:%s",
 		m.controllerKind.GroupVersion(), m.controllerKind.Kind, m.Controller.GetName())
-	// TODO CRDs don't support strategic merge, therefore replace the onwerReferences list with a merge patch
-	deleteOwnerRefPatch := fmt.Sprint(`{"metadata":{"ownerReferences":[]}}`)
-	err := m.virtualMachineControl.PatchVirtualMachine(vm.Namespace, vm.Name, []byte(deleteOwnerRefPatch))
+	refPath := fmt.Sprintf("/metadata/ownerReferences/%d", refIndex)
+	deleteOwnerRefPatch, err := patch.New(
+		patch.WithTest("/metadata/uid", vm.UID),
+		patch.WithTest(refPath+"/uid", m.Controller.GetUID()),
+		patch.WithRemove(refPath),
+	).GeneratePayload()
 	if err != nil {
-		if errors.IsNotFound(err) {
-			// If the vm no longer exists, ignore it.
-			return nil
-		}
-		if errors.IsInvalid(err) {
-			// Invalid error will be returned in two cases: 1. the vm
-			// has no owner reference, 2. the uid of the vm doesn't
-			// match, which means the vm is deleted and then recreated.
-			// In both cases, the error can be ignored.
-
-			// TODO: If the vm has owner references, but none of them
-			// has the owner.UID, server will silently ignore the patch.
-			// Investigate why.
-			return nil
-		}
+		return err
+	}
+	err = m.virtualMachineControl.PatchVirtualMachine(vm.Namespace, vm.Name, types.JSONPatchType, deleteOwnerRefPatch)
+	if err == nil {
+		m.recordRelease(vm, "VirtualMachine")
+		return nil
+	}
+	if errors.IsNotFound(err) {
+		// If the vm no longer exists, ignore it.
+		return nil
+	}
+	if errors.IsInvalid(err) {
+		// Invalid error will be returned when one of the tests fails, which means
+		// the vm was re-created or its ownerReferences changed in the meantime.
+		// In both cases the error can be ignored, the next sync will see the new state.
+		return nil
 	}
 	return err
 }
@@ -506,7 +645,11 @@ func (m *VirtualMachineControllerRefManager) AdoptDataVolume(dataVolume *cdiv1.D
 		`{"metadata":{"ownerReferences":[{"apiVersion":"%s","kind":"%s","name":"%s","uid":"%s","controller":true,"blockOwnerDeletion":true}],"uid":"%s"}}`,
 		m.controllerKind.GroupVersion(), m.controllerKind.Kind,
 		m.Controller.GetName(), m.Controller.GetUID(), dataVolume.UID)
-	return m.virtualMachineControl.PatchDataVolume(dataVolume.Namespace, dataVolume.Name, []byte(addControllerPatch))
+	if err := m.virtualMachineControl.PatchDataVolume(dataVolume.Namespace, dataVolume.Name, []byte(addControllerPatch)); err != nil {
+		return err
+	}
+	m.recordAdoption(dataVolume, "DataVolume")
+	return nil
 }
 
 // ReleaseDataVolume sends a patch to free the dataVolume from the control of the controller.
@@ -517,28 +660,117 @@ func (m *VirtualMachineControllerRefManager) ReleaseDataVolume(dataVolume *cdiv1
 	// TODO CRDs don't support strategic merge, therefore replace the onwerReferences list with a merge patch
 	deleteOwnerRefPatch := fmt.Sprint(`{"metadata":{"ownerReferences":[]}}`)
 	err := m.virtualMachineControl.PatchDataVolume(dataVolume.Namespace, dataVolume.Name, []byte(deleteOwnerRefPatch))
-	if err != nil {
-		if errors.IsNotFound(err) {
-			// If no longer exists, ignore it.
-			return nil
-		}
-		if errors.IsInvalid(err) {
-			// Invalid error will be returned in two cases: 1. the dataVolume
-			// has no owner reference, 2. the uid of the dataVolume doesn't
-			// match, which means the dataVolume is deleted and then recreated.
-			// In both cases, the error can be ignored.
-
-			// TODO: If the dataVolume has owner references, but none of them
-			// has the owner.UID, server will silently ignore the patch.
-			// Investigate why.
-			return nil
-		}
+	if err == nil {
+		m.recordRelease(dataVolume, "DataVolume")
+		return nil
+	}
+	if errors.IsNotFound(err) {
+		// If no longer exists, ignore it.
+		return nil
+	}
+	if errors.IsInvalid(err) {
+		// Invalid error will be returned in two cases: 1. the dataVolume
+		// has no owner reference, 2. the uid of the dataVolume doesn't
+		// match, which means the dataVolume is deleted and then recreated.
+		// In both cases, the error can be ignored.
+
+		// TODO: If the dataVolume has owner references, but none of them
+		// has the owner.UID, server will silently ignore the patch.
+		// Investigate why.
+		return nil
 	}
 	return err
 }
 
+// VirtualMachineInstanceControllerRefManager manages the controllerRef of the VirtualMachineInstances
+// owned directly by a controller of any kind. It shares its claim semantics with
+// VirtualMachineControllerRefManager.ClaimVirtualMachineInstances.
+type VirtualMachineInstanceControllerRefManager struct {
+	refManager *VirtualMachineControllerRefManager
+}
+
+// NewVirtualMachineInstanceControllerRefManager returns a VirtualMachineInstanceControllerRefManager.
+// canAdopt is called at most once, before the first adoption, see NewVirtualMachineControllerRefManager.
+func NewVirtualMachineInstanceControllerRefManager(
+	virtualMachineControl VirtualMachineControlInterface,
+	controller metav1.Object,
+	selector labels.Selector,
+	controllerKind schema.GroupVersionKind,
+	canAdopt func() error,
+) *VirtualMachineInstanceControllerRefManager {
+	return &VirtualMachineInstanceControllerRefManager{
+		refManager: NewVirtualMachineControllerRefManager(virtualMachineControl, controller, selector, controllerKind, canAdopt),
+	}
+}
+
+// WithEventRecorder makes the manager emit an event on every VirtualMachineInstance it adopts or releases
+func (m *VirtualMachineInstanceControllerRefManager) WithEventRecorder(recorder record.EventRecorder) *VirtualMachineInstanceControllerRefManager {
+	m.refManager.WithEventRecorder(recorder)
+	return m
+}
+
+// ClaimVirtualMachineInstances adopts the orphaned VirtualMachineInstances which match the selector and
+// all the filters, and releases the owned ones which don't match anymore. Nothing is adopted while
+// the controller or the VirtualMachineInstance is being deleted. The owned VirtualMachineInstances are returned.
+func (m *VirtualMachineInstanceControllerRefManager) ClaimVirtualMachineInstances(vmis []*virtv1.VirtualMachineInstance, filters ...func(vmi *virtv1.VirtualMachineInstance) bool) ([]*virtv1.VirtualMachineInstance, error) {
+	return m.refManager.ClaimVirtualMachineInstances(vmis, filters...)
+}
+
+// AdoptVirtualMachineInstance sends a patch to take control of the vmi.
+func (m *VirtualMachineInstanceControllerRefManager) AdoptVirtualMachineInstance(vmi *virtv1.VirtualMachineInstance) error {
+	return m.refManager.AdoptVirtualMachineInstance(vmi)
+}
+
+// ReleaseVirtualMachineInstance sends a patch to free the vmi from the control of the controller.
+func (m *VirtualMachineInstanceControllerRefManager) ReleaseVirtualMachineInstance(vmi *virtv1.VirtualMachineInstance) error {
+	return m.refManager.ReleaseVirtualMachineInstance(vmi)
+}
+
+// DataVolumeControllerRefManager manages the controllerRef of the DataVolumes owned by a controller
+// of any kind. It shares its claim semantics with VirtualMachineControllerRefManager.ClaimDataVolumes.
+type DataVolumeControllerRefManager struct {
+	refManager *VirtualMachineControllerRefManager
+}
+
+// NewDataVolumeControllerRefManager returns a DataVolumeControllerRefManager.
+// canAdopt is called at most once, before the first adoption, see NewVirtualMachineControllerRefManager.
+func NewDataVolumeControllerRefManager(
+	virtualMachineControl VirtualMachineControlInterface,
+	controller metav1.Object,
+	selector labels.Selector,
+	controllerKind schema.GroupVersionKind,
+	canAdopt func() error,
+) *DataVolumeControllerRefManager {
+	return &DataVolumeControllerRefManager{
+		refManager: NewVirtualMachineControllerRefManager(virtualMachineControl, controller, selector, controllerKind, canAdopt),
+	}
+}
+
+// WithEventRecorder makes the manager emit an event on every DataVolume it adopts or releases
+func (m *DataVolumeControllerRefManager) WithEventRecorder(recorder record.EventRecorder) *DataVolumeControllerRefManager {
+	m.refManager.WithEventRecorder(recorder)
+	return m
+}
+
+// ClaimDataVolumes adopts the orphaned DataVolumes which match the selector and all the filters, and
+// releases the owned ones which don't match anymore. Nothing is adopted while the controller or the
+// DataVolume is being deleted. The owned DataVolumes are returned.
+func (m *DataVolumeControllerRefManager) ClaimDataVolumes(dataVolumes []*cdiv1.DataVolume, filters ...func(dataVolume *cdiv1.DataVolume) bool) ([]*cdiv1.DataVolume, error) {
+	return m.refManager.ClaimDataVolumes(dataVolumes, filters...)
+}
+
+// AdoptDataVolume sends a patch to take control of the dataVolume.
+func (m *DataVolumeControllerRefManager) AdoptDataVolume(dataVolume *cdiv1.DataVolume) error {
+	return m.refManager.AdoptDataVolume(dataVolume)
+}
+
+// ReleaseDataVolume sends a patch to free the dataVolume from the control of the controller.
+func (m *DataVolumeControllerRefManager) ReleaseDataVolume(dataVolume *cdiv1.DataVolume) error {
+	return m.refManager.ReleaseDataVolume(dataVolume)
+}
+
 type VirtualMachineControlInterface interface {
-	PatchVirtualMachine(namespace, name string, data []byte) error
+	PatchVirtualMachine(namespace, name string, patchType types.PatchType, data []byte) error
 	PatchVirtualMachineInstance(namespace, name string, data []byte) error
 	PatchDataVolume(namespace, name string, data []byte) error
 }
@@ -553,9 +785,8 @@ func (r RealVirtualMachineControl) PatchVirtualMachineInstance(namespace, name s
 	return err
 }
 
-func (r RealVirtualMachineControl) PatchVirtualMachine(namespace, name string, data []byte) error {
-	// TODO should be a strategic merge patch, but not possible until https://github.com/kubernetes/kubernetes/issues/56348 is resolved
-	_, err := r.Clientset.VirtualMachine(namespace).Patch(context.Background(), name, types.MergePatchType, data, metav1.PatchOptions{})
+func (r RealVirtualMachineControl) PatchVirtualMachine(namespace, name string, patchType types.PatchType, data []byte) error {
+	_, err := r.Clientset.VirtualMachine(namespace).Patch(context.Background(), name, patchType, data, metav1.PatchOptions{})
 	return err
 }
 
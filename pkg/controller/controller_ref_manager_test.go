@@ -18,16 +18,20 @@ limitations under the License.
 package controller
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 
 	virtv1 "kubevirt.io/api/core/v1"
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
@@ -173,9 +177,50 @@ func TestClaimVirtualMachineInstance(t *testing.T) {
 				claimed:         []*virtv1.VirtualMachineInstance{virtualmachineToDelete1},
 			}
 		}(),
+		func() test {
+			controller := v1.ReplicationController{}
+			controller.UID = types.UID(controllerUID)
+			finalVirtualMachine := newVirtualMachine("virtualmachine2", productionLabel, nil)
+			finalVirtualMachine.Status.Phase = virtv1.Succeeded
+
+			return test{
+				name: "Controller does not claim orphaned virtualmachines rejected by a filter",
+				manager: NewVirtualMachineControllerRefManager(&FakeVirtualMachineControl{},
+					&controller,
+					productionLabelSelector,
+					controllerKind,
+					func() error { return nil }),
+				virtualmachines: []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, nil), finalVirtualMachine},
+				filters: []func(*virtv1.VirtualMachineInstance) bool{
+					func(vmi *virtv1.VirtualMachineInstance) bool { return !vmi.IsFinal() },
+				},
+				claimed: []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, nil)},
+			}
+		}(),
+		func() test {
+			controller := v1.ReplicationController{}
+			controller.UID = types.UID(controllerUID)
+			ignoredVirtualMachine := newVirtualMachine("virtualmachine2", productionLabel, &controller)
+			ignoredVirtualMachine.Annotations = map[string]string{"ignore": "true"}
+
+			return test{
+				name: "Controller releases claimed virtualmachines rejected by a filter",
+				manager: NewVirtualMachineControllerRefManager(&FakeVirtualMachineControl{},
+					&controller,
+					productionLabelSelector,
+					controllerKind,
+					func() error { return nil }),
+				virtualmachines: []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, &controller), ignoredVirtualMachine},
+				filters: []func(*virtv1.VirtualMachineInstance) bool{
+					func(vmi *virtv1.VirtualMachineInstance) bool { return !vmi.IsFinal() },
+					func(vmi *virtv1.VirtualMachineInstance) bool { return vmi.Annotations["ignore"] != "true" },
+				},
+				claimed: []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, &controller)},
+			}
+		}(),
 	}
 	for _, test := range tests {
-		claimed, err := test.manager.ClaimVirtualMachineInstances(test.virtualmachines)
+		claimed, err := test.manager.ClaimVirtualMachineInstances(test.virtualmachines, test.filters...)
 		if test.expectError && err == nil {
 			t.Errorf("Test case `%s`, expected error but got nil", test.name)
 		} else if !equality.Semantic.DeepEqual(test.claimed, claimed) {
@@ -185,6 +230,267 @@ func TestClaimVirtualMachineInstance(t *testing.T) {
 	}
 }
 
+func newOwnedVirtualMachine(owners ...metav1.OwnerReference) *virtv1.VirtualMachine {
+	return &virtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "vm",
+			Namespace:       metav1.NamespaceDefault,
+			UID:             "vm-uid",
+			OwnerReferences: owners,
+		},
+	}
+}
+
+func TestAdoptVirtualMachine(t *testing.T) {
+	controllerKind := v1beta1.SchemeGroupVersion.WithKind("Fake")
+	controller := v1.ReplicationController{}
+	controller.Name = "Fake"
+	controller.UID = types.UID(controllerUID)
+	otherRef := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"}
+
+	tests := []struct {
+		name          string
+		vm            *virtv1.VirtualMachine
+		expectedPatch string
+	}{
+		{
+			name:          "orphan without ownerReferences",
+			vm:            newOwnedVirtualMachine(),
+			expectedPatch: `[{"op":"test","path":"/metadata/uid","value":"vm-uid"},{"op":"add","path":"/metadata/ownerReferences","value":[{"apiVersion":"extensions/v1beta1","kind":"Fake","name":"Fake","uid":"123","controller":true,"blockOwnerDeletion":true}]}]`,
+		},
+		{
+			name:          "orphan with another non controller owner",
+			vm:            newOwnedVirtualMachine(otherRef),
+			expectedPatch: `[{"op":"test","path":"/metadata/uid","value":"vm-uid"},{"op":"add","path":"/metadata/ownerReferences/-","value":{"apiVersion":"extensions/v1beta1","kind":"Fake","name":"Fake","uid":"123","controller":true,"blockOwnerDeletion":true}}]`,
+		},
+	}
+	for _, test := range tests {
+		vmControl := &FakeVirtualMachineControl{}
+		manager := NewVirtualMachineControllerRefManager(vmControl, &controller, productionLabelSelector, controllerKind, func() error { return nil })
+
+		if err := manager.AdoptVirtualMachine(test.vm); err != nil {
+			t.Errorf("Test case `%s`, unexpected error: %v", test.name, err)
+		}
+		if len(vmControl.Patches) != 1 || string(vmControl.Patches[0]) != test.expectedPatch {
+			t.Errorf("Test case `%s`, expected patch %s, got %s", test.name, test.expectedPatch, vmControl.Patches)
+		}
+	}
+}
+
+func TestReleaseVirtualMachine(t *testing.T) {
+	controllerKind := v1beta1.SchemeGroupVersion.WithKind("Fake")
+	controller := v1.ReplicationController{}
+	controller.Name = "Fake"
+	controller.UID = types.UID(controllerUID)
+	otherRef := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"}
+
+	tests := []struct {
+		name          string
+		vm            *virtv1.VirtualMachine
+		expectedPatch string
+	}{
+		{
+			name:          "vm owned only by the controller",
+			vm:            newOwnedVirtualMachine(*newControllerRef(&controller)),
+			expectedPatch: `[{"op":"test","path":"/metadata/uid","value":"vm-uid"},{"op":"test","path":"/metadata/ownerReferences/0/uid","value":"123"},{"op":"remove","path":"/metadata/ownerReferences/0"}]`,
+		},
+		{
+			name:          "vm with another owner",
+			vm:            newOwnedVirtualMachine(otherRef, *newControllerRef(&controller)),
+			expectedPatch: `[{"op":"test","path":"/metadata/uid","value":"vm-uid"},{"op":"test","path":"/metadata/ownerReferences/1/uid","value":"123"},{"op":"remove","path":"/metadata/ownerReferences/1"}]`,
+		},
+		{
+			name: "vm not owned by the controller",
+			vm:   newOwnedVirtualMachine(otherRef),
+		},
+	}
+	for _, test := range tests {
+		vmControl := &FakeVirtualMachineControl{}
+		manager := NewVirtualMachineControllerRefManager(vmControl, &controller, productionLabelSelector, controllerKind, func() error { return nil })
+
+		if err := manager.ReleaseVirtualMachine(test.vm); err != nil {
+			t.Errorf("Test case `%s`, unexpected error: %v", test.name, err)
+		}
+		if test.expectedPatch == "" {
+			if len(vmControl.Patches) != 0 {
+				t.Errorf("Test case `%s`, expected no patch, got %s", test.name, vmControl.Patches)
+			}
+			continue
+		}
+		if len(vmControl.Patches) != 1 || string(vmControl.Patches[0]) != test.expectedPatch {
+			t.Errorf("Test case `%s`, expected patch %s, got %s", test.name, test.expectedPatch, vmControl.Patches)
+		}
+	}
+}
+
+func newOwnedVirtualMachines(count int, owner metav1.Object) []*virtv1.VirtualMachine {
+	var vms []*virtv1.VirtualMachine
+	for i := 0; i < count; i++ {
+		vm := newOwnedVirtualMachine(*newControllerRef(owner))
+		vm.Name = fmt.Sprintf("vm-%d", i)
+		vm.UID = types.UID(vm.Name + "-uid")
+		vms = append(vms, vm)
+	}
+	return vms
+}
+
+func TestReleaseVirtualMachines(t *testing.T) {
+	controllerKind := v1beta1.SchemeGroupVersion.WithKind("Fake")
+	controller := v1.ReplicationController{}
+	controller.Name = "Fake"
+	controller.UID = types.UID(controllerUID)
+
+	tests := []struct {
+		name          string
+		failing       []string
+		expectedError string
+	}{
+		{
+			name: "all releases succeed",
+		},
+		{
+			name:          "one failing release",
+			failing:       []string{"vm-7"},
+			expectedError: "failed to release VirtualMachine default/vm-7: patch failed",
+		},
+		{
+			name:          "several failing releases",
+			failing:       []string{"vm-3", "vm-42"},
+			expectedError: "[failed to release VirtualMachine default/vm-3: patch failed, failed to release VirtualMachine default/vm-42: patch failed]",
+		},
+	}
+	for _, test := range tests {
+		vms := newOwnedVirtualMachines(100, &controller)
+		vmControl := &FakeVirtualMachineControl{VirtualMachineErrs: map[string]error{}}
+		for _, name := range test.failing {
+			vmControl.VirtualMachineErrs[name] = fmt.Errorf("patch failed")
+		}
+		manager := NewVirtualMachineControllerRefManager(vmControl, &controller, productionLabelSelector, controllerKind, func() error { return nil })
+
+		err := manager.ReleaseVirtualMachines(vms)
+		if test.expectedError == "" && err != nil {
+			t.Errorf("Test case `%s`, unexpected error: %v", test.name, err)
+		} else if test.expectedError != "" && (err == nil || err.Error() != test.expectedError) {
+			t.Errorf("Test case `%s`, expected error %q, got %v", test.name, test.expectedError, err)
+		}
+
+		patched := sets.New[string](vmControl.PatchedVirtualMachines...)
+		if len(vmControl.PatchedVirtualMachines) != len(vms) || patched.Len() != len(vms) {
+			t.Errorf("Test case `%s`, expected every vm to be patched once, got %v", test.name, vmControl.PatchedVirtualMachines)
+		}
+	}
+}
+
+func TestReleaseDetachedVirtualMachines(t *testing.T) {
+	controllerKind := v1beta1.SchemeGroupVersion.WithKind("Fake")
+	controller := v1.ReplicationController{}
+	controller.Name = "Fake"
+	controller.UID = types.UID(controllerUID)
+
+	vms := newOwnedVirtualMachines(10, &controller)
+	for _, vm := range vms[:4] {
+		vm.Labels = productionLabel
+	}
+	orphan := newOwnedVirtualMachine()
+	orphan.Name = "orphan"
+	vms = append(vms, orphan)
+
+	vmControl := &FakeVirtualMachineControl{VirtualMachineErrs: map[string]error{"vm-5": fmt.Errorf("patch failed")}}
+	manager := NewVirtualMachineControllerRefManager(vmControl, &controller, productionLabelSelector, controllerKind, func() error { return nil })
+
+	owned, err := manager.ReleaseDetachedVirtualMachines(vms)
+	if err == nil || err.Error() != "failed to release VirtualMachine default/vm-5: patch failed" {
+		t.Errorf("expected the failed release of vm-5 to be reported, got %v", err)
+	}
+	if !equality.Semantic.DeepEqual(owned, vms[:4]) {
+		t.Errorf("expected the matching vms to stay owned, got %v", owned)
+	}
+	patched := sets.New[string](vmControl.PatchedVirtualMachines...)
+	if !patched.Equal(sets.New[string]("vm-4", "vm-5", "vm-6", "vm-7", "vm-8", "vm-9")) {
+		t.Errorf("expected only the detached vms to be released, got %v", vmControl.PatchedVirtualMachines)
+	}
+}
+
+type fakeControllerRefMetricsProvider struct {
+	adoptions map[string]int
+	releases  map[string]int
+}
+
+func (f *fakeControllerRefMetricsProvider) IncAdoptions(kind string) {
+	f.adoptions[kind]++
+}
+
+func (f *fakeControllerRefMetricsProvider) IncReleases(kind string) {
+	f.releases[kind]++
+}
+
+func TestRecordAdoptionAndRelease(t *testing.T) {
+	metricsProvider := &fakeControllerRefMetricsProvider{adoptions: map[string]int{}, releases: map[string]int{}}
+	SetControllerRefMetricsProvider(metricsProvider)
+	defer SetControllerRefMetricsProvider(noopControllerRefMetricsProvider{})
+
+	controllerKind := v1beta1.SchemeGroupVersion.WithKind("Fake")
+	controller := v1.ReplicationController{}
+	controller.Name = "Fake"
+	controller.UID = types.UID(controllerUID)
+	recorder := record.NewFakeRecorder(10)
+	manager := NewVirtualMachineControllerRefManager(&FakeVirtualMachineControl{}, &controller, productionLabelSelector, controllerKind, func() error { return nil }).
+		WithEventRecorder(recorder)
+
+	if err := manager.AdoptVirtualMachine(newOwnedVirtualMachine()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := manager.ReleaseVirtualMachine(newOwnedVirtualMachine(*newControllerRef(&controller))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := manager.AdoptDataVolume(newDataVolume("datavolume1", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedEvents := []string{
+		"Normal Adopted Adopted by Fake Fake",
+		"Normal Released Released by Fake Fake",
+		"Normal Adopted Adopted by Fake Fake",
+	}
+	for _, expected := range expectedEvents {
+		select {
+		case event := <-recorder.Events:
+			if event != expected {
+				t.Errorf("expected event %q, got %q", expected, event)
+			}
+		default:
+			t.Errorf("expected event %q, got none", expected)
+		}
+	}
+
+	expectedAdoptions := map[string]int{"VirtualMachine": 1, "DataVolume": 1}
+	expectedReleases := map[string]int{"VirtualMachine": 1}
+	if !equality.Semantic.DeepEqual(metricsProvider.adoptions, expectedAdoptions) {
+		t.Errorf("expected adoptions %v, got %v", expectedAdoptions, metricsProvider.adoptions)
+	}
+	if !equality.Semantic.DeepEqual(metricsProvider.releases, expectedReleases) {
+		t.Errorf("expected releases %v, got %v", expectedReleases, metricsProvider.releases)
+	}
+}
+
+func TestReleaseFailureIsNotRecorded(t *testing.T) {
+	controllerKind := v1beta1.SchemeGroupVersion.WithKind("Fake")
+	controller := v1.ReplicationController{}
+	controller.Name = "Fake"
+	controller.UID = types.UID(controllerUID)
+	recorder := record.NewFakeRecorder(10)
+	vmControl := &FakeVirtualMachineControl{Err: errors.NewNotFound(schema.GroupResource{Resource: "virtualmachines"}, "vm")}
+	manager := NewVirtualMachineControllerRefManager(vmControl, &controller, productionLabelSelector, controllerKind, func() error { return nil }).
+		WithEventRecorder(recorder)
+
+	if err := manager.ReleaseVirtualMachine(newOwnedVirtualMachine(*newControllerRef(&controller))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no event, got %q", <-recorder.Events)
+	}
+}
+
 func newDataVolume(name string, owner metav1.Object) *cdiv1.DataVolume {
 	dataVolume := &cdiv1.DataVolume{
 		ObjectMeta: metav1.ObjectMeta{
@@ -291,6 +597,281 @@ func TestClaimDataVolume(t *testing.T) {
 	}
 }
 
+func newLabeledDataVolume(name string, label map[string]string, owner metav1.Object) *cdiv1.DataVolume {
+	dataVolume := newDataVolume(name, owner)
+	dataVolume.Labels = label
+	return dataVolume
+}
+
+func TestClaimDataVolumesWithSelector(t *testing.T) {
+	controllerKind := schema.GroupVersionKind{}
+	type test struct {
+		name            string
+		manager         *VirtualMachineControllerRefManager
+		datavolumes     []*cdiv1.DataVolume
+		filters         []func(*cdiv1.DataVolume) bool
+		claimed         []*cdiv1.DataVolume
+		expectedPatches int
+	}
+	var tests = []test{
+		{
+			name: "Claim datavolumes with correct label",
+			manager: NewVirtualMachineControllerRefManager(&FakeVirtualMachineControl{},
+				&v1.ReplicationController{},
+				productionLabelSelector,
+				controllerKind,
+				func() error { return nil }),
+			datavolumes:     []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, nil), newLabeledDataVolume("datavolume2", testLabel, nil)},
+			claimed:         []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, nil)},
+			expectedPatches: 1,
+		},
+		{
+			name: "Claim only datavolumes accepted by the filters",
+			manager: NewVirtualMachineControllerRefManager(&FakeVirtualMachineControl{},
+				&v1.ReplicationController{},
+				productionLabelSelector,
+				controllerKind,
+				func() error { return nil }),
+			datavolumes: []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, nil), newLabeledDataVolume("datavolume2", productionLabel, nil)},
+			filters: []func(*cdiv1.DataVolume) bool{
+				func(dataVolume *cdiv1.DataVolume) bool { return dataVolume.Name == "datavolume2" },
+			},
+			claimed:         []*cdiv1.DataVolume{newLabeledDataVolume("datavolume2", productionLabel, nil)},
+			expectedPatches: 1,
+		},
+		func() test {
+			controller := v1.ReplicationController{}
+			controller.UID = types.UID(controllerUID)
+			now := metav1.Now()
+			controller.DeletionTimestamp = &now
+			return test{
+				name: "Controller marked for deletion can not claim new datavolumes",
+				manager: NewVirtualMachineControllerRefManager(&FakeVirtualMachineControl{},
+					&controller,
+					productionLabelSelector,
+					controllerKind,
+					func() error { return nil }),
+				datavolumes: []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller), newLabeledDataVolume("datavolume2", productionLabel, nil)},
+				claimed:     []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller)},
+			}
+		}(),
+		func() test {
+			controller := v1.ReplicationController{}
+			controller2 := v1.ReplicationController{}
+			controller.UID = types.UID(controllerUID)
+			controller2.UID = types.UID("AAAAA")
+			return test{
+				name: "Controller can not claim datavolumes owned by another controller",
+				manager: NewVirtualMachineControllerRefManager(&FakeVirtualMachineControl{},
+					&controller,
+					productionLabelSelector,
+					controllerKind,
+					func() error { return nil }),
+				datavolumes: []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller), newLabeledDataVolume("datavolume2", productionLabel, &controller2)},
+				claimed:     []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller)},
+			}
+		}(),
+		func() test {
+			controller := v1.ReplicationController{}
+			controller.UID = types.UID(controllerUID)
+			return test{
+				name: "Controller releases claimed datavolumes when selector doesn't match",
+				manager: NewVirtualMachineControllerRefManager(&FakeVirtualMachineControl{},
+					&controller,
+					productionLabelSelector,
+					controllerKind,
+					func() error { return nil }),
+				datavolumes:     []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller), newLabeledDataVolume("datavolume2", testLabel, &controller)},
+				claimed:         []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller)},
+				expectedPatches: 1,
+			}
+		}(),
+		func() test {
+			controller := v1.ReplicationController{}
+			controller.UID = types.UID(controllerUID)
+			datavolumeToDelete1 := newLabeledDataVolume("datavolume1", productionLabel, &controller)
+			datavolumeToDelete2 := newLabeledDataVolume("datavolume2", productionLabel, nil)
+			now := metav1.Now()
+			datavolumeToDelete1.DeletionTimestamp = &now
+			datavolumeToDelete2.DeletionTimestamp = &now
+
+			return test{
+				name: "Controller does not claim orphaned datavolumes marked for deletion",
+				manager: NewVirtualMachineControllerRefManager(&FakeVirtualMachineControl{},
+					&controller,
+					productionLabelSelector,
+					controllerKind,
+					func() error { return nil }),
+				datavolumes: []*cdiv1.DataVolume{datavolumeToDelete1, datavolumeToDelete2},
+				claimed:     []*cdiv1.DataVolume{datavolumeToDelete1},
+			}
+		}(),
+	}
+	for _, test := range tests {
+		claimed, err := test.manager.ClaimDataVolumes(test.datavolumes, test.filters...)
+		if err != nil {
+			t.Errorf("Test case `%s`, unexpected error: %v", test.name, err)
+		} else if !equality.Semantic.DeepEqual(test.claimed, claimed) {
+			t.Errorf("Test case `%s`, claimed wrong datavolumes. Expected %v, got %v", test.name, datavolumeToStringSlice(test.claimed), datavolumeToStringSlice(claimed))
+		}
+		if patches := test.manager.virtualMachineControl.(*FakeVirtualMachineControl).Patches; len(patches) != test.expectedPatches {
+			t.Errorf("Test case `%s`, expected %d patches, got %d", test.name, test.expectedPatches, len(patches))
+		}
+	}
+}
+
+func TestVirtualMachineInstanceControllerRefManager(t *testing.T) {
+	controller := v1.ReplicationController{}
+	controller.UID = types.UID(controllerUID)
+	otherController := v1.ReplicationController{}
+	otherController.UID = types.UID("AAAAA")
+	deletedController := controller
+	now := metav1.Now()
+	deletedController.DeletionTimestamp = &now
+	deletedVMI := newVirtualMachine("virtualmachine2", productionLabel, nil)
+	deletedVMI.DeletionTimestamp = &now
+
+	var tests = []struct {
+		name            string
+		controller      metav1.Object
+		vmis            []*virtv1.VirtualMachineInstance
+		filters         []func(*virtv1.VirtualMachineInstance) bool
+		claimed         []*virtv1.VirtualMachineInstance
+		expectedPatches int
+	}{
+		{
+			name:            "Claim orphaned vmis with correct label",
+			controller:      &controller,
+			vmis:            []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, nil), newVirtualMachine("virtualmachine2", testLabel, nil)},
+			claimed:         []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, nil)},
+			expectedPatches: 1,
+		},
+		{
+			name:       "Claim only vmis accepted by the filters",
+			controller: &controller,
+			vmis:       []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, nil), newVirtualMachine("virtualmachine2", productionLabel, nil)},
+			filters: []func(*virtv1.VirtualMachineInstance) bool{
+				func(vmi *virtv1.VirtualMachineInstance) bool { return vmi.Name == "virtualmachine2" },
+			},
+			claimed:         []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine2", productionLabel, nil)},
+			expectedPatches: 1,
+		},
+		{
+			name:       "Controller marked for deletion can not claim new vmis",
+			controller: &deletedController,
+			vmis:       []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, &controller), newVirtualMachine("virtualmachine2", productionLabel, nil)},
+			claimed:    []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, &controller)},
+		},
+		{
+			name:       "Controller can not claim vmis owned by another controller",
+			controller: &controller,
+			vmis:       []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, &controller), newVirtualMachine("virtualmachine2", productionLabel, &otherController)},
+			claimed:    []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, &controller)},
+		},
+		{
+			name:            "Controller releases claimed vmis when selector doesn't match",
+			controller:      &controller,
+			vmis:            []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, &controller), newVirtualMachine("virtualmachine2", testLabel, &controller)},
+			claimed:         []*virtv1.VirtualMachineInstance{newVirtualMachine("virtualmachine1", productionLabel, &controller)},
+			expectedPatches: 1,
+		},
+		{
+			name:       "Controller does not claim orphaned vmis marked for deletion",
+			controller: &controller,
+			vmis:       []*virtv1.VirtualMachineInstance{deletedVMI},
+		},
+	}
+	for _, test := range tests {
+		control := &FakeVirtualMachineControl{}
+		manager := NewVirtualMachineInstanceControllerRefManager(control, test.controller, productionLabelSelector, schema.GroupVersionKind{}, func() error { return nil })
+		claimed, err := manager.ClaimVirtualMachineInstances(test.vmis, test.filters...)
+		if err != nil {
+			t.Errorf("Test case `%s`, unexpected error: %v", test.name, err)
+		} else if !equality.Semantic.DeepEqual(test.claimed, claimed) {
+			t.Errorf("Test case `%s`, claimed wrong vmis. Expected %v, got %v", test.name, virtualmachineToStringSlice(test.claimed), virtualmachineToStringSlice(claimed))
+		}
+		if len(control.Patches) != test.expectedPatches {
+			t.Errorf("Test case `%s`, expected %d patches, got %d", test.name, test.expectedPatches, len(control.Patches))
+		}
+	}
+}
+
+func TestDataVolumeControllerRefManager(t *testing.T) {
+	controller := v1.ReplicationController{}
+	controller.UID = types.UID(controllerUID)
+	otherController := v1.ReplicationController{}
+	otherController.UID = types.UID("AAAAA")
+	deletedController := controller
+	now := metav1.Now()
+	deletedController.DeletionTimestamp = &now
+	deletedDataVolume := newLabeledDataVolume("datavolume2", productionLabel, nil)
+	deletedDataVolume.DeletionTimestamp = &now
+
+	var tests = []struct {
+		name            string
+		controller      metav1.Object
+		datavolumes     []*cdiv1.DataVolume
+		filters         []func(*cdiv1.DataVolume) bool
+		claimed         []*cdiv1.DataVolume
+		expectedPatches int
+	}{
+		{
+			name:            "Claim orphaned datavolumes with correct label",
+			controller:      &controller,
+			datavolumes:     []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, nil), newLabeledDataVolume("datavolume2", testLabel, nil)},
+			claimed:         []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, nil)},
+			expectedPatches: 1,
+		},
+		{
+			name:        "Claim only datavolumes accepted by the filters",
+			controller:  &controller,
+			datavolumes: []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, nil), newLabeledDataVolume("datavolume2", productionLabel, nil)},
+			filters: []func(*cdiv1.DataVolume) bool{
+				func(dataVolume *cdiv1.DataVolume) bool { return dataVolume.Name == "datavolume2" },
+			},
+			claimed:         []*cdiv1.DataVolume{newLabeledDataVolume("datavolume2", productionLabel, nil)},
+			expectedPatches: 1,
+		},
+		{
+			name:        "Controller marked for deletion can not claim new datavolumes",
+			controller:  &deletedController,
+			datavolumes: []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller), newLabeledDataVolume("datavolume2", productionLabel, nil)},
+			claimed:     []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller)},
+		},
+		{
+			name:        "Controller can not claim datavolumes owned by another controller",
+			controller:  &controller,
+			datavolumes: []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller), newLabeledDataVolume("datavolume2", productionLabel, &otherController)},
+			claimed:     []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller)},
+		},
+		{
+			name:            "Controller releases claimed datavolumes when selector doesn't match",
+			controller:      &controller,
+			datavolumes:     []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller), newLabeledDataVolume("datavolume2", testLabel, &controller)},
+			claimed:         []*cdiv1.DataVolume{newLabeledDataVolume("datavolume1", productionLabel, &controller)},
+			expectedPatches: 1,
+		},
+		{
+			name:        "Controller does not claim orphaned datavolumes marked for deletion",
+			controller:  &controller,
+			datavolumes: []*cdiv1.DataVolume{deletedDataVolume},
+		},
+	}
+	for _, test := range tests {
+		control := &FakeVirtualMachineControl{}
+		manager := NewDataVolumeControllerRefManager(control, test.controller, productionLabelSelector, schema.GroupVersionKind{}, func() error { return nil })
+		claimed, err := manager.ClaimDataVolumes(test.datavolumes, test.filters...)
+		if err != nil {
+			t.Errorf("Test case `%s`, unexpected error: %v", test.name, err)
+		} else if !equality.Semantic.DeepEqual(test.claimed, claimed) {
+			t.Errorf("Test case `%s`, claimed wrong datavolumes. Expected %v, got %v", test.name, datavolumeToStringSlice(test.claimed), datavolumeToStringSlice(claimed))
+		}
+		if len(control.Patches) != test.expectedPatches {
+			t.Errorf("Test case `%s`, expected %d patches, got %d", test.name, test.expectedPatches, len(control.Patches))
+		}
+	}
+}
+
 func datavolumeToStringSlice(dataVolumes []*cdiv1.DataVolume) []string {
 	var names []string
 	for _, dv := range dataVolumes {
@@ -312,6 +893,10 @@ type FakeVirtualMachineControl struct {
 	ControllerRefs []metav1.OwnerReference
 	Patches        [][]byte
 	Err            error
+	// PatchedVirtualMachines holds the names of the patched VirtualMachines
+	PatchedVirtualMachines []string
+	// VirtualMachineErrs holds the errors returned when patching the VirtualMachines, by name
+	VirtualMachineErrs map[string]error
 }
 
 var _ VirtualMachineControlInterface = &FakeVirtualMachineControl{}
@@ -326,10 +911,14 @@ func (f *FakeVirtualMachineControl) PatchVirtualMachineInstance(_, _ string, dat
 	return nil
 }
 
-func (f *FakeVirtualMachineControl) PatchVirtualMachine(_, _ string, data []byte) error {
+func (f *FakeVirtualMachineControl) PatchVirtualMachine(_, name string, _ types.PatchType, data []byte) error {
 	f.Lock()
 	defer f.Unlock()
 	f.Patches = append(f.Patches, data)
+	f.PatchedVirtualMachines = append(f.PatchedVirtualMachines, name)
+	if err := f.VirtualMachineErrs[name]; err != nil {
+		return err
+	}
 	if f.Err != nil {
 		return f.Err
 	}
@@ -1,30 +1,54 @@
 package emptydisk
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
 	"path"
 	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
 
 	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	ephemeraldiskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 	"kubevirt.io/kubevirt/pkg/util"
 )
 
-const emptyDiskBaseDir = "/var/run/libvirt/empty-disks/"
+const (
+	emptyDiskBaseDir = "/var/run/libvirt/empty-disks/"
+	emptyDiskDirMode = 0750
+	lockFileSuffix   = ".lock"
+)
 
 type emptyDiskCreator struct {
 	emptyDiskBaseDir string
-	discCreateFunc   func(filePath string, size string) error
+	discCreateFunc   func(filePath string, size string, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) error
+	ownershipManager ephemeraldiskutils.OwnershipManagerInterface
+	chownFunc        func(path string, uid, gid int) error
+	imageInfoFunc    func(filePath string, format v1.EmptyDiskFormat) ([]byte, error)
+	discResizeFunc   func(filePath string, size string, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) error
+	statfsFunc       func(path string, buf *unix.Statfs_t) error
 }
 
+var emptyDiskFormats = []v1.EmptyDiskFormat{v1.EmptyDiskFormatQCOW2, v1.EmptyDiskFormatRaw}
+
+// qcow2Magic starts the header of every qcow2 image
+var qcow2Magic = []byte{'Q', 'F', 'I', 0xfb}
+
 func (c *emptyDiskCreator) CreateTemporaryDisks(vmi *v1.VirtualMachineInstance) error {
 	logger := log.Log.Object(vmi)
 
+	if err := c.removeOrphanedDisks(vmi); err != nil {
+		return err
+	}
+
 	for _, volume := range vmi.Spec.Volumes {
 		if volume.EmptyDisk != nil {
 			// qemu-img takes the size in bytes or in Kibibytes/Mebibytes/...; lets take bytes
@@ -34,44 +58,278 @@ func (c *emptyDiskCreator) CreateTemporaryDisks(vmi *v1.VirtualMachineInstance)
 			if intSize == 0 {
 				return fmt.Errorf("the size for volume %s is too low", volume.Name)
 			}
-			// convert the size to string for qemu-img
-			size := strconv.FormatInt(intSize, 10)
-			file := filePathForVolumeName(c.emptyDiskBaseDir, volume.Name)
-			if err := util.MkdirAllWithNosec(c.emptyDiskBaseDir); err != nil {
+			format := Format(volume.EmptyDisk)
+			file := filePathForVolumeName(c.emptyDiskBaseDir, volume.Name, format)
+			if err := c.createBaseDir(vmi); err != nil {
 				return err
 			}
-			if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
-				if err := c.discCreateFunc(file, size); err != nil {
-					return err
-				}
-			} else if err != nil {
+			if err := c.createDisk(vmi, volume.Name, file, intSize, format, volume.EmptyDisk.Preallocation); err != nil {
 				return err
 			}
-			if err := ephemeraldiskutils.DefaultOwnershipManager.UnsafeSetFileOwnership(file); err != nil {
+		}
+	}
+
+	return nil
+}
+
+// createDisk creates or reconciles the disk of a single volume. The work is
+// done under an exclusive lock on a sidecar file, so that concurrent callers
+// can't run qemu-img on the same path at the same time.
+func (c *emptyDiskCreator) createDisk(vmi *v1.VirtualMachineInstance, volumeName string, file string, size int64, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) error {
+	unlock, err := lockFile(lockFilePath(file))
+	if err != nil {
+		return fmt.Errorf("failed to lock the empty disk for volume %s: %v", volumeName, err)
+	}
+	defer unlock()
+
+	if err := c.checkNoOtherFormat(volumeName, format); err != nil {
+		return err
+	}
+	if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+		if err := c.checkFreeSpace(file, size, format, preallocation); err != nil {
+			return fmt.Errorf("failed to create the empty disk for volume %s: %w", volumeName, err)
+		}
+		// convert the size to string for qemu-img
+		if err := c.discCreateFunc(file, strconv.FormatInt(size, 10), format, preallocation); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else if err := c.reconcileDisk(file, size, format, preallocation); err != nil {
+		return fmt.Errorf("failed to reconcile the empty disk for volume %s: %w", volumeName, err)
+	}
+	return c.setOwnership(vmi, file)
+}
+
+func lockFilePath(file string) string {
+	return file + lockFileSuffix
+}
+
+// lockFile blocks until it holds an exclusive flock on the given path and
+// returns the function which releases it again.
+func lockFile(lockPath string) (func(), error) {
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		if err := unix.Flock(int(f.Fd()), unix.LOCK_UN); err != nil {
+			log.Log.Reason(err).Warningf("failed to unlock %s", lockPath)
+		}
+		f.Close()
+	}, nil
+}
+
+// removeOrphanedDisks deletes the disks in the base directory which no longer
+// belong to an emptyDisk volume of the VMI, e.g. after a volume got renamed.
+func (c *emptyDiskCreator) removeOrphanedDisks(vmi *v1.VirtualMachineInstance) error {
+	entries, err := os.ReadDir(c.emptyDiskBaseDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	volumes := map[string]struct{}{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.EmptyDisk != nil {
+			volumes[volume.Name] = struct{}{}
+		}
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		volumeName, ok := volumeNameForFile(entry.Name())
+		if !ok {
+			continue
+		}
+		if _, exists := volumes[volumeName]; exists {
+			continue
+		}
+		file := path.Join(c.emptyDiskBaseDir, entry.Name())
+		log.Log.Object(vmi).Infof("removing orphaned empty disk %s", file)
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func volumeNameForFile(fileName string) (string, bool) {
+	fileName = strings.TrimSuffix(fileName, lockFileSuffix)
+	for _, format := range emptyDiskFormats {
+		if volumeName, found := strings.CutSuffix(fileName, "."+string(format)); found && volumeName != "" {
+			return volumeName, true
+		}
+	}
+	return "", false
+}
+
+// reconcileDisk brings an existing disk in line with the requested format and
+// capacity. The format is never probed from the content of the disk, the guest
+// controls those bytes and may have written an image header to a raw disk. A
+// file at the path of a qcow2 disk which does not start with the qcow2 magic
+// is a leftover raw disk and is recreated, since it would otherwise be handed
+// to qemu with the wrong driver type. A disk is grown when a larger capacity
+// was requested, shrinking is refused since it would cut off data the guest
+// may rely on.
+func (c *emptyDiskCreator) reconcileDisk(file string, size int64, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) error {
+	if format == v1.EmptyDiskFormatQCOW2 {
+		isQcow2, err := hasQcow2Magic(file)
+		if err != nil {
+			return err
+		}
+		if !isQcow2 {
+			log.Log.Infof("recreating empty disk %s with format %s, found no qcow2 image", file, format)
+			if err := os.Remove(file); err != nil {
 				return err
 			}
+			if err := c.checkFreeSpace(file, size, format, preallocation); err != nil {
+				return err
+			}
+			return c.discCreateFunc(file, strconv.FormatInt(size, 10), format, preallocation)
 		}
 	}
 
+	out, err := c.imageInfoFunc(file, format)
+	if err != nil {
+		return err
+	}
+	info, err := parseImageInfo(out)
+	if err != nil {
+		return err
+	}
+	switch {
+	case size > info.VirtualSize:
+		if err := c.checkFreeSpace(file, size-info.VirtualSize, format, preallocation); err != nil {
+			return err
+		}
+		return c.discResizeFunc(file, strconv.FormatInt(size, 10), format, preallocation)
+	case size < info.VirtualSize:
+		return fmt.Errorf("shrinking the disk from %d to %d bytes is not supported", info.VirtualSize, size)
+	}
 	return nil
 }
 
-func (c *emptyDiskCreator) FilePathForVolumeName(volumeName string) string {
-	return filePathForVolumeName(c.emptyDiskBaseDir, volumeName)
+func hasQcow2Magic(file string) (bool, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	magic := make([]byte, len(qcow2Magic))
+	if _, err := io.ReadFull(f, magic); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return bytes.Equal(magic, qcow2Magic), nil
+}
+
+// checkFreeSpace makes sure that the base directory can hold the given number
+// of additional bytes. Raw and preallocated disks have to fit right away, a
+// sparse qcow2 disk only allocates on write, so it is allowed to overcommit,
+// but the guest may run into write errors once the space is used up.
+func (c *emptyDiskCreator) checkFreeSpace(file string, size int64, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) error {
+	var stat unix.Statfs_t
+	if err := c.statfsFunc(c.emptyDiskBaseDir, &stat); err != nil {
+		return fmt.Errorf("failed to determine the free space in %s: %v", c.emptyDiskBaseDir, err)
+	}
+	available := int64(stat.Bavail) * stat.Bsize
+	if size <= available {
+		return nil
+	}
+	if format == v1.EmptyDiskFormatQCOW2 && !isPreallocated(preallocation) {
+		log.Log.Warningf("empty disk %s needs up to %d bytes, but only %d bytes are available in %s", file, size, available, c.emptyDiskBaseDir)
+		return nil
+	}
+	return fmt.Errorf("the disk needs %d bytes, but only %d bytes are available in %s", size, available, c.emptyDiskBaseDir)
 }
 
-func filePathForVolumeName(basedir string, volumeName string) string {
-	return path.Join(basedir, volumeName+".qcow2")
+func isPreallocated(preallocation v1.EmptyDiskPreallocation) bool {
+	return preallocation != "" && preallocation != v1.EmptyDiskPreallocationOff
 }
 
-func createQCOW(file string, size string) error {
-	// #nosec No risk for attacket injection. Parameters are predefined strings
-	return exec.Command("qemu-img", "create", "-f", "qcow2", file, size).Run()
+func parseImageInfo(out []byte) (*containerdisk.DiskInfo, error) {
+	info := &containerdisk.DiskInfo{}
+	if err := json.Unmarshal(out, info); err != nil {
+		return nil, fmt.Errorf("failed to parse disk info: %v", err)
+	}
+	return info, nil
+}
+
+func (c *emptyDiskCreator) createBaseDir(vmi *v1.VirtualMachineInstance) error {
+	if err := os.MkdirAll(c.emptyDiskBaseDir, emptyDiskDirMode); err != nil {
+		return err
+	}
+	// MkdirAll does not touch the mode of an already existing directory
+	if err := os.Chmod(c.emptyDiskBaseDir, emptyDiskDirMode); err != nil {
+		return err
+	}
+	return c.setOwnership(vmi, c.emptyDiskBaseDir)
+}
+
+// setOwnership hands the path over to the user qemu runs as. For non-root
+// VMIs this is the launcher's non-root UID, otherwise the qemu user.
+func (c *emptyDiskCreator) setOwnership(vmi *v1.VirtualMachineInstance, path string) error {
+	if util.IsNonRootVMI(vmi) {
+		if err := c.chownFunc(path, util.NonRootUID, util.NonRootUID); err != nil {
+			return fmt.Errorf("failed to change the ownership of %s: %w", path, err)
+		}
+		return nil
+	}
+	return c.ownershipManager.UnsafeSetFileOwnership(path)
+}
+
+// checkNoOtherFormat makes sure that a disk which was already created for the
+// volume with a different format is not silently replaced or ignored.
+func (c *emptyDiskCreator) checkNoOtherFormat(volumeName string, format v1.EmptyDiskFormat) error {
+	for _, other := range emptyDiskFormats {
+		if other == format {
+			continue
+		}
+		_, err := os.Stat(filePathForVolumeName(c.emptyDiskBaseDir, volumeName, other))
+		if err == nil {
+			return fmt.Errorf("volume %s already has an empty disk with format %s, refusing to use format %s", volumeName, other, format)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *emptyDiskCreator) FilePathForVolumeName(volumeName string, format v1.EmptyDiskFormat) string {
+	return filePathForVolumeName(c.emptyDiskBaseDir, volumeName, format)
+}
+
+func filePathForVolumeName(basedir string, volumeName string, format v1.EmptyDiskFormat) string {
+	return path.Join(basedir, volumeName+"."+string(format))
+}
+
+// Format returns the image format of the emptyDisk, defaulting to qcow2.
+func Format(source *v1.EmptyDiskSource) v1.EmptyDiskFormat {
+	if source == nil || source.Format == "" {
+		return v1.EmptyDiskFormatQCOW2
+	}
+	return source.Format
 }
 
 func NewEmptyDiskCreator() *emptyDiskCreator {
+	img := newQemuImg()
 	return &emptyDiskCreator{
 		emptyDiskBaseDir: emptyDiskBaseDir,
-		discCreateFunc:   createQCOW,
+		discCreateFunc:   img.create,
+		ownershipManager: ephemeraldiskutils.DefaultOwnershipManager,
+		chownFunc:        os.Chown,
+		imageInfoFunc:    img.info,
+		discResizeFunc:   img.resize,
+		statfsFunc:       unix.Statfs,
 	}
 }
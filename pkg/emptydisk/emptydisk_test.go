@@ -1,28 +1,47 @@
 package emptydisk
 
 import (
+	"fmt"
 	"os"
 	"path"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/sys/unix"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	v1 "kubevirt.io/api/core/v1"
+
 	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/safepath"
+	"kubevirt.io/kubevirt/pkg/util"
 )
 
 var _ = Describe("EmptyDisk", func() {
 
 	var emptyDiskBaseDir string
 	var creator *emptyDiskCreator
+	var owners *chownRecorder
 
 	BeforeEach(func() {
 		var err error
 		emptyDiskBaseDir, err = os.MkdirTemp("", "emptydisk-dir")
 		Expect(err).ToNot(HaveOccurred())
+		owners = &chownRecorder{owners: map[string]string{}}
 		creator = &emptyDiskCreator{
 			emptyDiskBaseDir: emptyDiskBaseDir,
 			discCreateFunc:   fakeCreatorFunc,
+			ownershipManager: owners,
+			chownFunc:        owners.chown,
+			imageInfoFunc:    fakeImageInfoFunc(3 * 1024 * 1024 * 1024),
+			discResizeFunc: func(_ string, _ string, _ v1.EmptyDiskFormat, _ v1.EmptyDiskPreallocation) error {
+				Fail("no resize expected")
+				return nil
+			},
+			statfsFunc: fakeStatfsFunc(100 * 1024 * 1024 * 1024),
 		}
 	})
 	AfterEach(func() {
@@ -37,7 +56,7 @@ var _ = Describe("EmptyDisk", func() {
 
 			err := creator.CreateTemporaryDisks(vmi)
 			Expect(err).ToNot(HaveOccurred())
-			_, err = os.Stat(filePathForVolumeName(emptyDiskBaseDir, "testdisk"))
+			_, err = os.Stat(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2))
 			Expect(err).ToNot(HaveOccurred())
 			_, err = os.Stat(path.Join(emptyDiskBaseDir, "testdisk.qcow2"))
 			Expect(err).ToNot(HaveOccurred())
@@ -49,35 +68,423 @@ var _ = Describe("EmptyDisk", func() {
 
 			err := creator.CreateTemporaryDisks(vmi)
 			Expect(err).ToNot(HaveOccurred())
-			_, err = os.Stat(filePathForVolumeName(emptyDiskBaseDir, "testdisk"))
+			_, err = os.Stat(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2))
 			Expect(err).ToNot(HaveOccurred())
 			_, err = os.Stat(path.Join(emptyDiskBaseDir, "testdisk.qcow2"))
 			Expect(err).ToNot(HaveOccurred())
 		})
 		It("should generate non-conflicting volume paths per disk", func() {
-			Expect(NewEmptyDiskCreator().FilePathForVolumeName("volume1")).ToNot(Equal(NewEmptyDiskCreator().FilePathForVolumeName("volume2")))
+			Expect(NewEmptyDiskCreator().FilePathForVolumeName("volume1", v1.EmptyDiskFormatQCOW2)).ToNot(Equal(NewEmptyDiskCreator().FilePathForVolumeName("volume2", v1.EmptyDiskFormatQCOW2)))
 		})
 		It("should leave pre-existing disks alone", func() {
 			vmi := libvmi.New(
 				libvmi.WithEmptyDisk("testdisk", "", resource.MustParse("3Gi")),
 			)
 
-			err := os.WriteFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk"), []byte("test"), 0777)
+			err := os.WriteFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2), []byte(qcow2Test), 0777)
 			Expect(err).ToNot(HaveOccurred())
 			err = creator.CreateTemporaryDisks(vmi)
 			Expect(err).ToNot(HaveOccurred())
-			data, err := os.ReadFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk"))
+			data, err := os.ReadFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2))
 			Expect(err).ToNot(HaveOccurred())
-			Expect(string(data)).To(Equal("test"))
+			Expect(string(data)).To(Equal(qcow2Test))
 		})
+		DescribeTable("should create the image with the requested format and preallocation", func(format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation, expectedFile string) {
+			vmi := libvmi.New(
+				libvmi.WithEmptyDisk("testdisk", "", resource.MustParse("3Gi")),
+			)
+			vmi.Spec.Volumes[0].EmptyDisk.Format = format
+			vmi.Spec.Volumes[0].EmptyDisk.Preallocation = preallocation
+
+			var createdFormat v1.EmptyDiskFormat
+			var createdPreallocation v1.EmptyDiskPreallocation
+			creator.discCreateFunc = func(filePath string, size string, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) error {
+				createdFormat = format
+				createdPreallocation = preallocation
+				return fakeCreatorFunc(filePath, size, format, preallocation)
+			}
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			_, err := os.Stat(path.Join(emptyDiskBaseDir, expectedFile))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(createdFormat).To(Equal(Format(vmi.Spec.Volumes[0].EmptyDisk)))
+			Expect(createdPreallocation).To(Equal(preallocation))
+		},
+			Entry("with the default format", v1.EmptyDiskFormat(""), v1.EmptyDiskPreallocation(""), "testdisk.qcow2"),
+			Entry("with qcow2 and no preallocation", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocationOff, "testdisk.qcow2"),
+			Entry("with qcow2 and falloc preallocation", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocationFalloc, "testdisk.qcow2"),
+			Entry("with qcow2 and full preallocation", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocationFull, "testdisk.qcow2"),
+			Entry("with raw and no preallocation", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationOff, "testdisk.raw"),
+			Entry("with raw and falloc preallocation", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationFalloc, "testdisk.raw"),
+			Entry("with raw and full preallocation", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationFull, "testdisk.raw"),
+		)
+		DescribeTable("should refuse to re-use a disk created with a different format", func(existing, requested v1.EmptyDiskFormat) {
+			vmi := libvmi.New(
+				libvmi.WithEmptyDisk("testdisk", "", resource.MustParse("3Gi")),
+			)
+			vmi.Spec.Volumes[0].EmptyDisk.Format = requested
+
+			Expect(os.WriteFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk", existing), []byte("test"), 0777)).To(Succeed())
+			err := creator.CreateTemporaryDisks(vmi)
+			Expect(err).To(MatchError(ContainSubstring("already has an empty disk with format " + string(existing))))
+			_, err = os.Stat(filePathForVolumeName(emptyDiskBaseDir, "testdisk", requested))
+			Expect(err).To(MatchError(os.ErrNotExist))
+		},
+			Entry("qcow2 disk requested as raw", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskFormatRaw),
+			Entry("raw disk requested as qcow2", v1.EmptyDiskFormatRaw, v1.EmptyDiskFormatQCOW2),
+		)
 	})
 
+	Describe("reconciliation of existing emptyDisks", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = libvmi.New(
+				libvmi.WithEmptyDisk("testdisk", "", resource.MustParse("3Gi")),
+			)
+			Expect(os.WriteFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2), []byte(qcow2Test), 0777)).To(Succeed())
+		})
+
+		It("should remove disks which no longer belong to a volume", func() {
+			for _, name := range []string{"olddisk.qcow2", "olddisk.qcow2.lock", "olddisk.raw", "unrelated.txt"} {
+				Expect(os.WriteFile(path.Join(emptyDiskBaseDir, name), []byte("test"), 0777)).To(Succeed())
+			}
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			entries, err := os.ReadDir(emptyDiskBaseDir)
+			Expect(err).ToNot(HaveOccurred())
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			Expect(names).To(ConsistOf("testdisk.qcow2", "testdisk.qcow2.lock", "unrelated.txt"))
+		})
+
+		It("should remove all disks if the VMI has no emptyDisk left", func() {
+			vmi.Spec.Volumes = nil
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			entries, err := os.ReadDir(emptyDiskBaseDir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(BeEmpty())
+		})
+
+		It("should grow the disk if a larger capacity is requested", func() {
+			vmi.Spec.Volumes[0].EmptyDisk.Preallocation = v1.EmptyDiskPreallocationFalloc
+			creator.imageInfoFunc = fakeImageInfoFunc(1024 * 1024 * 1024)
+			var resized []string
+			creator.discResizeFunc = func(filePath string, size string, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) error {
+				resized = append(resized, filePath, size, string(format), string(preallocation))
+				return nil
+			}
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			Expect(resized).To(Equal([]string{
+				filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2), "3221225472", "qcow2", "falloc",
+			}))
+		})
+
+		It("should refuse to shrink the disk", func() {
+			creator.imageInfoFunc = fakeImageInfoFunc(5 * 1024 * 1024 * 1024)
+
+			err := creator.CreateTemporaryDisks(vmi)
+			Expect(err).To(MatchError(ContainSubstring("shrinking the disk from 5368709120 to 3221225472 bytes is not supported")))
+		})
+
+		It("should recreate a raw disk left behind at the path of a qcow2 disk", func() {
+			file := filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2)
+			Expect(os.WriteFile(file, []byte("test"), 0777)).To(Succeed())
+			creator.imageInfoFunc = func(_ string, _ v1.EmptyDiskFormat) ([]byte, error) {
+				Fail("the format of the disk must not be probed")
+				return nil, nil
+			}
+			var created []string
+			creator.discCreateFunc = func(filePath string, size string, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) error {
+				created = append(created, filePath, size, string(format))
+				return fakeCreatorFunc(filePath, size, format, preallocation)
+			}
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			Expect(created).To(Equal([]string{file, "3221225472", "qcow2"}))
+			data, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(BeEmpty())
+		})
+
+		It("should not recreate a disk which has the requested format", func() {
+			creator.discCreateFunc = func(_ string, _ string, _ v1.EmptyDiskFormat, _ v1.EmptyDiskPreallocation) error {
+				Fail("no disk creation expected")
+				return nil
+			}
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			data, err := os.ReadFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(qcow2Test))
+		})
+
+		It("should keep a raw disk to which the guest wrote a qcow2 header", func() {
+			vmi.Spec.Volumes[0].EmptyDisk.Format = v1.EmptyDiskFormatRaw
+			Expect(os.Remove(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2))).To(Succeed())
+			file := filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatRaw)
+			Expect(os.WriteFile(file, []byte(qcow2Test), 0777)).To(Succeed())
+			creator.imageInfoFunc = func(_ string, format v1.EmptyDiskFormat) ([]byte, error) {
+				Expect(format).To(Equal(v1.EmptyDiskFormatRaw))
+				return []byte(`{"format": "raw", "virtual-size": 3221225472, "actual-size": 4096}`), nil
+			}
+			creator.discCreateFunc = func(_ string, _ string, _ v1.EmptyDiskFormat, _ v1.EmptyDiskPreallocation) error {
+				Fail("no disk creation expected")
+				return nil
+			}
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			data, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(qcow2Test))
+		})
+
+		It("should fail if the disk info can't be parsed", func() {
+			creator.imageInfoFunc = func(_ string, _ v1.EmptyDiskFormat) ([]byte, error) {
+				return []byte("not json"), nil
+			}
+
+			err := creator.CreateTemporaryDisks(vmi)
+			Expect(err).To(MatchError(ContainSubstring("failed to parse disk info")))
+		})
+	})
+
+	Describe("free space of the base directory", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = libvmi.New(
+				libvmi.WithEmptyDisk("testdisk", "", resource.MustParse("3Gi")),
+			)
+			creator.statfsFunc = fakeStatfsFunc(2 * 1024 * 1024 * 1024)
+		})
+
+		DescribeTable("should refuse to create a disk which does not fit", func(format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) {
+			vmi.Spec.Volumes[0].EmptyDisk.Format = format
+			vmi.Spec.Volumes[0].EmptyDisk.Preallocation = preallocation
+			creator.discCreateFunc = func(_ string, _ string, _ v1.EmptyDiskFormat, _ v1.EmptyDiskPreallocation) error {
+				Fail("no disk creation expected")
+				return nil
+			}
+
+			err := creator.CreateTemporaryDisks(vmi)
+			Expect(err).To(MatchError(ContainSubstring("failed to create the empty disk for volume testdisk")))
+			Expect(err).To(MatchError(ContainSubstring("the disk needs 3221225472 bytes, but only 2147483648 bytes are available")))
+		},
+			Entry("with raw", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationOff),
+			Entry("with preallocated raw", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationFull),
+			Entry("with preallocated qcow2", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocationFalloc),
+		)
+
+		It("should allow a sparse qcow2 disk to overcommit", func() {
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			_, err := os.Stat(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should only take the growth into account when a disk is resized", func() {
+			vmi.Spec.Volumes[0].EmptyDisk.Format = v1.EmptyDiskFormatRaw
+			Expect(os.WriteFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatRaw), []byte("test"), 0777)).To(Succeed())
+			creator.imageInfoFunc = func(_ string, _ v1.EmptyDiskFormat) ([]byte, error) {
+				return []byte(`{"format": "raw", "virtual-size": 2147483648, "actual-size": 2147483648}`), nil
+			}
+			resized := false
+			creator.discResizeFunc = func(_ string, _ string, _ v1.EmptyDiskFormat, _ v1.EmptyDiskPreallocation) error {
+				resized = true
+				return nil
+			}
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			Expect(resized).To(BeTrue())
+		})
+
+		It("should measure the free space of the base directory", func() {
+			creator.statfsFunc = unix.Statfs
+			vmi.Spec.Volumes[0].EmptyDisk.Format = v1.EmptyDiskFormatRaw
+			vmi.Spec.Volumes[0].EmptyDisk.Capacity = resource.MustParse("1Ei")
+
+			err := creator.CreateTemporaryDisks(vmi)
+			Expect(err).To(MatchError(ContainSubstring("bytes are available in " + emptyDiskBaseDir)))
+		})
+	})
+
+	It("should parse the output of qemu-img info", func() {
+		info, err := parseImageInfo([]byte(qemuImgInfoOutput))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Format).To(Equal("qcow2"))
+		Expect(info.VirtualSize).To(Equal(int64(3221225472)))
+		Expect(info.ActualSize).To(Equal(int64(200704)))
+	})
+
+	DescribeTable("should pass the format and preallocation to qemu-img resize", func(format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation, expectedArgs []string) {
+		Expect(resizeImageArgs("/disk", "1024", format, preallocation)).To(Equal(expectedArgs))
+	},
+		Entry("qcow2 without preallocation", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocationOff, []string{"resize", "-f", "qcow2", "/disk", "1024"}),
+		Entry("raw with full preallocation", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationFull, []string{"resize", "-f", "raw", "--preallocation=full", "/disk", "1024"}),
+	)
+
+	It("should create a disk only once when racing on the same volume", func() {
+		vmi := libvmi.New(
+			libvmi.WithEmptyDisk("testdisk", "", resource.MustParse("3Gi")),
+		)
+		var creations int32
+		creator.discCreateFunc = func(filePath string, _ string, _ v1.EmptyDiskFormat, _ v1.EmptyDiskPreallocation) error {
+			atomic.AddInt32(&creations, 1)
+			// give the other caller the chance to run into the half-created disk
+			time.Sleep(50 * time.Millisecond)
+			return os.WriteFile(filePath, []byte(qcow2Test), 0644)
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(vmi *v1.VirtualMachineInstance) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				errs <- creator.CreateTemporaryDisks(vmi)
+			}(vmi.DeepCopy())
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(atomic.LoadInt32(&creations)).To(Equal(int32(1)))
+		data, err := os.ReadFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(qcow2Test))
+	})
+
+	Describe("ownership of the emptyDisks", func() {
+		const qemuUser = "qemu"
+		nonRootOwner := fmt.Sprintf("%d:%d", util.NonRootUID, util.NonRootUID)
+
+		DescribeTable("should hand the disk and its directory to qemu", func(nonRoot bool, expectedOwner string) {
+			vmi := libvmi.New(
+				libvmi.WithEmptyDisk("testdisk", "", resource.MustParse("3Gi")),
+			)
+			if nonRoot {
+				vmi.Status.RuntimeUser = util.NonRootUID
+			}
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			Expect(owners.owners).To(Equal(map[string]string{
+				emptyDiskBaseDir: expectedOwner,
+				filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.EmptyDiskFormatQCOW2): expectedOwner,
+			}))
+		},
+			Entry("for a root VMI", false, qemuUser),
+			Entry("for a non-root VMI", true, nonRootOwner),
+		)
+
+		It("should tighten the mode of the directory", func() {
+			vmi := libvmi.New(
+				libvmi.WithEmptyDisk("testdisk", "", resource.MustParse("3Gi")),
+			)
+			Expect(os.Chmod(emptyDiskBaseDir, 0777)).To(Succeed())
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			info, err := os.Stat(emptyDiskBaseDir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0750)))
+		})
+
+		It("should fail if the ownership can't be changed", func() {
+			vmi := libvmi.New(
+				libvmi.WithEmptyDisk("testdisk", "", resource.MustParse("3Gi")),
+			)
+			vmi.Status.RuntimeUser = util.NonRootUID
+			creator.chownFunc = func(_ string, _, _ int) error {
+				return os.ErrPermission
+			}
+
+			Expect(creator.CreateTemporaryDisks(vmi)).To(MatchError(os.ErrPermission))
+		})
+	})
+
+	DescribeTable("should pass the format and preallocation to qemu-img", func(format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation, expectedArgs []string) {
+		Expect(createImageArgs("/disk", "1024", format, preallocation)).To(Equal(expectedArgs))
+	},
+		Entry("qcow2 without preallocation", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocation(""), []string{"create", "-f", "qcow2", "/disk", "1024"}),
+		Entry("qcow2 with preallocation off", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocationOff, []string{"create", "-f", "qcow2", "/disk", "1024"}),
+		Entry("qcow2 with falloc preallocation", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocationFalloc, []string{"create", "-f", "qcow2", "-o", "preallocation=falloc", "/disk", "1024"}),
+		Entry("qcow2 with full preallocation", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocationFull, []string{"create", "-f", "qcow2", "-o", "preallocation=full", "/disk", "1024"}),
+		Entry("raw without preallocation", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationOff, []string{"create", "-f", "raw", "/disk", "1024"}),
+		Entry("raw with falloc preallocation", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationFalloc, []string{"create", "-f", "raw", "-o", "preallocation=falloc", "/disk", "1024"}),
+		Entry("raw with full preallocation", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationFull, []string{"create", "-f", "raw", "-o", "preallocation=full", "/disk", "1024"}),
+	)
 })
 
-func fakeCreatorFunc(filePath string, _ string) error {
+func fakeCreatorFunc(filePath string, _ string, _ v1.EmptyDiskFormat, _ v1.EmptyDiskPreallocation) error {
 	f, err := os.Create(filePath)
 	if err != nil {
 		return err
 	}
 	return f.Close()
 }
+
+// qcow2Test is the content of a fake qcow2 disk, it only carries the qcow2 magic
+const qcow2Test = "QFI\xfbtest"
+
+const qemuImgInfoOutput = `{
+    "virtual-size": 3221225472,
+    "filename": "/var/run/libvirt/empty-disks/testdisk.qcow2",
+    "cluster-size": 65536,
+    "format": "qcow2",
+    "actual-size": 200704,
+    "format-specific": {
+        "type": "qcow2",
+        "data": {
+            "compat": "1.1",
+            "compression-type": "zlib",
+            "lazy-refcounts": false,
+            "refcount-bits": 16,
+            "corrupt": false,
+            "extended-l2": false
+        }
+    },
+    "dirty-flag": false
+}`
+
+func fakeImageInfoFunc(virtualSize int64) func(string, v1.EmptyDiskFormat) ([]byte, error) {
+	return func(_ string, _ v1.EmptyDiskFormat) ([]byte, error) {
+		return []byte(fmt.Sprintf(`{"format": "qcow2", "virtual-size": %d, "actual-size": 200704}`, virtualSize)), nil
+	}
+}
+
+func fakeStatfsFunc(available int64) func(string, *unix.Statfs_t) error {
+	return func(_ string, buf *unix.Statfs_t) error {
+		buf.Bsize = 4096
+		buf.Bavail = uint64(available / buf.Bsize)
+		return nil
+	}
+}
+
+type chownRecorder struct {
+	lock   sync.Mutex
+	owners map[string]string
+}
+
+func (r *chownRecorder) chown(file string, uid, gid int) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.owners[file] = fmt.Sprintf("%d:%d", uid, gid)
+	return nil
+}
+
+func (r *chownRecorder) UnsafeSetFileOwnership(file string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.owners[file] = "qemu"
+	return nil
+}
+
+func (r *chownRecorder) SetFileOwnership(file *safepath.Path) error {
+	return r.UnsafeSetFileOwnership(file.String())
+}
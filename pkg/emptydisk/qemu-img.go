@@ -0,0 +1,87 @@
+package emptydisk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+const (
+	qemuImgBinary = "qemu-img"
+	// full preallocation writes the whole disk, give it enough time on slow storage
+	defaultQemuImgTimeout = 10 * time.Minute
+)
+
+// qemuImg runs qemu-img and turns failures into errors which carry the
+// command line and whatever qemu-img printed, instead of a bare exit status.
+type qemuImg struct {
+	binary  string
+	timeout time.Duration
+}
+
+func newQemuImg() *qemuImg {
+	return &qemuImg{
+		binary:  qemuImgBinary,
+		timeout: defaultQemuImgTimeout,
+	}
+}
+
+func (q *qemuImg) run(args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), q.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	// #nosec No risk for attacket injection. Parameters are predefined strings
+	cmd := exec.CommandContext(ctx, q.binary, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("%s timed out after %s", cmd.String(), q.timeout)
+	}
+	if err != nil {
+		output := strings.TrimSpace(stderr.String())
+		if output == "" {
+			output = strings.TrimSpace(stdout.String())
+		}
+		return nil, fmt.Errorf("%s failed: %v: '%s'", cmd.String(), err, output)
+	}
+	return stdout.Bytes(), nil
+}
+
+func (q *qemuImg) create(file string, size string, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) error {
+	_, err := q.run(createImageArgs(file, size, format, preallocation)...)
+	return err
+}
+
+func (q *qemuImg) resize(file string, size string, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) error {
+	_, err := q.run(resizeImageArgs(file, size, format, preallocation)...)
+	return err
+}
+
+// info passes the format on, so that qemu-img does not probe it from the content of the disk
+func (q *qemuImg) info(file string, format v1.EmptyDiskFormat) ([]byte, error) {
+	return q.run("info", "-f", string(format), file, "--output", "json")
+}
+
+func createImageArgs(file string, size string, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) []string {
+	args := []string{"create", "-f", string(format)}
+	if isPreallocated(preallocation) {
+		args = append(args, "-o", "preallocation="+string(preallocation))
+	}
+	return append(args, file, size)
+}
+
+func resizeImageArgs(file string, size string, format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation) []string {
+	args := []string{"resize", "-f", string(format)}
+	if isPreallocated(preallocation) {
+		args = append(args, "--preallocation="+string(preallocation))
+	}
+	return append(args, file, size)
+}
@@ -0,0 +1,89 @@
+package emptydisk
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/sys/unix"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+)
+
+var _ = Describe("qemu-img", func() {
+
+	var binDir string
+
+	BeforeEach(func() {
+		var err error
+		binDir, err = os.MkdirTemp("", "qemu-img")
+		Expect(err).ToNot(HaveOccurred())
+	})
+	AfterEach(func() {
+		Expect(os.RemoveAll(binDir)).To(Succeed())
+	})
+
+	fakeQemuImg := func(script string) *qemuImg {
+		binary := filepath.Join(binDir, "qemu-img")
+		Expect(os.WriteFile(binary, []byte("#!/bin/sh\n"+script), 0755)).To(Succeed())
+		return &qemuImg{binary: binary, timeout: 10 * time.Second}
+	}
+
+	It("should return the output of a successful run", func() {
+		img := fakeQemuImg(`echo '{"format": "raw"}'; echo warning >&2`)
+
+		out, err := img.info("/disk.raw", v1.EmptyDiskFormatRaw)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("{\"format\": \"raw\"}\n"))
+	})
+
+	It("should not let qemu-img probe the format of the disk", func() {
+		img := fakeQemuImg(`echo "$@"`)
+
+		out, err := img.info("/disk.raw", v1.EmptyDiskFormatRaw)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(out)).To(Equal("info -f raw /disk.raw --output json\n"))
+	})
+
+	It("should include the command line and the output in errors", func() {
+		img := fakeQemuImg(`echo "qemu-img: /disk.qcow2: No space left on device" >&2; exit 1`)
+
+		err := img.create("/disk.qcow2", "1024", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocationFull)
+		Expect(err).To(MatchError(ContainSubstring("create -f qcow2 -o preallocation=full /disk.qcow2 1024 failed: exit status 1")))
+		Expect(err).To(MatchError(ContainSubstring("No space left on device")))
+	})
+
+	It("should fail when qemu-img does not finish in time", func() {
+		img := fakeQemuImg(`exec sleep 10`)
+		img.timeout = 100 * time.Millisecond
+
+		err := img.resize("/disk.raw", "1024", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationOff)
+		Expect(err).To(MatchError(ContainSubstring("timed out after 100ms")))
+	})
+
+	It("should propagate failures through CreateTemporaryDisks", func() {
+		img := fakeQemuImg(`echo "qemu-img: Could not create '/disk': Permission denied" >&2; exit 1`)
+		emptyDiskBaseDir, err := os.MkdirTemp("", "emptydisk-dir")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(emptyDiskBaseDir)
+		creator := &emptyDiskCreator{
+			emptyDiskBaseDir: emptyDiskBaseDir,
+			discCreateFunc:   img.create,
+			ownershipManager: &chownRecorder{owners: map[string]string{}},
+			imageInfoFunc:    img.info,
+			discResizeFunc:   img.resize,
+			statfsFunc:       unix.Statfs,
+		}
+		vmi := libvmi.New(
+			libvmi.WithEmptyDisk("testdisk", "", resource.MustParse("3Gi")),
+		)
+
+		err = creator.CreateTemporaryDisks(vmi)
+		Expect(err).To(MatchError(ContainSubstring("Permission denied")))
+	})
+})
@@ -41,6 +41,7 @@ It has these top-level messages:
 	SEVInfoResponse
 	LaunchMeasurementResponse
 	InjectLaunchSecretRequest
+	LaunchInfoResponse
 */
 package v1
 
@@ -217,6 +218,7 @@ type Cell struct {
 	Pages     []*Pages   `protobuf:"bytes,3,rep,name=pages" json:"pages,omitempty"`
 	Distances []*Sibling `protobuf:"bytes,4,rep,name=distances" json:"distances,omitempty"`
 	Cpus      []*CPU     `protobuf:"bytes,5,rep,name=cpus" json:"cpus,omitempty"`
+	FreePages []*Pages   `protobuf:"bytes,6,rep,name=free_pages,json=freePages" json:"free_pages,omitempty"`
 }
 
 func (m *Cell) Reset()                    { *m = Cell{} }
@@ -259,6 +261,13 @@ func (m *Cell) GetCpus() []*CPU {
 	return nil
 }
 
+func (m *Cell) GetFreePages() []*Pages {
+	if m != nil {
+		return m.FreePages
+	}
+	return nil
+}
+
 type Topology struct {
 	NumaCells []*Cell `protobuf:"bytes,1,rep,name=numa_cells,json=numaCells" json:"numa_cells,omitempty"`
 }
@@ -281,6 +290,8 @@ type SMBios struct {
 	Version      string `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
 	Sku          string `protobuf:"bytes,4,opt,name=sku" json:"sku,omitempty"`
 	Family       string `protobuf:"bytes,5,opt,name=family" json:"family,omitempty"`
+	Serial       string `protobuf:"bytes,6,opt,name=serial" json:"serial,omitempty"`
+	AssetTag     string `protobuf:"bytes,7,opt,name=assetTag" json:"assetTag,omitempty"`
 }
 
 func (m *SMBios) Reset()                    { *m = SMBios{} }
@@ -323,6 +334,20 @@ func (m *SMBios) GetFamily() string {
 	return ""
 }
 
+func (m *SMBios) GetSerial() string {
+	if m != nil {
+		return m.Serial
+	}
+	return ""
+}
+
+func (m *SMBios) GetAssetTag() string {
+	if m != nil {
+		return m.AssetTag
+	}
+	return ""
+}
+
 type DiskInfo struct {
 	Format      string `protobuf:"bytes,1,opt,name=format" json:"format,omitempty"`
 	BackingFile string `protobuf:"bytes,2,opt,name=backingFile" json:"backingFile,omitempty"`
@@ -364,10 +389,11 @@ func (m *DiskInfo) GetVirtualSize() uint64 {
 }
 
 type ClusterConfig struct {
-	ExpandDisksEnabled        bool `protobuf:"varint,1,opt,name=ExpandDisksEnabled" json:"ExpandDisksEnabled,omitempty"`
-	FreePageReportingDisabled bool `protobuf:"varint,2,opt,name=FreePageReportingDisabled" json:"FreePageReportingDisabled,omitempty"`
-	BochsDisplayForEFIGuests  bool `protobuf:"varint,3,opt,name=BochsDisplayForEFIGuests" json:"BochsDisplayForEFIGuests,omitempty"`
-	SerialConsoleLogDisabled  bool `protobuf:"varint,4,opt,name=SerialConsoleLogDisabled" json:"SerialConsoleLogDisabled,omitempty"`
+	ExpandDisksEnabled        bool   `protobuf:"varint,1,opt,name=ExpandDisksEnabled" json:"ExpandDisksEnabled,omitempty"`
+	FreePageReportingDisabled bool   `protobuf:"varint,2,opt,name=FreePageReportingDisabled" json:"FreePageReportingDisabled,omitempty"`
+	BochsDisplayForEFIGuests  bool   `protobuf:"varint,3,opt,name=BochsDisplayForEFIGuests" json:"BochsDisplayForEFIGuests,omitempty"`
+	SerialConsoleLogDisabled  bool   `protobuf:"varint,4,opt,name=SerialConsoleLogDisabled" json:"SerialConsoleLogDisabled,omitempty"`
+	ServiceDomainInfix        string `protobuf:"bytes,5,opt,name=ServiceDomainInfix" json:"ServiceDomainInfix,omitempty"`
 }
 
 func (m *ClusterConfig) Reset()                    { *m = ClusterConfig{} }
@@ -403,6 +429,13 @@ func (m *ClusterConfig) GetSerialConsoleLogDisabled() bool {
 	return false
 }
 
+func (m *ClusterConfig) GetServiceDomainInfix() string {
+	if m != nil {
+		return m.ServiceDomainInfix
+	}
+	return ""
+}
+
 type InterfaceBindingMigration struct {
 	Method string `protobuf:"bytes,1,opt,name=Method" json:"Method,omitempty"`
 }
@@ -932,6 +965,30 @@ func (m *InjectLaunchSecretRequest) GetOptions() []byte {
 	return nil
 }
 
+type LaunchInfoResponse struct {
+	Response   *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	LaunchInfo []byte    `protobuf:"bytes,2,opt,name=launchInfo,proto3" json:"launchInfo,omitempty"`
+}
+
+func (m *LaunchInfoResponse) Reset()                    { *m = LaunchInfoResponse{} }
+func (m *LaunchInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*LaunchInfoResponse) ProtoMessage()               {}
+func (*LaunchInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *LaunchInfoResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *LaunchInfoResponse) GetLaunchInfo() []byte {
+	if m != nil {
+		return m.LaunchInfo
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*QemuVersionResponse)(nil), "kubevirt.cmd.v1.QemuVersionResponse")
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
@@ -964,6 +1021,7 @@ func init() {
 	proto.RegisterType((*SEVInfoResponse)(nil), "kubevirt.cmd.v1.SEVInfoResponse")
 	proto.RegisterType((*LaunchMeasurementResponse)(nil), "kubevirt.cmd.v1.LaunchMeasurementResponse")
 	proto.RegisterType((*InjectLaunchSecretRequest)(nil), "kubevirt.cmd.v1.InjectLaunchSecretRequest")
+	proto.RegisterType((*LaunchInfoResponse)(nil), "kubevirt.cmd.v1.LaunchInfoResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1007,6 +1065,7 @@ type CmdClient interface {
 	GetSEVInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SEVInfoResponse, error)
 	GetLaunchMeasurement(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*LaunchMeasurementResponse, error)
 	InjectLaunchSecret(ctx context.Context, in *InjectLaunchSecretRequest, opts ...grpc.CallOption) (*Response, error)
+	GetLaunchInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*LaunchInfoResponse, error)
 }
 
 type cmdClient struct {
@@ -1287,6 +1346,15 @@ func (c *cmdClient) InjectLaunchSecret(ctx context.Context, in *InjectLaunchSecr
 	return out, nil
 }
 
+func (c *cmdClient) GetLaunchInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*LaunchInfoResponse, error) {
+	out := new(LaunchInfoResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/GetLaunchInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Cmd service
 
 type CmdServer interface {
@@ -1320,6 +1388,7 @@ type CmdServer interface {
 	GetSEVInfo(context.Context, *EmptyRequest) (*SEVInfoResponse, error)
 	GetLaunchMeasurement(context.Context, *VMIRequest) (*LaunchMeasurementResponse, error)
 	InjectLaunchSecret(context.Context, *InjectLaunchSecretRequest) (*Response, error)
+	GetLaunchInfo(context.Context, *EmptyRequest) (*LaunchInfoResponse, error)
 }
 
 func RegisterCmdServer(s *grpc.Server, srv CmdServer) {
@@ -1866,6 +1935,24 @@ func _Cmd_InjectLaunchSecret_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_GetLaunchInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).GetLaunchInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/GetLaunchInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).GetLaunchInfo(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cmd_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubevirt.cmd.v1.Cmd",
 	HandlerType: (*CmdServer)(nil),
@@ -1990,6 +2077,10 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "InjectLaunchSecret",
 			Handler:    _Cmd_InjectLaunchSecret_Handler,
 		},
+		{
+			MethodName: "GetLaunchInfo",
+			Handler:    _Cmd_GetLaunchInfo_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/handler-launcher-com/cmd/v1/cmd.proto",
@@ -1998,118 +2089,123 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1802 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x5f, 0x6f, 0x23, 0xb7,
-	0x11, 0x3f, 0x59, 0xb2, 0x2d, 0x8d, 0xff, 0xe4, 0x8e, 0xfe, 0x93, 0xb5, 0xdb, 0xbb, 0x73, 0x89,
-	0xe2, 0xe0, 0x14, 0x89, 0xdd, 0xbb, 0x5c, 0x82, 0xe2, 0x50, 0x04, 0x17, 0xcb, 0xb2, 0xe3, 0xe4,
-	0x7c, 0xa7, 0xac, 0x6c, 0x1f, 0x9a, 0x36, 0x08, 0xe8, 0x5d, 0x5a, 0x66, 0xbd, 0x4b, 0x2a, 0x4b,
-	0xae, 0x7a, 0xca, 0x53, 0x81, 0x14, 0x7d, 0x28, 0xd0, 0xcf, 0xd7, 0xb7, 0x7e, 0x8b, 0x02, 0x7d,
-	0x0c, 0xc8, 0xe5, 0xca, 0x2b, 0xed, 0xae, 0x7d, 0x86, 0xf4, 0x64, 0x92, 0x33, 0xf3, 0x9b, 0x21,
-	0x39, 0x33, 0xfc, 0x69, 0x0d, 0x1f, 0xf5, 0xae, 0xba, 0xbb, 0x97, 0x84, 0xfb, 0x01, 0x8d, 0x3e,
-	0x09, 0x48, 0xcc, 0xbd, 0x4b, 0x1a, 0x7d, 0xe2, 0x89, 0x70, 0xd7, 0x0b, 0xfd, 0xdd, 0xfe, 0x53,
-	0xfd, 0x67, 0xa7, 0x17, 0x09, 0x25, 0xd0, 0x07, 0x57, 0xf1, 0x39, 0xed, 0xb3, 0x48, 0xed, 0xe8,
-	0xb5, 0xfe, 0x53, 0x7c, 0x01, 0x2b, 0xdf, 0xd2, 0x30, 0x3e, 0xa3, 0x91, 0x64, 0x82, 0xbb, 0x54,
-	0xf6, 0x04, 0x97, 0x14, 0x7d, 0x06, 0xf5, 0xc8, 0x8e, 0x9d, 0xca, 0x56, 0x65, 0x7b, 0xe1, 0xd9,
-	0xc6, 0xce, 0x98, 0xe9, 0x4e, 0xaa, 0xec, 0x0e, 0x55, 0x91, 0x03, 0xf3, 0xfd, 0x04, 0xc9, 0x99,
-	0xd9, 0xaa, 0x6c, 0x37, 0xdc, 0x74, 0x8a, 0x1f, 0x43, 0xf5, 0xec, 0xf8, 0xc8, 0x28, 0x84, 0xec,
-	0x6b, 0x29, 0xb8, 0x81, 0x5d, 0x74, 0xd3, 0x29, 0x7e, 0x0a, 0xd5, 0x66, 0xfb, 0x14, 0x2d, 0xc3,
-	0x0c, 0xf3, 0x8d, 0x6c, 0xc9, 0x9d, 0x61, 0x3e, 0xda, 0x84, 0xba, 0x64, 0xe7, 0x01, 0xe3, 0x5d,
-	0xe9, 0xcc, 0x6c, 0x55, 0xb7, 0x97, 0xdc, 0xe1, 0x1c, 0xef, 0xc2, 0x7c, 0x27, 0x19, 0xe7, 0xcc,
-	0x56, 0x61, 0xb6, 0x4f, 0x82, 0x98, 0x9a, 0x30, 0x6a, 0x6e, 0x32, 0xc1, 0x2d, 0x98, 0x6d, 0x93,
-	0x2e, 0x95, 0x5a, 0xec, 0x89, 0x98, 0x2b, 0x63, 0x51, 0x73, 0x93, 0x09, 0x42, 0x50, 0x8b, 0x39,
-	0x53, 0x36, 0x74, 0x33, 0xd6, 0x6b, 0x92, 0xfd, 0x44, 0x9d, 0xaa, 0x81, 0x36, 0x63, 0xfc, 0x1c,
-	0xe6, 0x8e, 0x69, 0x28, 0xa2, 0x01, 0x5a, 0x87, 0x39, 0x12, 0x66, 0x80, 0xec, 0xac, 0x08, 0x09,
-	0xff, 0xa7, 0x02, 0xb5, 0x26, 0x0d, 0x82, 0x5c, 0xac, 0xbb, 0x30, 0x17, 0x1a, 0x38, 0xa3, 0xbe,
-	0xf0, 0xec, 0xc3, 0xdc, 0x49, 0x27, 0xde, 0x5c, 0xab, 0x86, 0x3e, 0x86, 0xd9, 0x9e, 0xde, 0x86,
-	0x53, 0xdd, 0xaa, 0x6e, 0x2f, 0x3c, 0x5b, 0xcf, 0xe9, 0x9b, 0x4d, 0xba, 0x89, 0x12, 0xfa, 0x1c,
-	0x1a, 0x3e, 0x93, 0x8a, 0x70, 0x8f, 0x4a, 0xa7, 0x66, 0x2c, 0x9c, 0x9c, 0x85, 0x3d, 0x47, 0xf7,
-	0x5a, 0x15, 0x6d, 0x43, 0xcd, 0xeb, 0xc5, 0xd2, 0x99, 0x35, 0x26, 0xab, 0x39, 0x93, 0x66, 0xfb,
-	0xd4, 0x35, 0x1a, 0xf8, 0x25, 0xd4, 0x4f, 0x44, 0x4f, 0x04, 0xa2, 0x3b, 0x40, 0xcf, 0x01, 0x78,
-	0x1c, 0x92, 0x1f, 0x3c, 0x1a, 0x04, 0xd2, 0xa9, 0x18, 0xdb, 0xb5, 0xbc, 0x2d, 0x0d, 0x02, 0xb7,
-	0xa1, 0x15, 0xf5, 0x48, 0xe2, 0x7f, 0x55, 0x60, 0xae, 0x73, 0xbc, 0xc7, 0x84, 0x44, 0x18, 0x16,
-	0x43, 0xc2, 0xe3, 0x0b, 0xe2, 0xa9, 0x38, 0xa2, 0x91, 0x39, 0xa7, 0x86, 0x3b, 0xb2, 0xa6, 0xb3,
-	0xa8, 0x17, 0x09, 0x3f, 0xf6, 0xd2, 0x13, 0x4e, 0xa7, 0xd9, 0x04, 0xac, 0x8e, 0x24, 0x20, 0xba,
-	0x0f, 0x55, 0x79, 0x15, 0x3b, 0x35, 0xb3, 0xaa, 0x87, 0xfa, 0xf2, 0x2e, 0x48, 0xc8, 0x82, 0x81,
-	0x33, 0x6b, 0x16, 0xed, 0x0c, 0xff, 0xb3, 0x02, 0xf5, 0x7d, 0x26, 0xaf, 0x8e, 0xf8, 0x85, 0x30,
-	0x4a, 0x22, 0x0a, 0x89, 0xb2, 0x81, 0xd8, 0x19, 0xda, 0x82, 0x85, 0x73, 0xe2, 0x5d, 0x31, 0xde,
-	0x3d, 0x60, 0x01, 0xb5, 0x61, 0x64, 0x97, 0xd0, 0x23, 0x00, 0x1d, 0x2f, 0x09, 0x3a, 0x69, 0xfe,
-	0xd4, 0xdc, 0xcc, 0x8a, 0x46, 0xd0, 0x47, 0x92, 0x2a, 0xd4, 0x8c, 0x42, 0x76, 0x09, 0xff, 0xaf,
-	0x02, 0x4b, 0xcd, 0x20, 0x96, 0x8a, 0x46, 0x4d, 0xc1, 0x2f, 0x58, 0x17, 0xed, 0x00, 0x6a, 0xbd,
-	0xeb, 0x11, 0xee, 0xeb, 0xf8, 0x64, 0x8b, 0x93, 0xf3, 0x80, 0x26, 0xa9, 0x54, 0x77, 0x0b, 0x24,
-	0xe8, 0x8f, 0xb0, 0x71, 0x10, 0x51, 0xaa, 0xf3, 0xc1, 0xa5, 0x3d, 0x11, 0x29, 0xc6, 0xbb, 0xfb,
-	0x4c, 0x26, 0x66, 0x33, 0xc6, 0xac, 0x5c, 0x01, 0xbd, 0x00, 0x67, 0x4f, 0x78, 0x97, 0x72, 0x9f,
-	0xc9, 0x5e, 0x40, 0x06, 0x07, 0x22, 0x6a, 0x1d, 0x1c, 0x1d, 0xc6, 0x54, 0x2a, 0x69, 0xf6, 0x53,
-	0x77, 0x4b, 0xe5, 0xda, 0xb6, 0x43, 0x23, 0x46, 0x82, 0xa6, 0xe0, 0x52, 0x04, 0xf4, 0x95, 0xb8,
-	0x76, 0x5c, 0x4b, 0x6c, 0xcb, 0xe4, 0xf8, 0x53, 0xd8, 0x38, 0xe2, 0x8a, 0x46, 0x17, 0xc4, 0xa3,
-	0x7b, 0x8c, 0xfb, 0x8c, 0x77, 0x8f, 0x59, 0x37, 0x22, 0x4a, 0xdf, 0xe3, 0xba, 0x2e, 0x3e, 0x75,
-	0x29, 0xfc, 0xf4, 0x42, 0x92, 0x19, 0xfe, 0xef, 0x3c, 0xac, 0x9d, 0x25, 0x87, 0x77, 0x4c, 0xbc,
-	0x4b, 0xc6, 0xe9, 0x9b, 0x9e, 0x36, 0x90, 0xe8, 0x1b, 0x58, 0x1d, 0x15, 0x24, 0x99, 0x66, 0xfb,
-	0x5a, 0xbe, 0xda, 0x12, 0xb1, 0x5b, 0x68, 0x84, 0x9e, 0xc3, 0xda, 0x31, 0x0d, 0xf7, 0x48, 0x10,
-	0x08, 0xc1, 0x3b, 0x8a, 0x28, 0xd9, 0xa6, 0x11, 0x13, 0xc9, 0x69, 0x2e, 0xb9, 0xc5, 0x42, 0xf4,
-	0x7b, 0x58, 0x69, 0x47, 0x54, 0xaf, 0x7b, 0x44, 0x51, 0xff, 0x4c, 0x04, 0x71, 0x68, 0xeb, 0xb7,
-	0xe1, 0x16, 0x89, 0x74, 0x03, 0x56, 0xb6, 0xa6, 0xcc, 0x79, 0x15, 0x35, 0xe0, 0xb4, 0xe8, 0xdc,
-	0xa1, 0x2a, 0xea, 0x40, 0xc3, 0x24, 0x80, 0xce, 0x5d, 0x5b, 0xb9, 0x9f, 0xe5, 0xec, 0x0a, 0x8f,
-	0x69, 0x67, 0x68, 0xd7, 0xe2, 0x2a, 0x1a, 0xb8, 0xd7, 0x38, 0x25, 0x59, 0x37, 0x57, 0x9a, 0x75,
-	0xfb, 0xb0, 0xe4, 0x65, 0xd3, 0xd6, 0x99, 0x37, 0x1b, 0x78, 0x94, 0x6f, 0x03, 0x59, 0x2d, 0x77,
-	0xd4, 0x08, 0xfd, 0x5c, 0x81, 0x0d, 0x96, 0xa6, 0xc1, 0xbe, 0x08, 0x09, 0xe3, 0x5f, 0x2a, 0x45,
-	0xbc, 0xcb, 0x90, 0x72, 0xe5, 0xd4, 0xcd, 0xde, 0x5a, 0xef, 0xb9, 0xb7, 0xa3, 0x32, 0x9c, 0x64,
-	0xaf, 0xe5, 0x7e, 0x10, 0x07, 0x34, 0x14, 0x0e, 0x93, 0xd0, 0x69, 0x18, 0xef, 0x5f, 0xdc, 0xd5,
-	0xfb, 0x10, 0x20, 0x71, 0x5b, 0x80, 0xbc, 0xf9, 0x16, 0x96, 0x47, 0x2f, 0x42, 0x37, 0xae, 0x2b,
-	0x3a, 0xb0, 0xd9, 0xae, 0x87, 0x68, 0x37, 0xfb, 0xb8, 0x15, 0x25, 0x46, 0xda, 0xbd, 0xec, 0xbb,
-	0xf7, 0x62, 0xe6, 0x0f, 0x95, 0xcd, 0x57, 0xf0, 0xe8, 0xe6, 0x53, 0x28, 0x70, 0x34, 0xf2, 0x8a,
-	0x36, 0xb2, 0x68, 0x3f, 0xc2, 0x87, 0x25, 0xbb, 0x2a, 0x80, 0x79, 0x39, 0x1a, 0xef, 0xef, 0x72,
-	0xf1, 0x96, 0x56, 0x7b, 0xc6, 0x25, 0xee, 0x03, 0x9c, 0x1d, 0x1f, 0xb9, 0xf4, 0x47, 0xdd, 0x60,
-	0xd0, 0x13, 0xa8, 0xf6, 0x43, 0x66, 0x6b, 0x38, 0xff, 0x38, 0x69, 0x4d, 0xad, 0x80, 0x5e, 0xc2,
-	0xbc, 0x48, 0xae, 0xc1, 0x7a, 0x7f, 0xf2, 0x7e, 0x97, 0xe6, 0xa6, 0x66, 0xf8, 0x04, 0xee, 0x5f,
-	0xc7, 0x73, 0x47, 0xef, 0xce, 0xa8, 0xf7, 0xc5, 0x6b, 0xd4, 0x9f, 0x2b, 0xb0, 0xd0, 0x7a, 0x47,
-	0xbd, 0x14, 0xf1, 0x11, 0x80, 0x6f, 0x6e, 0xe5, 0x35, 0x09, 0xa9, 0x3d, 0xbc, 0xcc, 0x8a, 0x46,
-	0x6a, 0x8a, 0x30, 0x24, 0xdc, 0x4f, 0x9f, 0x3c, 0x3b, 0xd5, 0x5c, 0xe3, 0xcb, 0xa8, 0x9b, 0x36,
-	0x13, 0x33, 0x46, 0x4f, 0x60, 0x59, 0xb1, 0x90, 0x8a, 0x58, 0x75, 0xa8, 0x27, 0xb8, 0x2f, 0x4d,
-	0x0f, 0x99, 0x75, 0xc7, 0x56, 0xf1, 0x32, 0x2c, 0xb6, 0xc2, 0x9e, 0x1a, 0xd8, 0x28, 0xf0, 0x17,
-	0x50, 0x77, 0x33, 0x5c, 0x4e, 0xc6, 0x9e, 0x47, 0xa5, 0xb4, 0x0f, 0x4c, 0x3a, 0xd5, 0x92, 0x90,
-	0x4a, 0x49, 0xba, 0x69, 0x62, 0xa4, 0x53, 0xfc, 0x03, 0x2c, 0x27, 0xb9, 0x35, 0x29, 0x91, 0x5c,
-	0x87, 0xb9, 0x64, 0xf3, 0xd6, 0x83, 0x9d, 0x61, 0x0e, 0x2b, 0x89, 0x03, 0xd3, 0x5d, 0x27, 0xf5,
-	0xb2, 0x05, 0x0b, 0xfe, 0x35, 0x5a, 0xfa, 0x88, 0x67, 0x96, 0xf0, 0x3b, 0x78, 0x60, 0x1e, 0x34,
-	0x53, 0x4d, 0x13, 0x7a, 0xfb, 0x18, 0x1e, 0x74, 0xc7, 0xb1, 0xac, 0xcf, 0xbc, 0x00, 0xff, 0xa3,
-	0x02, 0x6b, 0xc6, 0xf5, 0xa9, 0xa4, 0xd1, 0x2b, 0x26, 0xd5, 0xa4, 0xee, 0x9f, 0xc3, 0x5a, 0xb7,
-	0x08, 0xcf, 0x86, 0x50, 0x2c, 0xc4, 0xff, 0xae, 0x80, 0x63, 0xc2, 0xd0, 0x9c, 0x46, 0x0e, 0xa4,
-	0xa2, 0xe1, 0xc4, 0xc7, 0xfe, 0x02, 0x9c, 0x6e, 0x09, 0xa4, 0x0d, 0xa6, 0x54, 0x8e, 0x07, 0xb0,
-	0x98, 0x94, 0xcd, 0x64, 0x21, 0x6c, 0x42, 0x9d, 0xbe, 0x63, 0xaa, 0x29, 0xfc, 0xc4, 0xe5, 0xac,
-	0x3b, 0x9c, 0xeb, 0xdc, 0x93, 0xca, 0x7f, 0x13, 0x2b, 0x4b, 0x21, 0xed, 0x0c, 0x7f, 0x07, 0xf7,
-	0xcd, 0x49, 0xb4, 0x35, 0x51, 0x7e, 0xcf, 0xb2, 0xcd, 0x17, 0xe2, 0x4c, 0x61, 0x21, 0x7e, 0x6d,
-	0xf3, 0x2c, 0xc1, 0x9e, 0x68, 0x6f, 0x58, 0xc0, 0x92, 0xe6, 0x74, 0x3f, 0xd1, 0xbb, 0x76, 0xab,
-	0xcf, 0x61, 0x3d, 0xe6, 0x17, 0xc6, 0xf4, 0xa4, 0x28, 0xe8, 0x12, 0x29, 0x7e, 0x0b, 0x0f, 0x92,
-	0x5f, 0x28, 0xfb, 0x71, 0xd8, 0xbb, 0xab, 0xd3, 0x4d, 0xa8, 0xfb, 0x71, 0xd8, 0x6b, 0x13, 0x75,
-	0x69, 0x2f, 0x7f, 0x38, 0xc7, 0xe7, 0xf0, 0x41, 0xa7, 0x75, 0x36, 0x8d, 0xda, 0xd3, 0xcd, 0x8c,
-	0xf6, 0x0d, 0x2b, 0xb2, 0x8d, 0xd8, 0x4e, 0xf1, 0xdf, 0x2b, 0xb0, 0xf1, 0xca, 0xfc, 0x66, 0x3e,
-	0xa6, 0x44, 0xc6, 0x11, 0xd5, 0x0f, 0xe2, 0x14, 0x4a, 0x3d, 0x18, 0xc7, 0xb4, 0x8e, 0xf3, 0x02,
-	0xfc, 0xbd, 0xe6, 0xbb, 0x7f, 0xa5, 0x9e, 0x4a, 0xe2, 0xe8, 0x50, 0x2f, 0xa2, 0x6a, 0x6a, 0x4f,
-	0xcd, 0xb3, 0xff, 0xaf, 0x40, 0xb5, 0x19, 0xfa, 0xe8, 0x35, 0xa0, 0xce, 0x80, 0x7b, 0xa3, 0xcf,
-	0x1d, 0xfa, 0x55, 0x21, 0x64, 0xe2, 0x7c, 0xb3, 0x7c, 0xb3, 0xf8, 0x1e, 0x7a, 0x03, 0x2b, 0x6d,
-	0x12, 0x4b, 0x3a, 0x35, 0xc0, 0x6f, 0x61, 0xed, 0x94, 0xf7, 0xa6, 0x0a, 0xd9, 0x81, 0xd5, 0xa4,
-	0x16, 0xc6, 0x10, 0xf3, 0x5c, 0x74, 0xa4, 0x64, 0x6e, 0x06, 0x75, 0x61, 0xfd, 0xd4, 0x56, 0xc2,
-	0xd4, 0x02, 0x3d, 0x01, 0xa7, 0x23, 0x2e, 0x94, 0x4b, 0xcf, 0x85, 0x50, 0x53, 0x43, 0x75, 0x61,
-	0xbd, 0x73, 0x19, 0x2b, 0x5f, 0xfc, 0x8d, 0x4f, 0x0d, 0xf3, 0x35, 0xa0, 0x6f, 0x58, 0x10, 0x4c,
-	0x0d, 0xaf, 0x0d, 0xab, 0xfb, 0x34, 0xa0, 0x6a, 0x7a, 0x67, 0xf9, 0x16, 0xd6, 0x12, 0xc6, 0x36,
-	0x0e, 0xf9, 0x9b, 0xfc, 0x97, 0x95, 0x31, 0x66, 0x77, 0x6b, 0xc6, 0xeb, 0x0a, 0x1a, 0x1a, 0x9d,
-	0x90, 0xa8, 0x4b, 0xd5, 0x04, 0x91, 0xfe, 0x09, 0x1e, 0x36, 0x09, 0xf7, 0xe8, 0xd8, 0x69, 0x5e,
-	0xff, 0xda, 0x9d, 0xec, 0xea, 0x59, 0x97, 0x93, 0x20, 0x09, 0xb2, 0x2d, 0xfc, 0x66, 0x40, 0x09,
-	0x8f, 0x7b, 0x13, 0x60, 0xfe, 0x19, 0x1e, 0x1f, 0x30, 0x4e, 0x02, 0x36, 0x9e, 0xf8, 0xd3, 0x08,
-	0xf8, 0x35, 0xa0, 0xaf, 0x84, 0xea, 0x05, 0x71, 0xf7, 0x2b, 0x21, 0xd5, 0x3e, 0xed, 0x33, 0x8f,
-	0xca, 0x09, 0xf0, 0x8e, 0xa1, 0x71, 0x48, 0x55, 0xc2, 0x16, 0xd1, 0xc3, 0x9c, 0x66, 0x96, 0xf7,
-	0x6e, 0x3e, 0xce, 0xff, 0x84, 0x1a, 0xa1, 0xb1, 0x26, 0xa9, 0x96, 0x87, 0x70, 0x86, 0x1b, 0xde,
-	0x86, 0xf9, 0xdb, 0x12, 0xcc, 0x11, 0xe6, 0x6a, 0x5a, 0xd4, 0xe2, 0x21, 0x55, 0x43, 0x96, 0x79,
-	0x1b, 0x2c, 0xce, 0x89, 0x73, 0x04, 0xd5, 0x80, 0xd6, 0x0f, 0xa9, 0x61, 0x73, 0xb7, 0xc6, 0xf9,
-	0xa4, 0x18, 0x30, 0xc7, 0x04, 0xef, 0xa1, 0xbf, 0x98, 0x23, 0xc8, 0xb0, 0xb2, 0xdb, 0xa0, 0x3f,
-	0x2a, 0x86, 0x2e, 0xe2, 0x75, 0xf7, 0xd0, 0x1e, 0xd4, 0x34, 0xfb, 0xb9, 0x0d, 0xf3, 0xc6, 0x3b,
-	0x6f, 0x41, 0x4d, 0xb3, 0x43, 0xf4, 0xeb, 0x3c, 0xc6, 0xf5, 0x6f, 0xad, 0xcd, 0x87, 0x25, 0xd2,
-	0x4c, 0x33, 0x6e, 0x0c, 0xd9, 0x58, 0x41, 0xd3, 0x18, 0x67, 0x81, 0x65, 0x77, 0x92, 0x25, 0x73,
-	0xa6, 0x7a, 0x9c, 0xb1, 0xaa, 0x19, 0x92, 0x26, 0x84, 0x4b, 0xbe, 0xf9, 0x66, 0x18, 0xd5, 0x6d,
-	0x3d, 0x4f, 0xdf, 0x4d, 0xe6, 0x53, 0xfe, 0xdd, 0xd3, 0xb3, 0xe0, 0xff, 0x00, 0xb6, 0x8f, 0xe4,
-	0x58, 0x43, 0xb3, 0x7d, 0x2a, 0x27, 0x7c, 0xec, 0x72, 0x98, 0xf6, 0x93, 0xfa, 0x24, 0x7c, 0x04,
-	0x0e, 0xa9, 0xb2, 0x84, 0xf1, 0xb6, 0xed, 0x6f, 0xe5, 0x3f, 0xfb, 0x8d, 0x32, 0x4d, 0x7c, 0x0f,
-	0x11, 0x58, 0x3d, 0xa4, 0x2a, 0x47, 0x0e, 0x6f, 0x0e, 0x31, 0xff, 0x75, 0xa3, 0x94, 0x5d, 0xe2,
-	0x7b, 0xe8, 0x7b, 0x40, 0x79, 0xea, 0x87, 0x8a, 0xbe, 0x90, 0x94, 0xf0, 0xc3, 0x1b, 0x8f, 0x64,
-	0xaf, 0xf6, 0xdd, 0x4c, 0xff, 0xe9, 0xf9, 0x9c, 0xf9, 0xdf, 0xcf, 0xa7, 0xbf, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0x7a, 0xa3, 0xf8, 0x64, 0x28, 0x1a, 0x00, 0x00,
+	// 1879 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xef, 0x72, 0x1b, 0xb7,
+	0x11, 0x37, 0xff, 0x48, 0x22, 0x57, 0x7f, 0x62, 0xc3, 0x92, 0x72, 0x52, 0x6b, 0x59, 0xbd, 0x76,
+	0x3c, 0x4a, 0x27, 0x91, 0x6a, 0xc5, 0xce, 0x74, 0x3c, 0x9d, 0x8c, 0x23, 0x8a, 0x52, 0x94, 0x58,
+	0x36, 0x73, 0x94, 0xe4, 0x69, 0xda, 0x8c, 0x07, 0xba, 0x03, 0x29, 0x54, 0x77, 0x00, 0x73, 0xc0,
+	0xb1, 0xa2, 0x3f, 0x75, 0x26, 0x9d, 0x7e, 0x6b, 0xdf, 0xa2, 0xaf, 0xd1, 0x57, 0xe8, 0x1b, 0xf4,
+	0x59, 0x3a, 0xc0, 0xe1, 0xa8, 0x23, 0xef, 0x4e, 0xb2, 0x42, 0x7e, 0x12, 0x16, 0xbb, 0xfb, 0xdb,
+	0x05, 0xb0, 0x58, 0xfc, 0x78, 0x82, 0x4f, 0x7a, 0x97, 0xdd, 0x9d, 0x0b, 0xcc, 0x3c, 0x9f, 0x84,
+	0x9f, 0xf9, 0x38, 0x62, 0xee, 0x05, 0x09, 0x3f, 0x73, 0x79, 0xb0, 0xe3, 0x06, 0xde, 0x4e, 0xff,
+	0xa9, 0xfa, 0xb3, 0xdd, 0x0b, 0xb9, 0xe4, 0xe8, 0xa3, 0xcb, 0xe8, 0x9c, 0xf4, 0x69, 0x28, 0xb7,
+	0xd5, 0x5c, 0xff, 0xa9, 0xdd, 0x81, 0x87, 0xdf, 0x91, 0x20, 0x3a, 0x23, 0xa1, 0xa0, 0x9c, 0x39,
+	0x44, 0xf4, 0x38, 0x13, 0x04, 0x3d, 0x87, 0x5a, 0x68, 0xc6, 0x56, 0x69, 0xb3, 0xb4, 0x35, 0xbf,
+	0xbb, 0xb6, 0x3d, 0xe6, 0xba, 0x9d, 0x18, 0x3b, 0x43, 0x53, 0x64, 0xc1, 0x5c, 0x3f, 0x46, 0xb2,
+	0xca, 0x9b, 0xa5, 0xad, 0xba, 0x93, 0x88, 0xf6, 0x63, 0xa8, 0x9c, 0x1d, 0x1f, 0x69, 0x83, 0x80,
+	0x7e, 0x23, 0x38, 0xd3, 0xb0, 0x0b, 0x4e, 0x22, 0xda, 0x4f, 0xa1, 0xd2, 0x68, 0x9d, 0xa2, 0x25,
+	0x28, 0x53, 0x4f, 0xeb, 0x16, 0x9d, 0x32, 0xf5, 0xd0, 0x3a, 0xd4, 0x04, 0x3d, 0xf7, 0x29, 0xeb,
+	0x0a, 0xab, 0xbc, 0x59, 0xd9, 0x5a, 0x74, 0x86, 0xb2, 0xbd, 0x03, 0x73, 0xed, 0x78, 0x9c, 0x71,
+	0x5b, 0x86, 0x99, 0x3e, 0xf6, 0x23, 0xa2, 0xd3, 0xa8, 0x3a, 0xb1, 0x60, 0x37, 0x61, 0xa6, 0x85,
+	0xbb, 0x44, 0x28, 0xb5, 0xcb, 0x23, 0x26, 0xb5, 0x47, 0xd5, 0x89, 0x05, 0x84, 0xa0, 0x1a, 0x31,
+	0x2a, 0x4d, 0xea, 0x7a, 0xac, 0xe6, 0x04, 0x7d, 0x4f, 0xac, 0x8a, 0x86, 0xd6, 0x63, 0xfb, 0x19,
+	0xcc, 0x1e, 0x93, 0x80, 0x87, 0x03, 0xb4, 0x0a, 0xb3, 0x38, 0x48, 0x01, 0x19, 0x29, 0x0f, 0xc9,
+	0xfe, 0x57, 0x19, 0xaa, 0x0d, 0xe2, 0xfb, 0x99, 0x5c, 0x77, 0x60, 0x36, 0xd0, 0x70, 0xda, 0x7c,
+	0x7e, 0xf7, 0xe3, 0xcc, 0x4e, 0xc7, 0xd1, 0x1c, 0x63, 0x86, 0x3e, 0x85, 0x99, 0x9e, 0x5a, 0x86,
+	0x55, 0xd9, 0xac, 0x6c, 0xcd, 0xef, 0xae, 0x66, 0xec, 0xf5, 0x22, 0x9d, 0xd8, 0x08, 0x7d, 0x01,
+	0x75, 0x8f, 0x0a, 0x89, 0x99, 0x4b, 0x84, 0x55, 0xd5, 0x1e, 0x56, 0xc6, 0xc3, 0xec, 0xa3, 0x73,
+	0x6d, 0x8a, 0xb6, 0xa0, 0xea, 0xf6, 0x22, 0x61, 0xcd, 0x68, 0x97, 0xe5, 0x8c, 0x4b, 0xa3, 0x75,
+	0xea, 0x68, 0x0b, 0xf4, 0x1c, 0xa0, 0x13, 0x12, 0xf2, 0x2e, 0x4e, 0x6a, 0xf6, 0xc6, 0xa4, 0xea,
+	0xca, 0x52, 0x0f, 0xed, 0x97, 0x50, 0x3b, 0xe1, 0x3d, 0xee, 0xf3, 0xee, 0x00, 0x3d, 0x03, 0x60,
+	0x51, 0x80, 0xdf, 0xb9, 0xc4, 0xf7, 0x85, 0x55, 0xd2, 0x10, 0x2b, 0xd9, 0x90, 0xc4, 0xf7, 0x9d,
+	0xba, 0x32, 0x54, 0x23, 0x61, 0xff, 0xa7, 0x04, 0xb3, 0xed, 0xe3, 0x3d, 0xca, 0x05, 0xb2, 0x61,
+	0x21, 0xc0, 0x2c, 0xea, 0x60, 0x57, 0x46, 0x21, 0x09, 0xf5, 0xf6, 0xd6, 0x9d, 0x91, 0x39, 0x55,
+	0x7c, 0xbd, 0x90, 0x7b, 0x91, 0x9b, 0x1c, 0x4c, 0x22, 0xa6, 0xeb, 0xb6, 0x32, 0x52, 0xb7, 0xe8,
+	0x3e, 0x54, 0xc4, 0x65, 0x64, 0x55, 0xf5, 0xac, 0x1a, 0xaa, 0x33, 0xef, 0xe0, 0x80, 0xfa, 0x03,
+	0x6b, 0x46, 0x4f, 0x1a, 0x49, 0xcd, 0x0b, 0x12, 0x52, 0xec, 0x5b, 0xb3, 0xf1, 0x7c, 0x2c, 0xa9,
+	0x0a, 0xc6, 0x42, 0x10, 0x79, 0x82, 0xbb, 0xd6, 0x9c, 0xd6, 0x0c, 0x65, 0xfb, 0x1f, 0x25, 0xa8,
+	0xed, 0x53, 0x71, 0x79, 0xc4, 0x3a, 0x5c, 0x03, 0xf3, 0x30, 0xc0, 0xd2, 0x24, 0x6f, 0x24, 0xb4,
+	0x09, 0xf3, 0xe7, 0xd8, 0xbd, 0xa4, 0xac, 0x7b, 0x40, 0x7d, 0x62, 0x52, 0x4f, 0x4f, 0xa1, 0x0d,
+	0x00, 0xb5, 0x46, 0xec, 0xb7, 0x93, 0x52, 0xad, 0x3a, 0xa9, 0x19, 0x85, 0xa0, 0xb6, 0x31, 0x31,
+	0xa8, 0x6a, 0x83, 0xf4, 0x94, 0xfd, 0xef, 0x32, 0x2c, 0x36, 0xfc, 0x48, 0x48, 0x12, 0x36, 0x38,
+	0xeb, 0xd0, 0x2e, 0xda, 0x06, 0xd4, 0xbc, 0xea, 0x61, 0xe6, 0xa9, 0xfc, 0x44, 0x93, 0xe1, 0x73,
+	0x9f, 0xc4, 0x55, 0x5b, 0x73, 0x72, 0x34, 0xe8, 0x0f, 0xb0, 0x76, 0x60, 0x8e, 0xd6, 0x21, 0x3d,
+	0x1e, 0x4a, 0xca, 0xba, 0xfb, 0x54, 0xc4, 0x6e, 0x65, 0xed, 0x56, 0x6c, 0x80, 0x5e, 0x80, 0xb5,
+	0xc7, 0xdd, 0x0b, 0xb1, 0x4f, 0x45, 0xcf, 0xc7, 0x83, 0x03, 0x1e, 0x36, 0x0f, 0x8e, 0x0e, 0x23,
+	0x22, 0xa4, 0xd0, 0xeb, 0xa9, 0x39, 0x85, 0x7a, 0xe5, 0xdb, 0xd6, 0x5b, 0xdd, 0xe0, 0x4c, 0x70,
+	0x9f, 0xbc, 0xe2, 0xd7, 0x81, 0xab, 0xb1, 0x6f, 0x91, 0x5e, 0xad, 0xb2, 0x4d, 0xc2, 0x3e, 0x75,
+	0xc9, 0x3e, 0x0f, 0x30, 0x65, 0x47, 0xac, 0x43, 0xaf, 0xcc, 0xc1, 0xe6, 0x68, 0xec, 0xcf, 0x61,
+	0xed, 0x88, 0x49, 0x12, 0x76, 0xb0, 0x4b, 0xf6, 0x28, 0xf3, 0x28, 0xeb, 0x1e, 0xd3, 0x6e, 0x88,
+	0xa5, 0xaa, 0x95, 0x55, 0xd5, 0x17, 0xe4, 0x05, 0xf7, 0x92, 0x03, 0x8c, 0x25, 0xfb, 0x7f, 0x73,
+	0xb0, 0x72, 0x16, 0x6f, 0xf6, 0x31, 0x76, 0x2f, 0x28, 0x23, 0x6f, 0x7a, 0xca, 0x41, 0xa0, 0x6f,
+	0x61, 0x79, 0x54, 0x11, 0x57, 0xb3, 0x55, 0x2a, 0x68, 0x04, 0xb1, 0xda, 0xc9, 0x75, 0x42, 0xcf,
+	0x60, 0xe5, 0x98, 0x04, 0x7b, 0xd8, 0xf7, 0x39, 0x67, 0x6d, 0x89, 0xa5, 0x68, 0x91, 0x90, 0xf2,
+	0x78, 0xf7, 0x17, 0x9d, 0x7c, 0x25, 0xfa, 0x1d, 0x3c, 0x6c, 0x85, 0x44, 0xcd, 0xbb, 0x58, 0x12,
+	0xef, 0x8c, 0xfb, 0x51, 0x60, 0x5a, 0x4b, 0xdd, 0xc9, 0x53, 0xa9, 0xb7, 0x41, 0x9a, 0x7b, 0x6b,
+	0x55, 0x0b, 0xde, 0x86, 0xe4, 0x62, 0x3b, 0x43, 0x53, 0xd4, 0x86, 0xba, 0x2e, 0x18, 0x55, 0xeb,
+	0xa6, 0xa9, 0x3c, 0xcf, 0xf8, 0xe5, 0x6e, 0xd3, 0xf6, 0xd0, 0xaf, 0xc9, 0x64, 0x38, 0x70, 0xae,
+	0x71, 0x0a, 0xaa, 0x74, 0xb6, 0xb0, 0x4a, 0xf7, 0x61, 0xd1, 0x4d, 0x97, 0xb9, 0xbe, 0x91, 0xf3,
+	0xbb, 0x1b, 0xd9, 0x56, 0x93, 0xb6, 0x72, 0x46, 0x9d, 0xd0, 0x4f, 0x25, 0x58, 0xa3, 0x49, 0x19,
+	0xc4, 0xe5, 0xf1, 0x95, 0x94, 0xd8, 0xbd, 0x08, 0x08, 0x93, 0x56, 0x4d, 0xaf, 0xad, 0xf9, 0x81,
+	0x6b, 0x3b, 0x2a, 0xc2, 0x89, 0xd7, 0x5a, 0x1c, 0x07, 0x31, 0x40, 0x43, 0xe5, 0xb0, 0x08, 0xad,
+	0xba, 0x8e, 0xfe, 0xe5, 0x5d, 0xa3, 0x0f, 0x01, 0xe2, 0xb0, 0x39, 0xc8, 0xeb, 0x6f, 0x61, 0x69,
+	0xf4, 0x20, 0x54, 0x73, 0xbc, 0x24, 0x03, 0x53, 0xed, 0x6a, 0x88, 0x76, 0xd2, 0xef, 0x6e, 0x5e,
+	0x61, 0x24, 0xdd, 0xce, 0x3c, 0xc9, 0x2f, 0xca, 0xbf, 0x2f, 0xad, 0xbf, 0x82, 0x8d, 0x9b, 0x77,
+	0x21, 0x27, 0xd0, 0xc8, 0x03, 0x5f, 0x4f, 0xa3, 0xfd, 0x08, 0x1f, 0x17, 0xac, 0x2a, 0x07, 0xe6,
+	0xe5, 0x68, 0xbe, 0xbf, 0xcd, 0xe4, 0x5b, 0x78, 0xdb, 0x53, 0x21, 0xed, 0x3e, 0xc0, 0xd9, 0xf1,
+	0x91, 0x43, 0x7e, 0x54, 0x0d, 0x09, 0x3d, 0x81, 0x4a, 0x3f, 0xa0, 0xe6, 0x0e, 0x67, 0xdf, 0x4d,
+	0x65, 0xa9, 0x0c, 0xd0, 0x4b, 0x98, 0xe3, 0xf1, 0x31, 0x98, 0xe8, 0x4f, 0x3e, 0xec, 0xd0, 0x9c,
+	0xc4, 0xcd, 0x3e, 0x81, 0xfb, 0xd7, 0xf9, 0xdc, 0x31, 0xba, 0x35, 0x1a, 0x7d, 0xe1, 0x1a, 0xf5,
+	0xa7, 0x12, 0xcc, 0x37, 0xaf, 0x88, 0x9b, 0x20, 0x6e, 0x00, 0x78, 0xfa, 0x54, 0x5e, 0xe3, 0x80,
+	0x98, 0xcd, 0x4b, 0xcd, 0x28, 0xa4, 0x06, 0x0f, 0x02, 0xcc, 0xbc, 0xe4, 0x59, 0x35, 0xa2, 0xa2,
+	0x41, 0x5f, 0x85, 0xdd, 0xa4, 0x99, 0xe8, 0x31, 0x7a, 0x02, 0x4b, 0x92, 0x06, 0x84, 0x47, 0xb2,
+	0x4d, 0x5c, 0xce, 0x3c, 0xa1, 0x7b, 0xc8, 0x8c, 0x33, 0x36, 0x6b, 0x2f, 0xc1, 0x42, 0x33, 0xe8,
+	0xc9, 0x81, 0xc9, 0xc2, 0xfe, 0x12, 0x6a, 0x4e, 0x8a, 0x66, 0x8a, 0xc8, 0x75, 0x89, 0x10, 0xe6,
+	0x41, 0x4a, 0x44, 0xa5, 0x09, 0x88, 0x10, 0xb8, 0x9b, 0x14, 0x46, 0x22, 0xda, 0xef, 0x60, 0x29,
+	0xae, 0xad, 0x49, 0x39, 0xee, 0x2a, 0xcc, 0xc6, 0x8b, 0x37, 0x11, 0x8c, 0x64, 0x33, 0x78, 0x18,
+	0x07, 0xd0, 0xdd, 0x75, 0xd2, 0x28, 0x9b, 0x30, 0xef, 0x5d, 0xa3, 0x25, 0x8f, 0x7e, 0x6a, 0xca,
+	0xbe, 0x82, 0x07, 0xfa, 0x01, 0xd4, 0xb7, 0x69, 0xc2, 0x68, 0x9f, 0xc2, 0x83, 0xee, 0x38, 0x96,
+	0x89, 0x99, 0x55, 0xd8, 0x7f, 0x2f, 0xc1, 0x8a, 0x0e, 0x7d, 0x2a, 0x48, 0xf8, 0x8a, 0x0a, 0x39,
+	0x69, 0xf8, 0x67, 0xb0, 0xd2, 0xcd, 0xc3, 0x33, 0x29, 0xe4, 0x2b, 0xed, 0x7f, 0x96, 0xc0, 0xd2,
+	0x69, 0x28, 0x0e, 0x24, 0x06, 0x42, 0x92, 0x60, 0xe2, 0x6d, 0x7f, 0x01, 0x56, 0xb7, 0x00, 0xd2,
+	0x24, 0x53, 0xa8, 0xb7, 0x07, 0xb0, 0x10, 0x5f, 0x9b, 0xc9, 0x52, 0x58, 0x87, 0x1a, 0xb9, 0xa2,
+	0xb2, 0xc1, 0xbd, 0x38, 0xe4, 0x8c, 0x33, 0x94, 0x35, 0xc7, 0x94, 0xde, 0x9b, 0x48, 0x1a, 0x9a,
+	0x6a, 0x24, 0xfb, 0x7b, 0xb8, 0xaf, 0x77, 0xa2, 0xa5, 0x38, 0xfc, 0x07, 0x5e, 0xdb, 0xec, 0x45,
+	0x2c, 0xe7, 0x5e, 0xc4, 0x6f, 0xe0, 0x41, 0x0a, 0x7b, 0xa2, 0xb5, 0xd9, 0x1c, 0x16, 0x15, 0x07,
+	0x7c, 0x4f, 0xee, 0xda, 0xad, 0xbe, 0x80, 0xd5, 0x88, 0x75, 0xb4, 0xeb, 0x49, 0x5e, 0xd2, 0x05,
+	0x5a, 0xfb, 0x2d, 0x3c, 0x88, 0x7f, 0x3c, 0xed, 0x47, 0x41, 0xef, 0xae, 0x41, 0xd7, 0xa1, 0xe6,
+	0x45, 0x41, 0xaf, 0x85, 0xe5, 0x85, 0x39, 0xfc, 0xa1, 0x6c, 0x9f, 0xc3, 0x47, 0xed, 0xe6, 0xd9,
+	0x34, 0xee, 0x9e, 0x6a, 0x66, 0xa4, 0xaf, 0x59, 0x91, 0x69, 0xc4, 0x46, 0xb4, 0xff, 0x56, 0x82,
+	0xb5, 0x57, 0xfa, 0xe7, 0xfc, 0x31, 0xc1, 0x22, 0x0a, 0x89, 0x7a, 0x10, 0xa7, 0x70, 0xd5, 0xfd,
+	0x71, 0x4c, 0x13, 0x38, 0xab, 0xb0, 0x7f, 0x50, 0x7c, 0xf7, 0x2f, 0xc4, 0x95, 0x71, 0x1e, 0x6d,
+	0xe2, 0x86, 0x44, 0x4e, 0xef, 0xa9, 0xb9, 0x04, 0x14, 0x03, 0x4f, 0x63, 0x23, 0x37, 0x00, 0xfc,
+	0x21, 0x98, 0x89, 0x94, 0x9a, 0xd9, 0xfd, 0xef, 0x32, 0x54, 0x1a, 0x81, 0x87, 0x5e, 0x03, 0x6a,
+	0x0f, 0x98, 0x3b, 0xfa, 0xb6, 0xa2, 0x5f, 0xe4, 0xe6, 0x1f, 0xaf, 0x74, 0xbd, 0x38, 0xbe, 0x7d,
+	0x0f, 0xbd, 0x81, 0x87, 0x2d, 0x1c, 0x09, 0x32, 0x35, 0xc0, 0xef, 0x60, 0xe5, 0x94, 0xf5, 0xa6,
+	0x0a, 0xd9, 0x86, 0xe5, 0xf8, 0xe2, 0x8d, 0x21, 0x66, 0x89, 0xef, 0xc8, 0xfd, 0xbc, 0x19, 0xd4,
+	0x81, 0xd5, 0x53, 0xd6, 0xc9, 0x83, 0xfd, 0xf9, 0x89, 0x9e, 0x80, 0xd5, 0xe6, 0x1d, 0xe9, 0x90,
+	0x73, 0xce, 0xe5, 0xd4, 0x50, 0x1d, 0x58, 0x6d, 0x5f, 0x44, 0xd2, 0xe3, 0x7f, 0x65, 0x53, 0xc3,
+	0x7c, 0x0d, 0xe8, 0x5b, 0xea, 0xfb, 0x53, 0xc3, 0x6b, 0xc1, 0xf2, 0x3e, 0xf1, 0x89, 0x9c, 0xde,
+	0x5e, 0xbe, 0x85, 0x95, 0x98, 0x1e, 0x8e, 0x43, 0xfe, 0x2a, 0xe3, 0x35, 0x4e, 0x23, 0x6f, 0xad,
+	0x78, 0x75, 0x83, 0x86, 0x4e, 0x27, 0x38, 0xec, 0x12, 0x39, 0x41, 0xa6, 0x7f, 0x84, 0x47, 0x0d,
+	0xf5, 0xd5, 0x69, 0x6c, 0x37, 0x87, 0x01, 0x26, 0x3c, 0x7a, 0xda, 0x65, 0xd8, 0x8f, 0x93, 0x6c,
+	0x71, 0xaf, 0xe1, 0x13, 0xcc, 0xa2, 0xde, 0x04, 0x98, 0x7f, 0x82, 0xc7, 0x07, 0x94, 0x61, 0x9f,
+	0xbe, 0x27, 0xd3, 0x4f, 0xf8, 0x35, 0xa0, 0xaf, 0xb9, 0xec, 0xf9, 0x51, 0xf7, 0x6b, 0x2e, 0xe4,
+	0x3e, 0x51, 0x9f, 0x20, 0xc4, 0x04, 0x78, 0xc7, 0x50, 0x3f, 0x24, 0x32, 0xa6, 0xa6, 0xe8, 0x51,
+	0xc6, 0x32, 0x4d, 0xb2, 0xd7, 0x1f, 0x67, 0x7f, 0xaf, 0x8d, 0x70, 0x66, 0x5d, 0x54, 0x4b, 0x43,
+	0x38, 0x4d, 0x44, 0x6f, 0xc3, 0xfc, 0x4d, 0x01, 0xe6, 0x08, 0x4d, 0xd6, 0x2d, 0x6a, 0xe1, 0x90,
+	0xc8, 0x21, 0xa5, 0xbd, 0x0d, 0xd6, 0xce, 0xa8, 0x33, 0x6c, 0x58, 0x83, 0xd6, 0x0e, 0x89, 0xa6,
+	0x8e, 0xb7, 0xe6, 0xf9, 0x24, 0x1f, 0x30, 0x43, 0x3b, 0xef, 0xa1, 0x3f, 0xeb, 0x2d, 0x48, 0x51,
+	0xc0, 0xdb, 0xa0, 0x3f, 0xc9, 0x87, 0xce, 0x23, 0x91, 0xf7, 0xd0, 0x1e, 0x54, 0x15, 0xd5, 0xba,
+	0x0d, 0xf3, 0xc6, 0x33, 0x6f, 0x42, 0x55, 0x51, 0x51, 0xf4, 0xcb, 0x2c, 0xc6, 0xf5, 0x0f, 0xbb,
+	0xf5, 0x47, 0x05, 0xda, 0x54, 0x33, 0xae, 0x0f, 0xa9, 0x5f, 0x4e, 0xd3, 0x18, 0xa7, 0x9c, 0xeb,
+	0xf6, 0x4d, 0x26, 0xa9, 0xdb, 0x63, 0x8d, 0xdd, 0x9a, 0x21, 0x43, 0x43, 0x76, 0xc1, 0xb7, 0xef,
+	0x14, 0x7d, 0xbb, 0xad, 0xe7, 0xa9, 0xb3, 0x49, 0xfd, 0x4b, 0xe3, 0xee, 0xe5, 0x99, 0xf3, 0xff,
+	0x10, 0xd3, 0x47, 0x32, 0xac, 0xa1, 0xd1, 0x3a, 0x15, 0x13, 0x3e, 0x76, 0x19, 0xcc, 0x78, 0xc1,
+	0x13, 0xf1, 0x11, 0x38, 0x24, 0xd2, 0xb0, 0xd3, 0xdb, 0x96, 0xbf, 0x99, 0x51, 0x8f, 0xd1, 0x5a,
+	0xfb, 0x1e, 0xc2, 0xb0, 0x7c, 0x48, 0x64, 0x86, 0x89, 0xde, 0x9c, 0x62, 0xf6, 0x53, 0x4a, 0x21,
+	0x95, 0xb5, 0xef, 0xa1, 0x1f, 0x00, 0x65, 0x79, 0x26, 0xca, 0xfb, 0x1c, 0x53, 0x40, 0x46, 0x6f,
+	0xde, 0x92, 0x53, 0x58, 0x1c, 0xae, 0xe0, 0x43, 0x76, 0xe5, 0xd7, 0x05, 0xc9, 0x8f, 0x6e, 0xcc,
+	0x5e, 0xf5, 0xfb, 0x72, 0xff, 0xe9, 0xf9, 0xac, 0xfe, 0xd7, 0xda, 0xe7, 0xff, 0x1f, 0x00, 0xae,
+	0xd0, 0x60, 0xec, 0x87, 0x1b, 0x00, 0x00,
 }
@@ -24,6 +24,10 @@ const DownwardmetricsRefreshDuration = 5 * time.Second
 const DownwardmetricsCollectionTimeout = collector.CollectionTimeout
 const qemuVersionUnknown = "qemu-unknown"
 
+// downwardmetricsMaxConcurrentScrapes bounds the VMIs scraped at the same time. The downward metrics
+// are refreshed in the background rather than per metrics request, so max-metric-requests doesn't apply.
+const downwardmetricsMaxConcurrentScrapes = 16
+
 type StaticHostMetrics struct {
 	HostName             string
 	HostSystemInfo       string
@@ -163,7 +167,7 @@ func RunDownwardMetricsCollector(context context.Context, nodeName string, vmiIn
 		isolation: isolation,
 		reporter:  NewReporter(nodeName),
 	}
-	collector := collector.NewConcurrentCollector(1)
+	collector := collector.NewConcurrentCollector(1, downwardmetricsMaxConcurrentScrapes)
 
 	go func() {
 		ticker := time.NewTicker(DownwardmetricsRefreshDuration)
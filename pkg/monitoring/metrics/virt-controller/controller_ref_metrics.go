@@ -0,0 +1,61 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package virt_controller
+
+import (
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+var (
+	controllerRefMetrics = []operatormetrics.Metric{
+		controllerAdoptions,
+		controllerReleases,
+	}
+
+	controllerAdoptions = operatormetrics.NewCounterVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_controller_adoptions_total",
+			Help: "The number of objects adopted by a KubeVirt controller, by kind of the adopted object.",
+		},
+		[]string{"kind"},
+	)
+
+	controllerReleases = operatormetrics.NewCounterVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_controller_releases_total",
+			Help: "The number of objects released by a KubeVirt controller, by kind of the released object.",
+		},
+		[]string{"kind"},
+	)
+)
+
+type controllerRefMetricsProvider struct{}
+
+var _ controller.ControllerRefMetricsProvider = controllerRefMetricsProvider{}
+
+func (controllerRefMetricsProvider) IncAdoptions(kind string) {
+	controllerAdoptions.WithLabelValues(kind).Inc()
+}
+
+func (controllerRefMetricsProvider) IncReleases(kind string) {
+	controllerReleases.WithLabelValues(kind).Inc()
+}
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 
+	"kubevirt.io/kubevirt/pkg/controller"
 	"kubevirt.io/kubevirt/pkg/monitoring/metrics/common/client"
 	"kubevirt.io/kubevirt/pkg/monitoring/metrics/common/workqueue"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -35,6 +36,7 @@ import (
 var (
 	metrics = [][]operatormetrics.Metric{
 		componentMetrics,
+		controllerRefMetrics,
 		migrationMetrics,
 		perfscaleMetrics,
 		vmiMetrics,
@@ -78,6 +80,8 @@ func SetupMetrics(
 		return err
 	}
 
+	controller.SetControllerRefMetricsProvider(controllerRefMetricsProvider{})
+
 	if err := operatormetrics.RegisterMetrics(metrics...); err != nil {
 		return err
 	}
@@ -42,17 +42,33 @@ type ConcurrentCollector struct {
 	lock             sync.Mutex
 	clientsPerKey    map[string]int
 	maxClientsPerKey int
+	maxWorkers       int
 	socketMapper     func(vmis []*k6tv1.VirtualMachineInstance) vmiSocketMap
 }
 
-func NewConcurrentCollector(MaxRequestsPerKey int) Collector {
-	return NewConcurrentCollectorWithMapper(MaxRequestsPerKey, newvmiSocketMapFromVMIs)
+type scrapeJob struct {
+	key string
+	vmi *k6tv1.VirtualMachineInstance
 }
 
-func NewConcurrentCollectorWithMapper(MaxRequestsPerKey int, mapper func(vmis []*k6tv1.VirtualMachineInstance) vmiSocketMap) Collector {
+// NewConcurrentCollector returns a collector which scrapes at most maxConcurrentScrapes sources at the
+// same time during a single collection, so dense nodes do not spawn one goroutine per VMI.
+func NewConcurrentCollector(MaxRequestsPerKey, maxConcurrentScrapes int) Collector {
+	return NewConcurrentCollectorWithMapper(MaxRequestsPerKey, maxConcurrentScrapes, newvmiSocketMapFromVMIs)
+}
+
+func NewConcurrentCollectorWithMapper(MaxRequestsPerKey, maxConcurrentScrapes int, mapper func(vmis []*k6tv1.VirtualMachineInstance) vmiSocketMap) Collector {
+	return newConcurrentCollector(MaxRequestsPerKey, maxConcurrentScrapes, mapper)
+}
+
+func newConcurrentCollector(maxRequestsPerKey, maxWorkers int, mapper func(vmis []*k6tv1.VirtualMachineInstance) vmiSocketMap) *ConcurrentCollector {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
 	return &ConcurrentCollector{
 		clientsPerKey:    make(map[string]int),
-		maxClientsPerKey: MaxRequestsPerKey,
+		maxClientsPerKey: maxRequestsPerKey,
+		maxWorkers:       maxWorkers,
 		socketMapper:     mapper,
 	}
 }
@@ -60,9 +76,10 @@ func NewConcurrentCollectorWithMapper(MaxRequestsPerKey int, mapper func(vmis []
 func (cc *ConcurrentCollector) Collect(vmis []*k6tv1.VirtualMachineInstance, scraper MetricsScraper, timeout time.Duration) ([]string, bool) {
 	socketToVMIs := cc.socketMapper(vmis)
 	log.Log.V(3).Infof("Collecting VM metrics from %d sources", len(socketToVMIs))
-	var busyScrapers sync.WaitGroup
+	deadline := time.Now().Add(timeout)
 
 	var skipped []string
+	jobs := make(chan scrapeJob, len(socketToVMIs))
 	for key, vmi := range socketToVMIs {
 		reserved := cc.reserveKey(key)
 		if !reserved {
@@ -72,8 +89,19 @@ func (cc *ConcurrentCollector) Collect(vmis []*k6tv1.VirtualMachineInstance, scr
 		}
 
 		log.Log.V(4).Infof("Source %s responsive, scraping", key)
+		jobs <- scrapeJob{key: key, vmi: vmi}
+	}
+	close(jobs)
+
+	workers := cc.maxWorkers
+	if len(jobs) < workers {
+		workers = len(jobs)
+	}
+
+	var busyScrapers sync.WaitGroup
+	for i := 0; i < workers; i++ {
 		busyScrapers.Add(1)
-		go cc.collectFromSource(scraper, &busyScrapers, key, vmi)
+		go cc.scrapeWorker(scraper, &busyScrapers, jobs, deadline)
 	}
 
 	completed := true
@@ -85,7 +113,7 @@ func (cc *ConcurrentCollector) Collect(vmis []*k6tv1.VirtualMachineInstance, scr
 	select {
 	case <-c:
 		log.Log.V(3).Infof("Collection successful")
-	case <-time.After(timeout):
+	case <-time.After(time.Until(deadline)):
 		log.Log.Warning("Collection timeout")
 		completed = false
 	}
@@ -96,8 +124,22 @@ func (cc *ConcurrentCollector) Collect(vmis []*k6tv1.VirtualMachineInstance, scr
 	return skipped, completed
 }
 
-func (cc *ConcurrentCollector) collectFromSource(scraper MetricsScraper, wg *sync.WaitGroup, socket string, vmi *k6tv1.VirtualMachineInstance) {
+// scrapeWorker consumes jobs until the queue is drained. Once the collection
+// deadline has passed, the remaining jobs are dropped without being scraped.
+func (cc *ConcurrentCollector) scrapeWorker(scraper MetricsScraper, wg *sync.WaitGroup, jobs <-chan scrapeJob, deadline time.Time) {
 	defer wg.Done()
+
+	for job := range jobs {
+		if time.Now().After(deadline) {
+			log.Log.V(4).Infof("Collection deadline exceeded, not scraping source %s", job.key)
+			cc.releaseKey(job.key)
+			continue
+		}
+		cc.collectFromSource(scraper, job.key, job.vmi)
+	}
+}
+
+func (cc *ConcurrentCollector) collectFromSource(scraper MetricsScraper, socket string, vmi *k6tv1.VirtualMachineInstance) {
 	defer cc.releaseKey(socket)
 
 	log.Log.V(4).Infof("Getting stats from source %s", socket)
@@ -20,6 +20,8 @@
 package collector
 
 import (
+	"fmt"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -40,7 +42,7 @@ var _ = Describe("Collector", func() {
 			}
 			return m
 		}
-		collector := NewConcurrentCollectorWithMapper(retries, fakeMapper)
+		collector := NewConcurrentCollectorWithMapper(retries, len(vmis), fakeMapper)
 		return collector
 	}
 
@@ -55,7 +57,7 @@ var _ = Describe("Collector", func() {
 	Context("on running source", func() {
 		It("should scrape all the sources", func() {
 			fs := newFakeScraper()
-			cc := NewConcurrentCollector(1)
+			cc := NewConcurrentCollector(1, 1)
 
 			skipped, completed := cc.Collect(vmis, fs, 1*time.Second)
 
@@ -131,8 +133,132 @@ var _ = Describe("Collector", func() {
 			Expect(completed).To(BeTrue())
 		})
 	})
+
+	Context("with a bounded worker pool", func() {
+		var newBoundedCollector = func(workers int) Collector {
+			fakeMapper := func(vmi []*k6tv1.VirtualMachineInstance) vmiSocketMap {
+				m := vmiSocketMap{}
+				for _, vmi := range vmi {
+					m[vmi.Name] = vmi
+				}
+				return m
+			}
+			return newConcurrentCollector(1, workers, fakeMapper)
+		}
+
+		var manyVMIs = func(count int) []*k6tv1.VirtualMachineInstance {
+			var vmis []*k6tv1.VirtualMachineInstance
+			for i := 0; i < count; i++ {
+				vmis = append(vmis, &k6tv1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("vmi-%d", i)}})
+			}
+			return vmis
+		}
+
+		DescribeTable("should scrape every source regardless of ordering", func(workers int) {
+			vmis := manyVMIs(20)
+			rs := newRecordingScraper(0)
+			cc := newBoundedCollector(workers)
+
+			skipped, completed := cc.Collect(vmis, rs, 5*time.Second)
+			Expect(skipped).To(BeEmpty())
+			Expect(completed).To(BeTrue())
+
+			var expected []string
+			for _, vmi := range vmis {
+				expected = append(expected, vmi.Name)
+			}
+			Expect(rs.Scraped()).To(ConsistOf(expected))
+			Expect(rs.MaxInFlight()).To(BeNumerically("<=", workers))
+		},
+			Entry("with a single worker", 1),
+			Entry("with fewer workers than sources", 4),
+			Entry("with more workers than sources", 64),
+		)
+
+		It("should stop scraping once the deadline is exceeded", func() {
+			vmis := manyVMIs(10)
+			rs := newRecordingScraper(100 * time.Millisecond)
+			cc := newBoundedCollector(1)
+
+			start := time.Now()
+			skipped, completed := cc.Collect(vmis, rs, 250*time.Millisecond)
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+			Expect(skipped).To(BeEmpty())
+			Expect(completed).To(BeFalse())
+			Expect(rs.IsCompleted()).To(BeTrue())
+
+			By("Waiting for the in-flight scrape to finish")
+			Eventually(rs.InFlight).WithTimeout(time.Second).Should(BeZero())
+			scraped := len(rs.Scraped())
+			Expect(scraped).To(BeNumerically("<", len(vmis)))
+			Consistently(func() int { return len(rs.Scraped()) }).WithTimeout(300 * time.Millisecond).Should(Equal(scraped))
+
+			By("Collecting again with all the sources released")
+			skipped, _ = cc.Collect(vmis, newRecordingScraper(0), time.Second)
+			Expect(skipped).To(BeEmpty())
+		})
+	})
 })
 
+type recordingScraper struct {
+	lock        sync.Mutex
+	delay       time.Duration
+	scraped     []string
+	inFlight    int
+	maxInFlight int
+	completed   bool
+}
+
+func newRecordingScraper(delay time.Duration) *recordingScraper {
+	return &recordingScraper{delay: delay}
+}
+
+func (rs *recordingScraper) Scrape(key string, _ *k6tv1.VirtualMachineInstance) {
+	rs.lock.Lock()
+	rs.inFlight++
+	if rs.inFlight > rs.maxInFlight {
+		rs.maxInFlight = rs.inFlight
+	}
+	rs.lock.Unlock()
+
+	time.Sleep(rs.delay)
+
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	rs.inFlight--
+	rs.scraped = append(rs.scraped, key)
+}
+
+func (rs *recordingScraper) Complete() {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	rs.completed = true
+}
+
+func (rs *recordingScraper) Scraped() []string {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	return append([]string{}, rs.scraped...)
+}
+
+func (rs *recordingScraper) InFlight() int {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	return rs.inFlight
+}
+
+func (rs *recordingScraper) MaxInFlight() int {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	return rs.maxInFlight
+}
+
+func (rs *recordingScraper) IsCompleted() bool {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	return rs.completed
+}
+
 type fakeScraper struct {
 	ready   map[string]chan bool
 	blocked map[string]chan bool
@@ -19,7 +19,10 @@
 package domainstats
 
 import (
+	"time"
+
 	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/client-go/tools/cache"
 	k6tv1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
@@ -40,14 +43,37 @@ var (
 		networkMetrics{},
 		cpuAffinityMetrics{},
 		filesystemMetrics{},
+		stateMetrics{},
+		guestHostnameMetrics{},
+		fsFreezeMetrics{},
+		guestTimezoneMetrics{},
+		guestUsersMetrics{},
+		guestAgentCommandsMetrics{},
 	}
 
 	Collector = operatormetrics.Collector{
-		Metrics:         domainStatsMetrics(rms...),
+		Metrics:         append(domainStatsMetrics(rms...), nodeMemoryMetrics()...),
 		CollectCallback: domainStatsCollectorCallback,
 	}
 
 	settings *collectorSettings
+
+	// maxConcurrentScrapes bounds the VMIs scraped at the same time, 0 scrapes all of them at the same time
+	maxConcurrentScrapes = 0
+
+	CollectorMetrics = []operatormetrics.Metric{
+		collectorDuration,
+	}
+
+	collectorDuration = operatormetrics.NewHistogram(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_guest_collector_duration_seconds",
+			Help: "Histogram of the time spent collecting the domain stats of all the VMIs on the node in seconds.",
+		},
+		prometheus.HistogramOpts{
+			Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15},
+		},
+	)
 )
 
 type resourceMetrics interface {
@@ -71,6 +97,19 @@ func SetupDomainStatsCollector(virtShareDir, nodeName string, maxRequestsInFligh
 	}
 }
 
+// SetMaxConcurrentScrapes configures how many VMIs are scraped at the same time during a collection,
+// 0 scrapes all of them at the same time
+func SetMaxConcurrentScrapes(max int) {
+	maxConcurrentScrapes = max
+}
+
+func concurrentScrapes(vmis int) int {
+	if maxConcurrentScrapes > 0 && maxConcurrentScrapes < vmis {
+		return maxConcurrentScrapes
+	}
+	return vmis
+}
+
 func domainStatsMetrics(rms ...resourceMetrics) []operatormetrics.Metric {
 	var metrics []operatormetrics.Metric
 
@@ -85,7 +124,7 @@ func domainStatsCollectorCallback() []operatormetrics.CollectorResult {
 	cachedObjs := settings.vmiInformer.GetIndexer().List()
 	if len(cachedObjs) == 0 {
 		log.Log.V(4).Infof("No VMIs detected")
-		return []operatormetrics.CollectorResult{}
+		return newNodeMemoryAggregator(settings.nodeName, guestLowMemoryThresholdKB).collect()
 	}
 
 	vmis := make([]*k6tv1.VirtualMachineInstance, len(cachedObjs))
@@ -94,11 +133,18 @@ func domainStatsCollectorCallback() []operatormetrics.CollectorResult {
 		vmis[i] = obj.(*k6tv1.VirtualMachineInstance)
 	}
 
-	concCollector := collector.NewConcurrentCollector(settings.maxRequestsInFlight)
-	return execCollector(concCollector, vmis)
+	start := time.Now()
+	defer func() {
+		collectorDuration.Observe(time.Since(start).Seconds())
+	}()
+	guestAgentInfos.expire(start)
+
+	concCollector := collector.NewConcurrentCollector(settings.maxRequestsInFlight, concurrentScrapes(len(vmis)))
+	nodeMemory := newNodeMemoryAggregator(settings.nodeName, guestLowMemoryThresholdKB)
+	return execCollector(concCollector, vmis, nodeMemory)
 }
 
-func execCollector(concCollector collector.Collector, vmis []*k6tv1.VirtualMachineInstance) []operatormetrics.CollectorResult {
+func execCollector(concCollector collector.Collector, vmis []*k6tv1.VirtualMachineInstance, nodeMemory *nodeMemoryAggregator) []operatormetrics.CollectorResult {
 	scraper := NewDomainstatsScraper(len(vmis))
 	go concCollector.Collect(vmis, scraper, PrometheusCollectionTimeout)
 
@@ -108,7 +154,8 @@ func execCollector(concCollector collector.Collector, vmis []*k6tv1.VirtualMachi
 		for _, rm := range rms {
 			crs = append(crs, rm.Collect(vmiReport)...)
 		}
+		nodeMemory.add(vmiReport)
 	}
 
-	return crs
+	return append(crs, nodeMemory.collect()...)
 }
@@ -75,12 +75,28 @@ var _ = Describe("domain stats collector", func() {
 				vmis:     vmis,
 				vmiStats: vmiStats,
 			}
-			crs := execCollector(concCollector, vmis)
-			Expect(crs).To(HaveLen(2))
+			crs := execCollector(concCollector, vmis, newNodeMemoryAggregator("test-node", DefaultGuestLowMemoryThresholdKB))
+			// the resident memory and the fs freeze status of each vmi
+			Expect(crs).To(HaveLen(2*2 + len(nodeMemoryMetrics())))
 			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(memoryResident, kibibytesToBytes(1))))
 			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(memoryResident, kibibytesToBytes(2))))
 		})
 	})
+
+	Context("concurrent scrapes", func() {
+		AfterEach(func() {
+			SetMaxConcurrentScrapes(0)
+		})
+
+		DescribeTable("should scrape", func(max, vmis, expected int) {
+			SetMaxConcurrentScrapes(max)
+			Expect(concurrentScrapes(vmis)).To(Equal(expected))
+		},
+			Entry("all the VMIs at the same time by default", 0, 200, 200),
+			Entry("at most the configured number of VMIs at the same time", 16, 200, 16),
+			Entry("all the VMIs when there are less than configured", 16, 3, 3),
+		)
+	})
 })
 
 type fakeCollector struct {
@@ -21,6 +21,7 @@ package domainstats
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
 	k6tv1 "kubevirt.io/api/core/v1"
@@ -34,8 +35,76 @@ var (
 
 	// Preffixes used when transforming K8s metadata into metric labels
 	labelPrefix = "kubernetes_vmi_label_"
+
+	vmiLabelsConfig     = DefaultVMILabelsConfig()
+	vmiLabelsConfigLock sync.RWMutex
 )
 
+// VMILabelsConfig controls which VMI labels are propagated into the metric labels
+type VMILabelsConfig struct {
+	// Prefix is prepended to the sanitized VMI label key
+	Prefix string
+	// Allowlist holds the VMI label keys to propagate. When empty, all the labels are propagated.
+	// An entry ending with "*" matches all the keys starting with the part before it.
+	Allowlist []string
+	// Denylist holds the VMI label keys which are never propagated, it takes precedence over the Allowlist.
+	// Its entries match keys like the ones of the Allowlist.
+	Denylist []string
+}
+
+func DefaultVMILabelsConfig() VMILabelsConfig {
+	return VMILabelsConfig{Prefix: labelPrefix}
+}
+
+// NewVMILabelsConfig returns the VMI labels propagation configured by the metrics configuration of the KubeVirt CR
+func NewVMILabelsConfig(config *k6tv1.MetricsConfiguration) VMILabelsConfig {
+	if config == nil {
+		return DefaultVMILabelsConfig()
+	}
+	return VMILabelsConfig{
+		Prefix:    config.VMILabelPrefix,
+		Allowlist: config.VMILabelAllowlist,
+		Denylist:  config.VMILabelDenylist,
+	}
+}
+
+// SetVMILabelsConfig configures the VMI labels propagation, it can be called while the metrics are collected
+func SetVMILabelsConfig(config VMILabelsConfig) {
+	if config.Prefix == "" {
+		config.Prefix = labelPrefix
+	}
+	vmiLabelsConfigLock.Lock()
+	defer vmiLabelsConfigLock.Unlock()
+	vmiLabelsConfig = config
+}
+
+func getVMILabelsConfig() VMILabelsConfig {
+	vmiLabelsConfigLock.RLock()
+	defer vmiLabelsConfigLock.RUnlock()
+	return vmiLabelsConfig
+}
+
+func (c VMILabelsConfig) allows(key string) bool {
+	if matchesAnyLabelKey(key, c.Denylist) {
+		return false
+	}
+
+	return len(c.Allowlist) == 0 || matchesAnyLabelKey(key, c.Allowlist)
+}
+
+func matchesAnyLabelKey(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, isPrefix := strings.CutSuffix(pattern, "*"); isPrefix {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == pattern {
+			return true
+		}
+	}
+	return false
+}
+
 type VirtualMachineInstanceReport struct {
 	vmi           *k6tv1.VirtualMachineInstance
 	vmiStats      *VirtualMachineInstanceStats
@@ -45,6 +114,14 @@ type VirtualMachineInstanceReport struct {
 type VirtualMachineInstanceStats struct {
 	DomainStats *stats.DomainStats
 	FsStats     k6tv1.VirtualMachineInstanceFileSystemList
+	// GuestHostname is the hostname reported by the guest agent, empty when it is not known
+	GuestHostname string
+	// GuestTimezone is the timezone reported by the guest agent, formatted as "<zone>, <offset>"
+	GuestTimezone string
+	// GuestUsers are the users logged in the guest reported by the guest agent, nil when they are not known
+	GuestUsers *k6tv1.VirtualMachineInstanceGuestOSUserList
+	// GuestAgentCommands are the commands advertised by the guest agent
+	GuestAgentCommands []k6tv1.GuestAgentCommandInfo
 }
 
 func newVirtualMachineInstanceReport(vmi *k6tv1.VirtualMachineInstance, vmiStats *VirtualMachineInstanceStats) *VirtualMachineInstanceReport {
@@ -64,8 +141,12 @@ func (vmiReport *VirtualMachineInstanceReport) GetVmiStats() VirtualMachineInsta
 func (vmiReport *VirtualMachineInstanceReport) buildRuntimeLabels() {
 	vmiReport.runtimeLabels = map[string]string{}
 
+	labelsConfig := getVMILabelsConfig()
 	for label, val := range vmiReport.vmi.Labels {
-		key := labelPrefix + labelFormatter.Replace(label)
+		if !labelsConfig.allows(label) {
+			continue
+		}
+		key := labelsConfig.Prefix + labelFormatter.Replace(label)
 		vmiReport.runtimeLabels[key] = val
 	}
 }
@@ -20,6 +20,8 @@
 package domainstats
 
 import (
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -75,4 +77,101 @@ var _ = Describe("domainstats", func() {
 			Expect(cr.ConstLabels).To(HaveKeyWithValue("name", "test-vmi-1"))
 		})
 	})
+
+	Context("VMI labels propagation", func() {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vmi-1",
+				Namespace: "test-ns-1",
+				Labels: map[string]string{
+					"app":                 "web",
+					"kubevirt.io/domain":  "test-vmi-1",
+					"pod-template-hash":   "5d8f9c7b6",
+					"deployment/revision": "42",
+				},
+			},
+		}
+
+		AfterEach(func() {
+			SetVMILabelsConfig(DefaultVMILabelsConfig())
+		})
+
+		It("should propagate all the labels with the default configuration", func() {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(vmiReport.runtimeLabels).To(Equal(map[string]string{
+				"kubernetes_vmi_label_app":                 "web",
+				"kubernetes_vmi_label_kubevirt_io_domain":  "test-vmi-1",
+				"kubernetes_vmi_label_pod_template_hash":   "5d8f9c7b6",
+				"kubernetes_vmi_label_deployment_revision": "42",
+			}))
+		})
+
+		It("should only propagate the allowlisted labels", func() {
+			SetVMILabelsConfig(VMILabelsConfig{Allowlist: []string{"app", "kubevirt.io/domain"}})
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(vmiReport.runtimeLabels).To(Equal(map[string]string{
+				"kubernetes_vmi_label_app":                "web",
+				"kubernetes_vmi_label_kubevirt_io_domain": "test-vmi-1",
+			}))
+		})
+
+		It("should not propagate the denylisted labels, even when allowlisted", func() {
+			SetVMILabelsConfig(VMILabelsConfig{Allowlist: []string{"app", "pod-template-hash"}, Denylist: []string{"pod-template-hash"}})
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(vmiReport.runtimeLabels).To(Equal(map[string]string{
+				"kubernetes_vmi_label_app": "web",
+			}))
+		})
+
+		It("should only propagate the labels matching an allowlisted prefix", func() {
+			vmi := vmi.DeepCopy()
+			for i := 0; i < 50; i++ {
+				vmi.Labels[fmt.Sprintf("build.example.com/commit-%d", i)] = fmt.Sprintf("%040x", i)
+			}
+			vmi.Labels["app.kubernetes.io/name"] = "web"
+			vmi.Labels["app.kubernetes.io/part-of"] = "shop"
+
+			SetVMILabelsConfig(VMILabelsConfig{Allowlist: []string{"app.kubernetes.io/*", "app"}})
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(vmiReport.runtimeLabels).To(Equal(map[string]string{
+				"kubernetes_vmi_label_app":                       "web",
+				"kubernetes_vmi_label_app_kubernetes_io_name":    "web",
+				"kubernetes_vmi_label_app_kubernetes_io_part_of": "shop",
+			}))
+		})
+
+		It("should not propagate the labels matching a denylisted prefix", func() {
+			SetVMILabelsConfig(VMILabelsConfig{Denylist: []string{"kubevirt.io/*", "pod-*"}})
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(vmiReport.runtimeLabels).To(Equal(map[string]string{
+				"kubernetes_vmi_label_app":                 "web",
+				"kubernetes_vmi_label_deployment_revision": "42",
+			}))
+		})
+
+		It("should use the configured prefix", func() {
+			SetVMILabelsConfig(VMILabelsConfig{Prefix: "vmi_label_", Allowlist: []string{"app"}})
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(vmiReport.runtimeLabels).To(Equal(map[string]string{
+				"vmi_label_app": "web",
+			}))
+		})
+
+		It("should use the metrics configuration of the KubeVirt CR", func() {
+			SetVMILabelsConfig(NewVMILabelsConfig(&k6tv1.MetricsConfiguration{
+				VMILabelAllowlist: []string{"app", "kubevirt.io/*"},
+				VMILabelDenylist:  []string{"kubevirt.io/domain"},
+			}))
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(vmiReport.runtimeLabels).To(Equal(map[string]string{
+				"kubernetes_vmi_label_app": "web",
+			}))
+		})
+
+		It("should propagate all the labels when the KubeVirt CR has no metrics configuration", func() {
+			SetVMILabelsConfig(NewVMILabelsConfig(nil))
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(vmiReport.runtimeLabels).To(HaveLen(4))
+		})
+	})
 })
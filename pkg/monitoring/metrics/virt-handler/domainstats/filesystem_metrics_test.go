@@ -63,6 +63,31 @@ var _ = Describe("filesystem metrics", func() {
 			Entry("kubevirt_vmi_filesystem_used_bytes", filesystemUsedBytes, 2.0),
 		)
 
+		It("should distinguish filesystems mounted from the same disk by their mount point", func() {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{
+				FsStats: k6tv1.VirtualMachineInstanceFileSystemList{
+					Items: []k6tv1.VirtualMachineInstanceFileSystem{
+						{DiskName: "vda1", MountPoint: "/", FileSystemType: "ext4", TotalBytes: 10, UsedBytes: 9},
+						{DiskName: "vda1", MountPoint: "/var/lib/data", FileSystemType: "ext4", TotalBytes: 10, UsedBytes: 1},
+					},
+				},
+			})
+
+			crs := filesystemMetrics{}.Collect(vmiReport)
+			Expect(crs).To(HaveLen(4))
+
+			usedByMountPoint := map[string]float64{}
+			for _, cr := range crs {
+				Expect(cr.ConstLabels).To(HaveKeyWithValue("disk_name", "vda1"))
+				Expect(cr.ConstLabels).To(HaveKey("mount_point"))
+				Expect(cr.ConstLabels).To(HaveKey("file_system_type"))
+				if cr.Metric == filesystemUsedBytes {
+					usedByMountPoint[cr.ConstLabels["mount_point"]] = cr.Value
+				}
+			}
+			Expect(usedByMountPoint).To(Equal(map[string]float64{"/": 9, "/var/lib/data": 1}))
+		})
+
 		It("result should be empty if stat not populated or set is false", func() {
 			vmiStats.FsStats.Items = []k6tv1.VirtualMachineInstanceFileSystem{}
 			crs := filesystemMetrics{}.Collect(vmiReport)
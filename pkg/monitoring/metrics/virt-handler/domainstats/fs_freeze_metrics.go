@@ -0,0 +1,67 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	fsFreezeStatusThawed  = 0.0
+	fsFreezeStatusFrozen  = 1.0
+	fsFreezeStatusUnknown = -1.0
+)
+
+var (
+	fsFreezeStatus = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_fs_freeze_status",
+			Help: "The freeze status of the guest filesystems in the VMI status. 0 when thawed, 1 when frozen and -1 when the status is unknown.",
+		},
+	)
+)
+
+type fsFreezeMetrics struct{}
+
+func (fsFreezeMetrics) Describe() []operatormetrics.Metric {
+	return []operatormetrics.Metric{
+		fsFreezeStatus,
+	}
+}
+
+func (fsFreezeMetrics) Collect(vmiReport *VirtualMachineInstanceReport) []operatormetrics.CollectorResult {
+	return []operatormetrics.CollectorResult{
+		vmiReport.newCollectorResult(fsFreezeStatus, fsFreezeStatusValue(vmiReport.vmi.Status.FSFreezeStatus)),
+	}
+}
+
+// fsFreezeStatusValue maps the freeze status of the VMI status, which virt-handler clears once the guest is thawed
+func fsFreezeStatusValue(status string) float64 {
+	switch status {
+	case "", api.FSThawed:
+		return fsFreezeStatusThawed
+	case api.FSFrozen:
+		return fsFreezeStatusFrozen
+	default:
+		return fsFreezeStatusUnknown
+	}
+}
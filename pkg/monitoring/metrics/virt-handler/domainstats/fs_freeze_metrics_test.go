@@ -0,0 +1,54 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/monitoring/metrics/testing"
+)
+
+var _ = Describe("fs freeze metrics", func() {
+	Context("on Collect", func() {
+		DescribeTable("should map the fsfreeze status of the VMI to the metric value", func(status string, expectedValue float64) {
+			vmi := &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-vmi-1",
+					Namespace: "test-ns-1",
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{FSFreezeStatus: status},
+			}
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+
+			crs := fsFreezeMetrics{}.Collect(vmiReport)
+			Expect(crs).To(HaveLen(1))
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(fsFreezeStatus, expectedValue)))
+		},
+			Entry("thawed", "thawed", 0.0),
+			Entry("cleared once thawed", "", 0.0),
+			Entry("frozen", "frozen", 1.0),
+			Entry("unknown status", "freezing", -1.0),
+		)
+	})
+})
@@ -0,0 +1,54 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+)
+
+var (
+	guestAgentSupportedCommands = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_guest_agent_supported_commands",
+			Help: "The commands enabled in the guest agent, one series per command exposed in the `command` label.",
+		},
+	)
+)
+
+type guestAgentCommandsMetrics struct{}
+
+func (guestAgentCommandsMetrics) Describe() []operatormetrics.Metric {
+	return []operatormetrics.Metric{
+		guestAgentSupportedCommands,
+	}
+}
+
+func (guestAgentCommandsMetrics) Collect(vmiReport *VirtualMachineInstanceReport) []operatormetrics.CollectorResult {
+	var crs []operatormetrics.CollectorResult
+
+	for _, command := range vmiReport.vmiStats.GuestAgentCommands {
+		if !command.Enabled || command.Name == "" {
+			continue
+		}
+		crs = append(crs, vmiReport.newCollectorResultWithLabels(guestAgentSupportedCommands, 1.0, map[string]string{"command": command.Name}))
+	}
+
+	return crs
+}
@@ -0,0 +1,67 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/monitoring/metrics/testing"
+)
+
+var _ = Describe("guest agent commands metrics", func() {
+	Context("on Collect", func() {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vmi-1",
+				Namespace: "test-ns-1",
+			},
+		}
+
+		It("should report one series per enabled command", func() {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{
+				GuestAgentCommands: []k6tv1.GuestAgentCommandInfo{
+					{Name: "guest-ping", Enabled: true},
+					{Name: "guest-exec", Enabled: false},
+					{Name: "guest-get-osinfo", Enabled: true},
+					{Name: "guest-fsfreeze-freeze", Enabled: false},
+				},
+			})
+
+			crs := guestAgentCommandsMetrics{}.Collect(vmiReport)
+			Expect(crs).To(HaveLen(2))
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(guestAgentSupportedCommands, 1.0)))
+
+			var commands []string
+			for _, cr := range crs {
+				commands = append(commands, cr.ConstLabels["command"])
+			}
+			Expect(commands).To(ConsistOf("guest-ping", "guest-get-osinfo"))
+		})
+
+		It("result should be empty if the guest agent does not advertise commands", func() {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(guestAgentCommandsMetrics{}.Collect(vmiReport)).To(BeEmpty())
+		})
+	})
+})
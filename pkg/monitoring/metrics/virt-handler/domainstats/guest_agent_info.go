@@ -0,0 +1,118 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	"sync"
+	"time"
+
+	k6tv1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
+
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+)
+
+// guestAgentInfoMaxAge is how long the guest agent data of a VMI is reused by the following scrapes.
+// virt-launcher polls the agent every few seconds to minutes, so fetching the data on every scrape of
+// every VMI mostly adds calls to the launchers.
+const guestAgentInfoMaxAge = 30 * time.Second
+
+var guestAgentInfos = newGuestAgentInfoCache(guestAgentInfoMaxAge)
+
+type guestAgentInfo struct {
+	fetched time.Time
+
+	hostname          string
+	timezone          string
+	users             *k6tv1.VirtualMachineInstanceGuestOSUserList
+	supportedCommands []k6tv1.GuestAgentCommandInfo
+}
+
+// guestAgentInfoCache keeps the guest agent data of the VMIs by the socket of their launcher.
+// The data of VMIs without an agent is kept as well, so that they aren't asked on every scrape either.
+type guestAgentInfoCache struct {
+	lock   sync.Mutex
+	maxAge time.Duration
+	infos  map[string]*guestAgentInfo
+}
+
+func newGuestAgentInfoCache(maxAge time.Duration) *guestAgentInfoCache {
+	return &guestAgentInfoCache{
+		maxAge: maxAge,
+		infos:  map[string]*guestAgentInfo{},
+	}
+}
+
+// get returns the guest agent data of the launcher listening on the socket, it is fetched
+// when none was fetched yet or when it is older than maxAge.
+func (c *guestAgentInfoCache) get(socketFile string, cli cmdclient.LauncherClient, now time.Time) *guestAgentInfo {
+	c.lock.Lock()
+	info, exists := c.infos[socketFile]
+	c.lock.Unlock()
+	if exists && now.Sub(info.fetched) < c.maxAge {
+		return info
+	}
+
+	info = fetchGuestAgentInfo(socketFile, cli)
+	info.fetched = now
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.infos[socketFile] = info
+	return info
+}
+
+// expire drops the data older than maxAge, e.g. of the VMIs which are gone.
+func (c *guestAgentInfoCache) expire(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for socketFile, info := range c.infos {
+		if now.Sub(info.fetched) >= c.maxAge {
+			delete(c.infos, socketFile)
+		}
+	}
+}
+
+func fetchGuestAgentInfo(socketFile string, cli cmdclient.LauncherClient) *guestAgentInfo {
+	info := &guestAgentInfo{}
+
+	// The guest agent data is optional, the guest may not run an agent at all
+	guestInfo, err := cli.GetGuestInfo()
+	if err != nil {
+		log.Log.V(4).Reason(err).Infof("failed to get the guest agent info from socket %s", socketFile)
+		return info
+	} else if guestInfo == nil {
+		return info
+	}
+	info.hostname = guestInfo.Hostname
+	info.timezone = guestInfo.Timezone
+	info.supportedCommands = guestInfo.SupportedCommands
+
+	// The guest info only carries the first users, fetch the whole list when an agent is connected
+	if guestInfo.GAVersion != "" {
+		users, err := cli.GetUsers()
+		if err != nil {
+			log.Log.V(4).Reason(err).Infof("failed to get the guest users from socket %s", socketFile)
+		} else {
+			info.users = &users
+		}
+	}
+	return info
+}
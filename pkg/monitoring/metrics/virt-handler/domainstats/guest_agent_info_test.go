@@ -0,0 +1,91 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+)
+
+var _ = Describe("guest agent info cache", func() {
+	const socketFile = "/var/run/kubevirt/sockets/launcher-sock"
+
+	var (
+		client *cmdclient.MockLauncherClient
+		cache  *guestAgentInfoCache
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		client = cmdclient.NewMockLauncherClient(gomock.NewController(GinkgoT()))
+		cache = newGuestAgentInfoCache(guestAgentInfoMaxAge)
+		now = time.Now()
+	})
+
+	It("should fetch the guest agent info and users once within the max age", func() {
+		users := k6tv1.VirtualMachineInstanceGuestOSUserList{Items: []k6tv1.VirtualMachineInstanceGuestOSUser{{UserName: "root"}}}
+		client.EXPECT().GetGuestInfo().Return(&k6tv1.VirtualMachineInstanceGuestAgentInfo{
+			GAVersion: "8.1.0",
+			Hostname:  "guest",
+			Timezone:  "UTC, 0",
+		}, nil).Times(1)
+		client.EXPECT().GetUsers().Return(users, nil).Times(1)
+
+		info := cache.get(socketFile, client, now)
+		Expect(info.hostname).To(Equal("guest"))
+		Expect(info.timezone).To(Equal("UTC, 0"))
+		Expect(info.users).To(Equal(&users))
+
+		Expect(cache.get(socketFile, client, now.Add(guestAgentInfoMaxAge-time.Second))).To(BeIdenticalTo(info))
+	})
+
+	It("should fetch the guest agent info again once it is older than the max age", func() {
+		client.EXPECT().GetGuestInfo().Return(&k6tv1.VirtualMachineInstanceGuestAgentInfo{Hostname: "guest"}, nil)
+		client.EXPECT().GetGuestInfo().Return(&k6tv1.VirtualMachineInstanceGuestAgentInfo{Hostname: "renamed"}, nil)
+
+		Expect(cache.get(socketFile, client, now).hostname).To(Equal("guest"))
+		Expect(cache.get(socketFile, client, now.Add(guestAgentInfoMaxAge)).hostname).To(Equal("renamed"))
+	})
+
+	It("should not ask again for the guest agent info of a VMI without an agent within the max age", func() {
+		client.EXPECT().GetGuestInfo().Return(nil, fmt.Errorf("no agent")).Times(1)
+
+		Expect(cache.get(socketFile, client, now)).To(Equal(&guestAgentInfo{fetched: now}))
+		Expect(cache.get(socketFile, client, now.Add(time.Second))).To(Equal(&guestAgentInfo{fetched: now}))
+	})
+
+	It("should expire the guest agent info older than the max age", func() {
+		client.EXPECT().GetGuestInfo().Return(&k6tv1.VirtualMachineInstanceGuestAgentInfo{}, nil).Times(2)
+		cache.get(socketFile, client, now)
+		cache.get("other-sock", client, now.Add(time.Second))
+
+		cache.expire(now.Add(guestAgentInfoMaxAge))
+		Expect(cache.infos).To(HaveLen(1))
+		Expect(cache.infos).To(HaveKey("other-sock"))
+	})
+})
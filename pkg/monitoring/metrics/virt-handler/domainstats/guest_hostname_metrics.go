@@ -0,0 +1,68 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	"strings"
+
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+)
+
+var (
+	guestHostnameMatches = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_guest_hostname_matches",
+			Help: "Whether the hostname reported by the guest agent matches the VMI name, ignoring case. 1 when it matches, 0 otherwise.",
+		},
+	)
+
+	guestHostnameInfo = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_guest_hostname_info",
+			Help: "The hostname reported by the guest agent, exposed in the `hostname` label.",
+		},
+	)
+)
+
+type guestHostnameMetrics struct{}
+
+func (guestHostnameMetrics) Describe() []operatormetrics.Metric {
+	return []operatormetrics.Metric{
+		guestHostnameMatches,
+		guestHostnameInfo,
+	}
+}
+
+func (guestHostnameMetrics) Collect(vmiReport *VirtualMachineInstanceReport) []operatormetrics.CollectorResult {
+	hostname := vmiReport.vmiStats.GuestHostname
+	if hostname == "" {
+		return nil
+	}
+
+	matches := 0.0
+	if strings.EqualFold(hostname, vmiReport.vmi.Name) {
+		matches = 1.0
+	}
+
+	return []operatormetrics.CollectorResult{
+		vmiReport.newCollectorResult(guestHostnameMatches, matches),
+		vmiReport.newCollectorResultWithLabels(guestHostnameInfo, 1.0, map[string]string{"hostname": hostname}),
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/monitoring/metrics/testing"
+)
+
+var _ = Describe("guest hostname metrics", func() {
+	Context("on Collect", func() {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vmi-1",
+				Namespace: "test-ns-1",
+			},
+		}
+
+		DescribeTable("should report whether the guest hostname matches the VMI name", func(hostname string, expectedMatch float64) {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{GuestHostname: hostname})
+
+			crs := guestHostnameMetrics{}.Collect(vmiReport)
+			Expect(crs).To(HaveLen(2))
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(guestHostnameMatches, expectedMatch)))
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(guestHostnameInfo, 1.0)))
+			for _, cr := range crs {
+				if cr.Metric == guestHostnameInfo {
+					Expect(cr.ConstLabels).To(HaveKeyWithValue("hostname", hostname))
+				}
+			}
+		},
+			Entry("when it matches", "test-vmi-1", 1.0),
+			Entry("when it matches ignoring case", "Test-VMI-1", 1.0),
+			Entry("when the guest was cloned from another VM", "golden-image", 0.0),
+			Entry("when the guest reports a fully qualified name", "test-vmi-1.example.com", 0.0),
+		)
+
+		It("result should be empty if the guest hostname is not known", func() {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(guestHostnameMetrics{}.Collect(vmiReport)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,91 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+)
+
+var (
+	guestTimezoneOffset = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_guest_timezone_offset_seconds",
+			Help: "The offset to UTC of the guest timezone reported by the guest agent, in seconds. Positive east of UTC, negative west of it.",
+		},
+	)
+
+	guestTimezoneOffsetDifference = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_guest_timezone_offset_difference_seconds",
+			Help: "The guest timezone offset minus the node timezone offset, in seconds. This compares the configured timezones only, not the guest clock.",
+		},
+	)
+
+	// nodeTimezoneOffset returns the offset to UTC of the node timezone, in seconds east of UTC
+	nodeTimezoneOffset = func() int {
+		_, offset := time.Now().Zone()
+		return offset
+	}
+)
+
+type guestTimezoneMetrics struct{}
+
+func (guestTimezoneMetrics) Describe() []operatormetrics.Metric {
+	return []operatormetrics.Metric{
+		guestTimezoneOffset,
+		guestTimezoneOffsetDifference,
+	}
+}
+
+func (guestTimezoneMetrics) Collect(vmiReport *VirtualMachineInstanceReport) []operatormetrics.CollectorResult {
+	offset, ok := parseGuestTimezoneOffset(vmiReport.vmiStats.GuestTimezone)
+	if !ok {
+		return nil
+	}
+
+	return []operatormetrics.CollectorResult{
+		vmiReport.newCollectorResult(guestTimezoneOffset, float64(offset)),
+		vmiReport.newCollectorResult(guestTimezoneOffsetDifference, float64(offset-nodeTimezoneOffset())),
+	}
+}
+
+// parseGuestTimezoneOffset extracts the offset from the "<zone>, <offset>" timezone of the guest agent info.
+// The offset follows the guest agent convention, seconds east of UTC.
+// An empty zone with a zero offset means the guest agent did not report the timezone.
+func parseGuestTimezoneOffset(timezone string) (int, bool) {
+	separator := strings.LastIndex(timezone, ",")
+	if separator == -1 {
+		return 0, false
+	}
+
+	zone := strings.TrimSpace(timezone[:separator])
+	offset, err := strconv.Atoi(strings.TrimSpace(timezone[separator+1:]))
+	if err != nil {
+		return 0, false
+	}
+	if zone == "" && offset == 0 {
+		return 0, false
+	}
+	return offset, true
+}
@@ -0,0 +1,71 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/monitoring/metrics/testing"
+)
+
+var _ = Describe("guest timezone metrics", func() {
+	Context("on Collect", func() {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vmi-1",
+				Namespace: "test-ns-1",
+			},
+		}
+
+		BeforeEach(func() {
+			origNodeTimezoneOffset := nodeTimezoneOffset
+			nodeTimezoneOffset = func() int { return 3600 }
+			DeferCleanup(func() { nodeTimezoneOffset = origNodeTimezoneOffset })
+		})
+
+		DescribeTable("should report the guest timezone offset and its difference to the node one", func(timezone string, expectedOffset, expectedDifference float64) {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{GuestTimezone: timezone})
+
+			crs := guestTimezoneMetrics{}.Collect(vmiReport)
+			Expect(crs).To(HaveLen(2))
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(guestTimezoneOffset, expectedOffset)))
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(guestTimezoneOffsetDifference, expectedDifference)))
+		},
+			Entry("with the node timezone", "CET, 3600", 3600.0, 0.0),
+			Entry("east of the node", "IST, 19800", 19800.0, 16200.0),
+			Entry("west of UTC", "EST, -18000", -18000.0, -21600.0),
+			Entry("in UTC", "UTC, 0", 0.0, -3600.0),
+			Entry("with a zone containing a comma", "Pacific Standard Time, Canada, -28800", -28800.0, -32400.0),
+		)
+
+		DescribeTable("result should be empty", func(timezone string) {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{GuestTimezone: timezone})
+			Expect(guestTimezoneMetrics{}.Collect(vmiReport)).To(BeEmpty())
+		},
+			Entry("if the guest agent info is not known", ""),
+			Entry("if the guest agent did not report the timezone", ", 0"),
+			Entry("if the offset is malformed", "CET, one hour"),
+		)
+	})
+})
@@ -0,0 +1,72 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+)
+
+var (
+	guestLoggedInUsers = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_guest_logged_in_users",
+			Help: "The number of users logged in the guest, as reported by the guest agent.",
+		},
+	)
+
+	guestEarliestLoginTimestamp = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_guest_earliest_login_timestamp_seconds",
+			Help: "The login time of the longest logged in user of the guest, in seconds since the epoch, as reported by the guest agent.",
+		},
+	)
+)
+
+type guestUsersMetrics struct{}
+
+func (guestUsersMetrics) Describe() []operatormetrics.Metric {
+	return []operatormetrics.Metric{
+		guestLoggedInUsers,
+		guestEarliestLoginTimestamp,
+	}
+}
+
+func (guestUsersMetrics) Collect(vmiReport *VirtualMachineInstanceReport) []operatormetrics.CollectorResult {
+	users := vmiReport.vmiStats.GuestUsers
+	if users == nil {
+		return nil
+	}
+
+	crs := []operatormetrics.CollectorResult{
+		vmiReport.newCollectorResult(guestLoggedInUsers, float64(len(users.Items))),
+	}
+
+	var earliestLogin float64
+	for _, user := range users.Items {
+		if user.LoginTime > 0 && (earliestLogin == 0 || user.LoginTime < earliestLogin) {
+			earliestLogin = user.LoginTime
+		}
+	}
+	if earliestLogin > 0 {
+		crs = append(crs, vmiReport.newCollectorResult(guestEarliestLoginTimestamp, earliestLogin))
+	}
+
+	return crs
+}
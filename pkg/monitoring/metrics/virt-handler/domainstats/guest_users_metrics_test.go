@@ -0,0 +1,73 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/monitoring/metrics/testing"
+)
+
+var _ = Describe("guest users metrics", func() {
+	Context("on Collect", func() {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vmi-1",
+				Namespace: "test-ns-1",
+			},
+		}
+
+		It("should report the logged in users and the earliest login", func() {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{
+				GuestUsers: &k6tv1.VirtualMachineInstanceGuestOSUserList{
+					Items: []k6tv1.VirtualMachineInstanceGuestOSUser{
+						{UserName: "root", LoginTime: 1700000200.5},
+						{UserName: "admin", Domain: "CORP", LoginTime: 1700000100.25},
+						{UserName: "fedora", LoginTime: 1700000300},
+					},
+				},
+			})
+
+			crs := guestUsersMetrics{}.Collect(vmiReport)
+			Expect(crs).To(HaveLen(2))
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(guestLoggedInUsers, 3.0)))
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(guestEarliestLoginTimestamp, 1700000100.25)))
+		})
+
+		It("should report no logged in users", func() {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{
+				GuestUsers: &k6tv1.VirtualMachineInstanceGuestOSUserList{},
+			})
+
+			crs := guestUsersMetrics{}.Collect(vmiReport)
+			Expect(crs).To(HaveLen(1))
+			Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(guestLoggedInUsers, 0.0)))
+		})
+
+		It("result should be empty if the guest users are not known", func() {
+			vmiReport := newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{})
+			Expect(guestUsersMetrics{}.Collect(vmiReport)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,109 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import "github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+
+// DefaultGuestLowMemoryThresholdKB is the guest usable memory under which a guest is counted as low on memory
+const DefaultGuestLowMemoryThresholdKB = 100 * 1024
+
+var (
+	guestLowMemoryThresholdKB uint64 = DefaultGuestLowMemoryThresholdKB
+
+	nodeGuests = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_node_guests",
+			Help: "The number of guests reporting domain stats on the node.",
+		},
+	)
+
+	nodeGuestMemoryAvailableBytes = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_node_guest_memory_available_bytes",
+			Help: "The memory which can be reclaimed by the balloon without pushing the guests to swap, summed over all the guests on the node, in bytes.",
+		},
+	)
+
+	nodeGuestsLowMemory = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_node_guests_low_memory",
+			Help: "The number of guests on the node whose usable memory is below the configured threshold.",
+		},
+	)
+)
+
+// SetGuestLowMemoryThresholdKB configures the usable memory threshold, in kilobytes,
+// under which a guest is counted by kubevirt_node_guests_low_memory
+func SetGuestLowMemoryThresholdKB(threshold uint64) {
+	guestLowMemoryThresholdKB = threshold
+}
+
+func nodeMemoryMetrics() []operatormetrics.Metric {
+	return []operatormetrics.Metric{
+		nodeGuests,
+		nodeGuestMemoryAvailableBytes,
+		nodeGuestsLowMemory,
+	}
+}
+
+// nodeMemoryAggregator accumulates the guest memory of the reports of a single collection
+// and emits the node level aggregates once the collection is done
+type nodeMemoryAggregator struct {
+	nodeName    string
+	thresholdKB uint64
+
+	guests      int
+	usableSumKB uint64
+	lowMemory   int
+}
+
+func newNodeMemoryAggregator(nodeName string, thresholdKB uint64) *nodeMemoryAggregator {
+	return &nodeMemoryAggregator{
+		nodeName:    nodeName,
+		thresholdKB: thresholdKB,
+	}
+}
+
+func (a *nodeMemoryAggregator) add(vmiReport *VirtualMachineInstanceReport) {
+	if vmiReport.vmiStats.DomainStats == nil {
+		return
+	}
+	a.guests++
+
+	mem := vmiReport.vmiStats.DomainStats.Memory
+	if mem == nil || !mem.UsableSet {
+		return
+	}
+
+	a.usableSumKB += mem.Usable
+	if mem.Usable < a.thresholdKB {
+		a.lowMemory++
+	}
+}
+
+func (a *nodeMemoryAggregator) collect() []operatormetrics.CollectorResult {
+	labels := map[string]string{"node": a.nodeName}
+
+	return []operatormetrics.CollectorResult{
+		{Metric: nodeGuests, ConstLabels: labels, Value: float64(a.guests)},
+		{Metric: nodeGuestMemoryAvailableBytes, ConstLabels: labels, Value: kibibytesToBytes(a.usableSumKB)},
+		{Metric: nodeGuestsLowMemory, ConstLabels: labels, Value: float64(a.lowMemory)},
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/monitoring/metrics/testing"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+var _ = Describe("node memory metrics", func() {
+	const (
+		nodeName    = "test-node"
+		thresholdKB = 1024
+	)
+
+	newReport := func(name string, memory *stats.DomainStatsMemory) *VirtualMachineInstanceReport {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-ns",
+			},
+		}
+		return newVirtualMachineInstanceReport(vmi, &VirtualMachineInstanceStats{
+			DomainStats: &stats.DomainStats{Memory: memory},
+		})
+	}
+
+	It("should sum the usable memory and count the guests below the threshold", func() {
+		aggregator := newNodeMemoryAggregator(nodeName, thresholdKB)
+		for _, report := range []*VirtualMachineInstanceReport{
+			newReport("above-threshold", &stats.DomainStatsMemory{UsableSet: true, Usable: 4096}),
+			newReport("at-threshold", &stats.DomainStatsMemory{UsableSet: true, Usable: thresholdKB}),
+			newReport("below-threshold", &stats.DomainStatsMemory{UsableSet: true, Usable: 512}),
+			newReport("no-usable-memory", &stats.DomainStatsMemory{RSSSet: true, RSS: 2048}),
+			newReport("no-memory-stats", nil),
+		} {
+			aggregator.add(report)
+		}
+
+		crs := aggregator.collect()
+		Expect(crs).To(HaveLen(3))
+		Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(nodeGuests, 5)))
+		Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(nodeGuestMemoryAvailableBytes, (4096+thresholdKB+512)*1024)))
+		Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(nodeGuestsLowMemory, 1)))
+		for _, cr := range crs {
+			Expect(cr.ConstLabels).To(HaveKeyWithValue("node", nodeName))
+		}
+	})
+
+	It("should not count reports without domain stats", func() {
+		aggregator := newNodeMemoryAggregator(nodeName, thresholdKB)
+		aggregator.add(newVirtualMachineInstanceReport(&k6tv1.VirtualMachineInstance{}, &VirtualMachineInstanceStats{}))
+
+		crs := aggregator.collect()
+		Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(nodeGuests, 0)))
+		Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(nodeGuestMemoryAvailableBytes, 0)))
+		Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(nodeGuestsLowMemory, 0)))
+	})
+
+	It("should emit the aggregates once at the end of the collection", func() {
+		vmis := []*k6tv1.VirtualMachineInstance{
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-vmi-1", Namespace: "test-ns"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-vmi-2", Namespace: "test-ns"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "test-vmi-3", Namespace: "test-ns"}},
+		}
+		vmiStats := []*VirtualMachineInstanceStats{
+			{DomainStats: &stats.DomainStats{Memory: &stats.DomainStatsMemory{UsableSet: true, Usable: 100}}},
+			{DomainStats: &stats.DomainStats{Memory: &stats.DomainStatsMemory{UsableSet: true, Usable: 200}}},
+			{DomainStats: &stats.DomainStats{Memory: &stats.DomainStatsMemory{UsableSet: true, Usable: 2048}}},
+		}
+
+		crs := execCollector(fakeCollector{vmis: vmis, vmiStats: vmiStats}, vmis, newNodeMemoryAggregator(nodeName, thresholdKB))
+
+		var guestCounts []float64
+		for _, cr := range crs {
+			if cr.Metric.GetOpts().Name == nodeGuests.GetOpts().Name {
+				guestCounts = append(guestCounts, cr.Value)
+			}
+		}
+		Expect(guestCounts).To(Equal([]float64{3}))
+		Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(nodeGuestMemoryAvailableBytes, 2348*1024)))
+		Expect(crs).To(ContainElement(testing.GomegaContainsCollectorResultMatcher(nodeGuestsLowMemory, 2)))
+	})
+})
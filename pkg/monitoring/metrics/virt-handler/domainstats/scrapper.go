@@ -119,5 +119,11 @@ func gatherMetrics(socketFile string) (bool, *VirtualMachineInstanceStats, error
 		return false, nil, fmt.Errorf("failed to update filesystem stats from socket %s: %w", socketFile, err)
 	}
 
+	guestInfo := guestAgentInfos.get(socketFile, cli, time.Now())
+	vmStats.GuestHostname = guestInfo.hostname
+	vmStats.GuestTimezone = guestInfo.timezone
+	vmStats.GuestUsers = guestInfo.users
+	vmStats.GuestAgentCommands = guestInfo.supportedCommands
+
 	return exists, vmStats, nil
 }
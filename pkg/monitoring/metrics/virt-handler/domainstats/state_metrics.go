@@ -0,0 +1,73 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+var (
+	guestStateMap = map[int]string{
+		stats.DomainNoState:     "NoState",
+		stats.DomainRunning:     "Running",
+		stats.DomainBlocked:     "Blocked",
+		stats.DomainPaused:      "Paused",
+		stats.DomainShutdown:    "ShuttingDown",
+		stats.DomainShutoff:     "Shutoff",
+		stats.DomainCrashed:     "Crashed",
+		stats.DomainPMSuspended: "PMSuspended",
+	}
+
+	guestState = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_guest_state",
+			Help: "State of the domain as reported by libvirt. One series per known `state` [`NoState`, `Running`, `Blocked`, `Paused`, `ShuttingDown`, `Shutoff`, `Crashed`, `PMSuspended`], set to 1 for the current state and 0 for the others.",
+		},
+	)
+)
+
+type stateMetrics struct{}
+
+func (stateMetrics) Describe() []operatormetrics.Metric {
+	return []operatormetrics.Metric{
+		guestState,
+	}
+}
+
+func (stateMetrics) Collect(vmiReport *VirtualMachineInstanceReport) []operatormetrics.CollectorResult {
+	var crs []operatormetrics.CollectorResult
+
+	if vmiReport.vmiStats.DomainStats == nil || vmiReport.vmiStats.DomainStats.State == nil || !vmiReport.vmiStats.DomainStats.State.StateSet {
+		return crs
+	}
+
+	current := vmiReport.vmiStats.DomainStats.State.State
+	for state, name := range guestStateMap {
+		value := 0.0
+		if state == current {
+			value = 1.0
+		}
+		crs = append(crs, vmiReport.newCollectorResultWithLabels(guestState, value, map[string]string{"state": name}))
+	}
+
+	return crs
+}
@@ -0,0 +1,95 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package domainstats
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k6tv1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+var _ = Describe("state metrics", func() {
+	Context("on Collect", func() {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vmi-1",
+				Namespace: "test-ns-1",
+			},
+		}
+
+		It("should emit one series per known state with only the current one set", func() {
+			vmiStats := &VirtualMachineInstanceStats{
+				DomainStats: &stats.DomainStats{
+					State: &stats.DomainStatsState{
+						StateSet: true,
+						State:    stats.DomainPaused,
+					},
+				},
+			}
+			crs := stateMetrics{}.Collect(newVirtualMachineInstanceReport(vmi, vmiStats))
+			Expect(crs).To(HaveLen(len(guestStateMap)))
+
+			values := map[string]float64{}
+			for _, cr := range crs {
+				Expect(cr.Metric).To(Equal(guestState))
+				values[cr.ConstLabels["state"]] = cr.Value
+			}
+			for _, name := range guestStateMap {
+				expected := 0.0
+				if name == "Paused" {
+					expected = 1.0
+				}
+				Expect(values).To(HaveKeyWithValue(name, expected))
+			}
+		})
+
+		It("should report every known state as unset for an unknown state", func() {
+			vmiStats := &VirtualMachineInstanceStats{
+				DomainStats: &stats.DomainStats{
+					State: &stats.DomainStatsState{
+						StateSet: true,
+						State:    42,
+					},
+				},
+			}
+			crs := stateMetrics{}.Collect(newVirtualMachineInstanceReport(vmi, vmiStats))
+			Expect(crs).To(HaveLen(len(guestStateMap)))
+			for _, cr := range crs {
+				Expect(cr.Value).To(BeZero())
+			}
+		})
+
+		It("result should be empty if stat not populated or set is false", func() {
+			vmiStats := &VirtualMachineInstanceStats{
+				DomainStats: &stats.DomainStats{
+					State: &stats.DomainStatsState{StateSet: false},
+				},
+			}
+			Expect(stateMetrics{}.Collect(newVirtualMachineInstanceReport(vmi, vmiStats))).To(BeEmpty())
+
+			vmiStats.DomainStats.State = nil
+			Expect(stateMetrics{}.Collect(newVirtualMachineInstanceReport(vmi, vmiStats))).To(BeEmpty())
+		})
+	})
+})
@@ -26,6 +26,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/monitoring/metrics/common/workqueue"
 	"kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-handler/domainstats"
 	"kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-handler/migrationdomainstats"
+	"kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-handler/vmiphase"
 )
 
 func SetupMetrics(virtShareDir, nodeName string, MaxRequestsInFlight int, vmiInformer cache.SharedIndexInformer) error {
@@ -37,7 +38,7 @@ func SetupMetrics(virtShareDir, nodeName string, MaxRequestsInFlight int, vmiInf
 		return err
 	}
 
-	if err := operatormetrics.RegisterMetrics(versionMetrics); err != nil {
+	if err := operatormetrics.RegisterMetrics(versionMetrics, migrationProxyMetrics, domainstats.CollectorMetrics, vmiphase.Metrics); err != nil {
 		return err
 	}
 	SetVersionInfo()
@@ -48,7 +49,11 @@ func SetupMetrics(virtShareDir, nodeName string, MaxRequestsInFlight int, vmiInf
 		return err
 	}
 
-	return operatormetrics.RegisterCollector(domainstats.Collector, migrationdomainstats.MigrationStatsCollector)
+	if err := vmiphase.SetupVMIPhaseCollector(vmiInformer); err != nil {
+		return err
+	}
+
+	return operatormetrics.RegisterCollector(domainstats.Collector, migrationdomainstats.MigrationStatsCollector, vmiphase.VMIPhaseCollector)
 }
 
 func ListMetrics() []operatormetrics.Metric {
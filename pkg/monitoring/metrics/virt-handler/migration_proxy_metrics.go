@@ -0,0 +1,85 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package virt_handler
+
+import (
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+)
+
+var (
+	migrationProxyMetrics = []operatormetrics.Metric{
+		migrationProxyConnections,
+		migrationProxyBytes,
+		migrationProxyErrors,
+		migrationProxyReconnects,
+	}
+
+	migrationProxyConnections = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_migration_proxy_connections",
+			Help: "Amount of open connections of the migration proxies of virt-handler.",
+		},
+	)
+
+	migrationProxyBytes = operatormetrics.NewCounterVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_migration_proxy_bytes_total",
+			Help: "The total number of bytes copied by the migration proxies of virt-handler, broken down by direction.",
+		},
+		[]string{"direction"},
+	)
+
+	migrationProxyErrors = operatormetrics.NewCounter(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_migration_proxy_errors_total",
+			Help: "The total number of migration proxy connections which could not be established or failed while copying.",
+		},
+	)
+
+	migrationProxyReconnects = operatormetrics.NewCounter(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_migration_proxy_reconnects_total",
+			Help: "The total number of connections the migration proxies of virt-handler accepted after an earlier connection of the same proxy failed.",
+		},
+	)
+)
+
+// MigrationProxyObserver exposes the connections of the migration proxies as metrics.
+type MigrationProxyObserver struct{}
+
+func (MigrationProxyObserver) ConnectionOpened() {
+	migrationProxyConnections.Inc()
+}
+
+func (MigrationProxyObserver) ConnectionClosed() {
+	migrationProxyConnections.Dec()
+}
+
+func (MigrationProxyObserver) BytesCopied(direction string, bytes int) {
+	migrationProxyBytes.WithLabelValues(direction).Add(float64(bytes))
+}
+
+func (MigrationProxyObserver) ConnectionFailed() {
+	migrationProxyErrors.Inc()
+}
+
+func (MigrationProxyObserver) Reconnected() {
+	migrationProxyReconnects.Inc()
+}
@@ -65,7 +65,7 @@ func newQueue(vmiStore cache.Store, vmi *v1.VirtualMachineInstance) *queue {
 	return &queue{
 		vmiStore:  vmiStore,
 		vmi:       vmi,
-		collector: domstatsCollector.NewConcurrentCollector(1),
+		collector: domstatsCollector.NewConcurrentCollector(1, 1),
 		results:   ring.New(bufferSize),
 	}
 }
@@ -0,0 +1,101 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package vmiphase
+
+import (
+	"time"
+
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	vmiPhaseHandler *handler
+
+	Metrics = []operatormetrics.Metric{
+		vmiPhaseTransition,
+	}
+
+	VMIPhaseCollector = operatormetrics.Collector{
+		Metrics: []operatormetrics.Metric{
+			vmiTimeInPhase,
+		},
+		CollectCallback: vmiPhaseCollectorCallback,
+	}
+
+	vmiPhaseTransition = operatormetrics.NewHistogramVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_phase_transition_seconds",
+			Help: "Histogram of the time spent by the VMIs running on the node between two consecutive phases in seconds.",
+		},
+		prometheus.HistogramOpts{
+			Buckets: []float64{0.5, 1, 2, 5, 10, 20, 30, 60, 120, 300, 600},
+		},
+		[]string{
+			// phase the vmi transitioned from
+			"from",
+			// phase the vmi transitioned to
+			"to",
+		},
+	)
+
+	vmiTimeInPhase = operatormetrics.NewGauge(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_vmi_time_in_phase_seconds",
+			Help: "Amount of time the VMI spent in its current phase in seconds.",
+		},
+	)
+)
+
+func SetupVMIPhaseCollector(vmiInformer cache.SharedIndexInformer) error {
+	if vmiInformer == nil {
+		return nil
+	}
+
+	var err error
+	vmiPhaseHandler, err = newHandler(vmiInformer, observeTransition)
+	return err
+}
+
+func observeTransition(from, to string, duration time.Duration) {
+	vmiPhaseTransition.WithLabelValues(from, to).Observe(duration.Seconds())
+}
+
+func vmiPhaseCollectorCallback() []operatormetrics.CollectorResult {
+	if vmiPhaseHandler == nil {
+		return []operatormetrics.CollectorResult{}
+	}
+
+	var crs []operatormetrics.CollectorResult
+	for _, r := range vmiPhaseHandler.timeInPhase(time.Now()) {
+		crs = append(crs, operatormetrics.CollectorResult{
+			Metric: vmiTimeInPhase,
+			ConstLabels: map[string]string{
+				"namespace": r.namespace,
+				"name":      r.name,
+				"phase":     r.phase,
+			},
+			Value: r.duration.Seconds(),
+		})
+	}
+
+	return crs
+}
@@ -0,0 +1,175 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package vmiphase
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+type observeFunc func(from, to string, duration time.Duration)
+
+type timeInPhaseResult struct {
+	namespace string
+	name      string
+	phase     string
+	duration  time.Duration
+}
+
+// handler observes the phase transitions of the VMIs in the informer cache.
+// Every transition is observed once: the phases already accounted for are tracked
+// per VMI UID, so that resyncs and repeated updates do not count them again.
+// The tracking only lives in memory, so the transitions which happened before the
+// handler started are skipped, they were observed before virt-handler restarted.
+// The history of a VMI migrated to the node was observed on the source node and is
+// skipped as well.
+type handler struct {
+	sync.Mutex
+
+	vmiStore cache.Store
+	started  time.Time
+	observed map[types.UID]*observedTransitions
+	observe  observeFunc
+}
+
+type observedTransitions struct {
+	// since is the time from which the transitions of the VMI are observed
+	since  time.Time
+	phases map[v1.VirtualMachineInstancePhase]struct{}
+}
+
+func newHandler(vmiInformer cache.SharedIndexInformer, observe observeFunc) (*handler, error) {
+	h := handler{
+		vmiStore: vmiInformer.GetStore(),
+		started:  time.Now(),
+		observed: make(map[types.UID]*observedTransitions),
+		observe:  observe,
+	}
+
+	_, err := vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    h.handleVmiAdd,
+		UpdateFunc: h.handleVmiUpdate,
+		DeleteFunc: h.handleVmiDelete,
+	})
+
+	return &h, err
+}
+
+func (h *handler) handleVmiAdd(obj interface{}) {
+	if vmi, ok := obj.(*v1.VirtualMachineInstance); ok {
+		h.observeTransitions(vmi)
+	}
+}
+
+func (h *handler) handleVmiUpdate(_, newObj interface{}) {
+	h.handleVmiAdd(newObj)
+}
+
+func (h *handler) handleVmiDelete(obj interface{}) {
+	vmi, ok := obj.(*v1.VirtualMachineInstance)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		if vmi, ok = tombstone.Obj.(*v1.VirtualMachineInstance); !ok {
+			return
+		}
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	delete(h.observed, vmi.UID)
+}
+
+func (h *handler) observeTransitions(vmi *v1.VirtualMachineInstance) {
+	transitions := sortedTransitions(vmi)
+	if len(transitions) < 2 {
+		return
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	observed, exists := h.observed[vmi.UID]
+	if !exists {
+		observed = &observedTransitions{
+			since:  h.started,
+			phases: make(map[v1.VirtualMachineInstancePhase]struct{}),
+		}
+		if vmi.Status.MigrationState != nil {
+			observed.since = time.Now()
+		}
+		h.observed[vmi.UID] = observed
+	}
+
+	for i := 1; i < len(transitions); i++ {
+		from, to := transitions[i-1], transitions[i]
+		if to.PhaseTransitionTimestamp.Time.Before(observed.since) {
+			continue
+		}
+		if _, alreadyObserved := observed.phases[to.Phase]; alreadyObserved {
+			continue
+		}
+		observed.phases[to.Phase] = struct{}{}
+		h.observe(string(from.Phase), string(to.Phase), to.PhaseTransitionTimestamp.Sub(from.PhaseTransitionTimestamp.Time))
+	}
+}
+
+func (h *handler) timeInPhase(now time.Time) []timeInPhaseResult {
+	var results []timeInPhaseResult
+
+	for _, obj := range h.vmiStore.List() {
+		vmi, ok := obj.(*v1.VirtualMachineInstance)
+		if !ok || vmi.Status.Phase == "" {
+			continue
+		}
+
+		transitions := sortedTransitions(vmi)
+		for i := len(transitions) - 1; i >= 0; i-- {
+			if transitions[i].Phase != vmi.Status.Phase {
+				continue
+			}
+			results = append(results, timeInPhaseResult{
+				namespace: vmi.Namespace,
+				name:      vmi.Name,
+				phase:     string(vmi.Status.Phase),
+				duration:  now.Sub(transitions[i].PhaseTransitionTimestamp.Time),
+			})
+			break
+		}
+	}
+
+	return results
+}
+
+func sortedTransitions(vmi *v1.VirtualMachineInstance) []v1.VirtualMachineInstancePhaseTransitionTimestamp {
+	transitions := append([]v1.VirtualMachineInstancePhaseTransitionTimestamp{}, vmi.Status.PhaseTransitionTimestamps...)
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].PhaseTransitionTimestamp.Before(&transitions[j].PhaseTransitionTimestamp)
+	})
+	return transitions
+}
@@ -0,0 +1,143 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package vmiphase
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/api/core/v1"
+)
+
+type observation struct {
+	from, to string
+	duration time.Duration
+}
+
+var _ = Describe("VMI phase transitions", func() {
+	var (
+		store        cache.Store
+		h            *handler
+		observations []observation
+		start        time.Time
+	)
+
+	newVMI := func(uid types.UID, phases ...v1.VirtualMachineInstancePhase) *v1.VirtualMachineInstance {
+		vmi := &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "testvmi", Namespace: "default", UID: uid},
+		}
+		for i, phase := range phases {
+			vmi.Status.Phase = phase
+			vmi.Status.PhaseTransitionTimestamps = append(vmi.Status.PhaseTransitionTimestamps, v1.VirtualMachineInstancePhaseTransitionTimestamp{
+				Phase:                    phase,
+				PhaseTransitionTimestamp: metav1.NewTime(start.Add(time.Duration(i*10) * time.Second)),
+			})
+		}
+		return vmi
+	}
+
+	BeforeEach(func() {
+		start = time.Now().Add(-time.Hour).Truncate(time.Second)
+		observations = nil
+		store = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		h = &handler{
+			vmiStore: store,
+			started:  start,
+			observed: make(map[types.UID]*observedTransitions),
+			observe: func(from, to string, duration time.Duration) {
+				observations = append(observations, observation{from: from, to: to, duration: duration})
+			},
+		}
+	})
+
+	It("should observe every transition once", func() {
+		h.handleVmiAdd(newVMI("uid-1", v1.Pending, v1.Scheduling))
+		h.handleVmiUpdate(nil, newVMI("uid-1", v1.Pending, v1.Scheduling, v1.Scheduled, v1.Running))
+		h.handleVmiUpdate(nil, newVMI("uid-1", v1.Pending, v1.Scheduling, v1.Scheduled, v1.Running))
+
+		Expect(observations).To(Equal([]observation{
+			{from: "Pending", to: "Scheduling", duration: 10 * time.Second},
+			{from: "Scheduling", to: "Scheduled", duration: 10 * time.Second},
+			{from: "Scheduled", to: "Running", duration: 10 * time.Second},
+		}))
+	})
+
+	It("should not observe again the transitions which happened before a restart", func() {
+		h.started = start.Add(25 * time.Second)
+		h.handleVmiAdd(newVMI("uid-1", v1.Pending, v1.Scheduling, v1.Scheduled, v1.Running))
+
+		Expect(observations).To(Equal([]observation{
+			{from: "Scheduled", to: "Running", duration: 10 * time.Second},
+		}))
+	})
+
+	It("should not observe again the history of a VMI migrated to the node", func() {
+		vmi := newVMI("uid-1", v1.Pending, v1.Scheduling, v1.Scheduled, v1.Running)
+		vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{}
+		h.handleVmiAdd(vmi)
+		Expect(observations).To(BeEmpty())
+
+		vmi = vmi.DeepCopy()
+		vmi.Status.Phase = v1.Succeeded
+		vmi.Status.PhaseTransitionTimestamps = append(vmi.Status.PhaseTransitionTimestamps, v1.VirtualMachineInstancePhaseTransitionTimestamp{
+			Phase:                    v1.Succeeded,
+			PhaseTransitionTimestamp: metav1.NewTime(time.Now().Add(time.Minute)),
+		})
+		h.handleVmiUpdate(nil, vmi)
+		Expect(observations).To(HaveLen(1))
+		Expect(observations[0].from).To(Equal("Running"))
+		Expect(observations[0].to).To(Equal("Succeeded"))
+	})
+
+	It("should not observe anything for a VMI with a single phase", func() {
+		h.handleVmiAdd(newVMI("uid-1", v1.Pending))
+		Expect(observations).To(BeEmpty())
+	})
+
+	It("should observe the transitions of a re-created VMI with a new UID", func() {
+		h.handleVmiAdd(newVMI("uid-1", v1.Pending, v1.Scheduling))
+		h.handleVmiAdd(newVMI("uid-2", v1.Pending, v1.Scheduling))
+		Expect(observations).To(HaveLen(2))
+	})
+
+	It("should clean the tracking map when the VMI is deleted", func() {
+		vmi := newVMI("uid-1", v1.Pending, v1.Scheduling)
+		h.handleVmiAdd(vmi)
+		Expect(h.observed).To(HaveKey(types.UID("uid-1")))
+
+		h.handleVmiDelete(cache.DeletedFinalStateUnknown{Key: "default/testvmi", Obj: vmi})
+		Expect(h.observed).To(BeEmpty())
+	})
+
+	It("should report the time spent in the current phase", func() {
+		Expect(store.Add(newVMI("uid-1", v1.Pending, v1.Scheduling, v1.Scheduled, v1.Running))).To(Succeed())
+
+		results := h.timeInPhase(start.Add(45 * time.Second))
+		Expect(results).To(Equal([]timeInPhaseResult{
+			{namespace: "default", name: "testvmi", phase: "Running", duration: 15 * time.Second},
+		}))
+	})
+})
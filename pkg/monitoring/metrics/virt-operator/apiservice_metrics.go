@@ -0,0 +1,78 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package virt_operator
+
+import (
+	"sync"
+	"time"
+
+	"github.com/machadovilaca/operator-observability/pkg/operatormetrics"
+)
+
+var (
+	apiServiceCollector = operatormetrics.Collector{
+		Metrics: []operatormetrics.Metric{
+			apiServiceCABundleAge,
+		},
+		CollectCallback: apiServiceCollectorCallback,
+	}
+
+	apiServiceCABundleAge = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_operator_apiservice_cabundle_age_seconds",
+			Help: "The age of the newest CA certificate in the caBundle of an APIService managed by virt-operator.",
+		},
+		[]string{"apiservice"},
+	)
+
+	apiServiceCABundlesLock     sync.Mutex
+	apiServiceCABundleNotBefore = map[string]time.Time{}
+)
+
+// SetAPIServiceCABundleNotBefore records when the newest CA certificate of the caBundle of an APIService became valid
+func SetAPIServiceCABundleNotBefore(apiService string, notBefore time.Time) {
+	apiServiceCABundlesLock.Lock()
+	defer apiServiceCABundlesLock.Unlock()
+
+	apiServiceCABundleNotBefore[apiService] = notBefore
+}
+
+// DeleteAPIServiceCABundle stops reporting the caBundle age of an APIService
+func DeleteAPIServiceCABundle(apiService string) {
+	apiServiceCABundlesLock.Lock()
+	defer apiServiceCABundlesLock.Unlock()
+
+	delete(apiServiceCABundleNotBefore, apiService)
+}
+
+func apiServiceCollectorCallback() []operatormetrics.CollectorResult {
+	apiServiceCABundlesLock.Lock()
+	defer apiServiceCABundlesLock.Unlock()
+
+	var results []operatormetrics.CollectorResult
+	for apiService, notBefore := range apiServiceCABundleNotBefore {
+		results = append(results, operatormetrics.CollectorResult{
+			Metric: apiServiceCABundleAge,
+			Labels: []string{apiService},
+			Value:  time.Since(notBefore).Seconds(),
+		})
+	}
+	return results
+}
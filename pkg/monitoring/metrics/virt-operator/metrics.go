@@ -35,9 +35,13 @@ func SetupMetrics() error {
 		return err
 	}
 
-	return operatormetrics.RegisterMetrics(
+	if err := operatormetrics.RegisterMetrics(
 		operatorMetrics,
-	)
+	); err != nil {
+		return err
+	}
+
+	return operatormetrics.RegisterCollector(apiServiceCollector)
 }
 
 func RegisterLeaderMetrics() error {
@@ -0,0 +1,71 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitter
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/network/istio"
+	"kubevirt.io/kubevirt/pkg/network/vmispec"
+)
+
+// ValidateIstioPorts rejects ports of pod network interfaces which collide
+// with the ports of the Istio proxy injected into the pod, or which use SCTP
+// that the proxy does not pass on to the guest.
+func ValidateIstioPorts(field *k8sfield.Path, vmi *v1.VirtualMachineInstance) []metav1.StatusCause {
+	if !istio.ProxyInjectionEnabled(vmi) {
+		return nil
+	}
+
+	reservedPorts := map[int32]struct{}{}
+	for _, port := range istio.ReservedPorts() {
+		reservedPorts[int32(port)] = struct{}{}
+	}
+
+	var causes []metav1.StatusCause
+	networksByName := vmispec.IndexNetworkSpecByName(vmi.Spec.Networks)
+	for idx, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if networksByName[iface.Name].Pod == nil {
+			continue
+		}
+		for portIdx, port := range iface.Ports {
+			if port.Protocol == "SCTP" {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("port %d of interface %s uses SCTP, which is not supported with the Istio proxy", port.Port, iface.Name),
+					Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("ports").Index(portIdx).Child("protocol").String(),
+				})
+			}
+			if _, reserved := reservedPorts[port.Port]; reserved {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("port %d of interface %s is reserved by the Istio proxy", port.Port, iface.Name),
+					Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("ports").Index(portIdx).Child("port").String(),
+				})
+			}
+		}
+	}
+	return causes
+}
@@ -0,0 +1,104 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright The KubeVirt Authors.
+ *
+ */
+
+package admitter_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/libvmi"
+	"kubevirt.io/kubevirt/pkg/network/admitter"
+	"kubevirt.io/kubevirt/pkg/network/istio"
+)
+
+var _ = Describe("Validate interface ports with the Istio proxy", func() {
+	newVMI := func(network *v1.Network, ports []v1.Port, opts ...libvmi.Option) *v1.VirtualMachineInstance {
+		opts = append(opts,
+			libvmi.WithInterface(v1.Interface{
+				Name:                   network.Name,
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+				Ports:                  ports,
+			}),
+			libvmi.WithNetwork(network),
+		)
+		return libvmi.New(opts...)
+	}
+
+	withProxyInjection := libvmi.WithAnnotation(istio.InjectSidecarAnnotation, "true")
+
+	DescribeTable("should reject", func(vmi *v1.VirtualMachineInstance, expectedCauses []metav1.StatusCause) {
+		Expect(admitter.ValidateIstioPorts(k8sfield.NewPath("fake"), vmi)).To(ConsistOf(expectedCauses))
+	},
+		Entry("a reserved port",
+			newVMI(v1.DefaultPodNetwork(), []v1.Port{{Port: 80}, {Port: istio.EnvoyOutboundPort}}, withProxyInjection),
+			[]metav1.StatusCause{{
+				Type:    "FieldValueInvalid",
+				Message: "port 15001 of interface default is reserved by the Istio proxy",
+				Field:   "fake.domain.devices.interfaces[0].ports[1].port",
+			}},
+		),
+		Entry("every reserved port",
+			newVMI(v1.DefaultPodNetwork(), []v1.Port{{Port: istio.EnvoyAdminPort}, {Port: istio.EnvoyDNSPort, Protocol: "UDP"}}, withProxyInjection),
+			[]metav1.StatusCause{{
+				Type:    "FieldValueInvalid",
+				Message: "port 15000 of interface default is reserved by the Istio proxy",
+				Field:   "fake.domain.devices.interfaces[0].ports[0].port",
+			}, {
+				Type:    "FieldValueInvalid",
+				Message: "port 15053 of interface default is reserved by the Istio proxy",
+				Field:   "fake.domain.devices.interfaces[0].ports[1].port",
+			}},
+		),
+		Entry("an SCTP port",
+			newVMI(v1.DefaultPodNetwork(), []v1.Port{{Port: 80}, {Port: 9000, Protocol: "SCTP"}}, withProxyInjection),
+			[]metav1.StatusCause{{
+				Type:    "FieldValueNotSupported",
+				Message: "port 9000 of interface default uses SCTP, which is not supported with the Istio proxy",
+				Field:   "fake.domain.devices.interfaces[0].ports[1].protocol",
+			}},
+		),
+	)
+
+	DescribeTable("should accept", func(vmi *v1.VirtualMachineInstance) {
+		Expect(admitter.ValidateIstioPorts(k8sfield.NewPath("fake"), vmi)).To(BeEmpty())
+	},
+		Entry("unreserved ports",
+			newVMI(v1.DefaultPodNetwork(), []v1.Port{{Port: 80}, {Port: istio.SshPort}}, withProxyInjection),
+		),
+		Entry("reserved ports without the proxy",
+			newVMI(v1.DefaultPodNetwork(), []v1.Port{{Port: istio.EnvoyOutboundPort}}),
+		),
+		Entry("SCTP ports without the proxy",
+			newVMI(v1.DefaultPodNetwork(), []v1.Port{{Port: 9000, Protocol: "SCTP"}}),
+		),
+		Entry("reserved ports with the proxy injection disabled",
+			newVMI(v1.DefaultPodNetwork(), []v1.Port{{Port: istio.EnvoyOutboundPort}},
+				libvmi.WithAnnotation(istio.InjectSidecarAnnotation, "false")),
+		),
+		Entry("reserved ports on a secondary network",
+			newVMI(libvmi.MultusNetwork("secondary", "net"), []v1.Port{{Port: istio.EnvoyOutboundPort}}, withProxyInjection),
+		),
+	)
+})
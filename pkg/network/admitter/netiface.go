@@ -28,6 +28,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/network/vmispec"
 	hwutil "kubevirt.io/kubevirt/pkg/util/hardware"
 
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
@@ -113,6 +114,7 @@ func validateInterfacesFields(field *k8sfield.Path, spec *v1.VirtualMachineInsta
 		causes = append(causes, validatePciAddress(field, idx, iface)...)
 		causes = append(causes, validatePortConfiguration(field, idx, iface, networksByName[iface.Name])...)
 		causes = append(causes, validateDHCPOptions(field, idx, iface)...)
+		causes = append(causes, validateInterfaceQueues(field, idx, iface, spec)...)
 	}
 	return causes
 }
@@ -190,6 +192,52 @@ func validatePciAddress(field *k8sfield.Path, idx int, iface v1.Interface) []met
 	return nil
 }
 
+func validateInterfaceQueues(field *k8sfield.Path, idx int, iface v1.Interface, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	if iface.Queues == nil {
+		return nil
+	}
+	queuesField := field.Child("domain", "devices", "interfaces").Index(idx).Child("queues").String()
+	if iface.MultiQueue != nil && !*iface.MultiQueue {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("interface %s cannot set queues with multiQueue disabled.", iface.Name),
+			Field:   queuesField,
+		}}
+	}
+	if *iface.Queues == 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("interface %s must have at least one queue.", iface.Name),
+			Field:   queuesField,
+		}}
+	}
+	if vCPUs := requestedVCPUs(spec); int64(*iface.Queues) > vCPUs {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("interface %s has %d queues, more than the %d vCPUs of the VM.", iface.Name, *iface.Queues, vCPUs),
+			Field:   queuesField,
+		}}
+	}
+	return nil
+}
+
+// requestedVCPUs follows the guest CPU topology the launcher ends up with,
+// which is taken from the CPU resources when no topology is specified.
+func requestedVCPUs(spec *v1.VirtualMachineInstanceSpec) int64 {
+	if spec.Domain.CPU != nil {
+		if vCPUs := hwutil.GetNumberOfVCPUs(spec.Domain.CPU); vCPUs != 0 {
+			return vCPUs
+		}
+	}
+	if cpuLimit, ok := spec.Domain.Resources.Limits[k8sv1.ResourceCPU]; ok {
+		return cpuLimit.Value()
+	}
+	if cpuRequest, ok := spec.Domain.Resources.Requests[k8sv1.ResourceCPU]; ok {
+		return cpuRequest.Value()
+	}
+	return 1
+}
+
 func validatePortConfiguration(field *k8sfield.Path, idx int, iface v1.Interface, network v1.Network) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	if network.Pod != nil && iface.Ports != nil {
@@ -232,10 +280,10 @@ func validateForwardPortName(field *k8sfield.Path, idx int, ports []v1.Port) []m
 
 func validateForwardPortProtocol(field *k8sfield.Path, idx int, forwardPort v1.Port, portIdx int) (causes []metav1.StatusCause) {
 	if forwardPort.Protocol != "" {
-		if forwardPort.Protocol != "TCP" && forwardPort.Protocol != "UDP" {
+		if forwardPort.Protocol != "TCP" && forwardPort.Protocol != "UDP" && forwardPort.Protocol != "SCTP" {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: "Unknown protocol, only TCP or UDP allowed",
+				Message: "Unknown protocol, only TCP, UDP or SCTP allowed",
 				Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("ports").Index(portIdx).Child("protocol").String(),
 			})
 		}
@@ -25,12 +25,15 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/kubevirt/pkg/network/admitter"
+	"kubevirt.io/kubevirt/pkg/pointer"
 )
 
 var _ = Describe("Validating VMI network spec", func() {
@@ -259,7 +262,7 @@ var _ = Describe("Validating VMI network spec", func() {
 				[]v1.Port{{Protocol: "bad", Port: 80}},
 				[]metav1.StatusCause{{
 					Type:    "FieldValueInvalid",
-					Message: "Unknown protocol, only TCP or UDP allowed",
+					Message: "Unknown protocol, only TCP, UDP or SCTP allowed",
 					Field:   "fake.domain.devices.interfaces[0].ports[0].protocol",
 				}},
 			),
@@ -310,6 +313,7 @@ var _ = Describe("Validating VMI network spec", func() {
 				"multiple ports, same number, different protocols",
 				[]v1.Port{{Port: 80}, {Protocol: "UDP", Port: 80}, {Protocol: "TCP", Port: 80}},
 			),
+			Entry("SCTP port", []v1.Port{{Protocol: "SCTP", Port: 9000}}),
 		)
 	})
 
@@ -391,4 +395,53 @@ var _ = Describe("Validating VMI network spec", func() {
 			),
 		)
 	})
+
+	When("the interface queues are specified", func() {
+		const vCPUs = 4
+
+		newSpec := func(multiQueue *bool, queues uint32) *v1.VirtualMachineInstanceSpec {
+			spec := &v1.VirtualMachineInstanceSpec{}
+			spec.Domain.CPU = &v1.CPU{Cores: vCPUs}
+			spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name:                   "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+				MultiQueue:             multiQueue,
+				Queues:                 &queues,
+			}}
+			spec.Networks = []v1.Network{{Name: "default", NetworkSource: v1.NetworkSource{Pod: &v1.PodNetwork{}}}}
+			return spec
+		}
+
+		DescribeTable("should reject", func(multiQueue *bool, queues uint32, expectedMessage string) {
+			validator := admitter.NewValidator(k8sfield.NewPath("fake"), newSpec(multiQueue, queues), stubClusterConfigChecker{})
+			Expect(validator.Validate()).To(ConsistOf(metav1.StatusCause{
+				Type:    "FieldValueInvalid",
+				Message: expectedMessage,
+				Field:   "fake.domain.devices.interfaces[0].queues",
+			}))
+		},
+			Entry("more queues than vCPUs", nil, uint32(vCPUs+1),
+				"interface default has 5 queues, more than the 4 vCPUs of the VM."),
+			Entry("no queues", nil, uint32(0), "interface default must have at least one queue."),
+			Entry("queues with multiQueue disabled", pointer.P(false), uint32(2),
+				"interface default cannot set queues with multiQueue disabled."),
+		)
+
+		DescribeTable("should accept", func(multiQueue *bool, queues uint32) {
+			validator := admitter.NewValidator(k8sfield.NewPath("fake"), newSpec(multiQueue, queues), stubClusterConfigChecker{})
+			Expect(validator.Validate()).To(BeEmpty())
+		},
+			Entry("queues up to the vCPUs", nil, uint32(vCPUs)),
+			Entry("queues with multiQueue enabled", pointer.P(true), uint32(1)),
+		)
+
+		It("should count the vCPUs from the CPU resources without a topology", func() {
+			spec := newSpec(nil, 3)
+			spec.Domain.CPU = nil
+			spec.Domain.Resources.Requests = k8sv1.ResourceList{k8sv1.ResourceCPU: resource.MustParse("2")}
+
+			validator := admitter.NewValidator(k8sfield.NewPath("fake"), spec, stubClusterConfigChecker{})
+			Expect(validator.Validate()).To(ConsistOf(HaveField("Field", "fake.domain.devices.interfaces[0].queues")))
+		})
+	})
 })
@@ -99,6 +99,7 @@ type DHCPConfig struct {
 	IPAMDisabled        bool
 	Gateway             net.IP
 	Subdomain           string
+	ServiceDomainInfix  string
 }
 
 func (d DHCPConfig) String() string {
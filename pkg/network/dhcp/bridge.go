@@ -15,13 +15,14 @@ type cacheCreator interface {
 }
 
 type BridgeConfigGenerator struct {
-	handler          netdriver.NetworkHandler
-	podInterfaceName string
-	cacheCreator     cacheCreator
-	launcherPID      string
-	vmiSpecIfaces    []v1.Interface
-	vmiSpecIface     *v1.Interface
-	subdomain        string
+	handler            netdriver.NetworkHandler
+	podInterfaceName   string
+	cacheCreator       cacheCreator
+	launcherPID        string
+	vmiSpecIfaces      []v1.Interface
+	vmiSpecIface       *v1.Interface
+	subdomain          string
+	serviceDomainInfix string
 }
 
 func (d *BridgeConfigGenerator) Generate() (*cache.DHCPConfig, error) {
@@ -47,6 +48,7 @@ func (d *BridgeConfigGenerator) Generate() (*cache.DHCPConfig, error) {
 	}
 	dhcpConfig.Mtu = uint16(podNicLink.Attrs().MTU)
 	dhcpConfig.Subdomain = d.subdomain
+	dhcpConfig.ServiceDomainInfix = d.serviceDomainInfix
 
 	return dhcpConfig, nil
 }
@@ -19,6 +19,8 @@ const (
 	ifaceName   = "eth0"
 	launcherPID = "self"
 	subdomain   = "subdomain"
+
+	serviceDomainInfix = ".services."
 )
 
 var _ = Describe("Bridge DHCP configurator", func() {
@@ -54,13 +56,14 @@ var _ = Describe("Bridge DHCP configurator", func() {
 
 			iface := v1.Interface{Name: "network"}
 			generator = BridgeConfigGenerator{
-				cacheCreator:     &cacheCreator,
-				launcherPID:      launcherPID,
-				podInterfaceName: ifaceName,
-				vmiSpecIfaces:    []v1.Interface{iface},
-				vmiSpecIface:     &iface,
-				handler:          mockHandler,
-				subdomain:        subdomain,
+				cacheCreator:       &cacheCreator,
+				launcherPID:        launcherPID,
+				podInterfaceName:   ifaceName,
+				vmiSpecIfaces:      []v1.Interface{iface},
+				vmiSpecIface:       &iface,
+				handler:            mockHandler,
+				subdomain:          subdomain,
+				serviceDomainInfix: serviceDomainInfix,
 			}
 
 			mtu := 1410
@@ -77,6 +80,7 @@ var _ = Describe("Bridge DHCP configurator", func() {
 			expectedConfig.AdvertisingIPAddr = advertisingIPAddr.IP
 			expectedConfig.Mtu = 1410
 			expectedConfig.Subdomain = subdomain
+			expectedConfig.ServiceDomainInfix = serviceDomainInfix
 			Expect(*config).To(Equal(expectedConfig))
 		})
 		It("Should succeed with no ipam", func() {
@@ -54,31 +54,32 @@ type ConfigGenerator interface {
 }
 
 func NewBridgeConfigurator(cacheCreator cacheCreator, launcherPID string, advertisingIfaceName string, handler netdriver.NetworkHandler, podInterfaceName string,
-	vmiSpecIfaces []v1.Interface, vmiSpecIface *v1.Interface, subdomain string) *configurator {
+	vmiSpecIfaces []v1.Interface, vmiSpecIface *v1.Interface, subdomain, serviceDomainInfix string) *configurator {
 	return &configurator{
 		podInterfaceName:     podInterfaceName,
 		advertisingIfaceName: advertisingIfaceName,
 		handler:              handler,
 		dhcpStartedDirectory: defaultDHCPStartedDirectory,
 		configGenerator: &BridgeConfigGenerator{
-			handler:          handler,
-			podInterfaceName: podInterfaceName,
-			cacheCreator:     cacheCreator,
-			launcherPID:      launcherPID,
-			vmiSpecIfaces:    vmiSpecIfaces,
-			vmiSpecIface:     vmiSpecIface,
-			subdomain:        subdomain,
+			handler:            handler,
+			podInterfaceName:   podInterfaceName,
+			cacheCreator:       cacheCreator,
+			launcherPID:        launcherPID,
+			vmiSpecIfaces:      vmiSpecIfaces,
+			vmiSpecIface:       vmiSpecIface,
+			subdomain:          subdomain,
+			serviceDomainInfix: serviceDomainInfix,
 		},
 	}
 }
 
 func NewMasqueradeConfigurator(advertisingIfaceName string, handler netdriver.NetworkHandler, vmiSpecIface *v1.Interface, vmiSpecNetwork *v1.Network, podInterfaceName string,
-	subdomain string) *configurator {
+	subdomain, serviceDomainInfix string) *configurator {
 	return &configurator{
 		podInterfaceName:     podInterfaceName,
 		advertisingIfaceName: advertisingIfaceName,
 		configGenerator: &MasqueradeConfigGenerator{handler: handler, vmiSpecIface: vmiSpecIface, vmiSpecNetwork: vmiSpecNetwork,
-			subdomain: subdomain, podInterfaceName: podInterfaceName},
+			subdomain: subdomain, serviceDomainInfix: serviceDomainInfix, podInterfaceName: podInterfaceName},
 		handler:              handler,
 		dhcpStartedDirectory: defaultDHCPStartedDirectory,
 	}
@@ -29,11 +29,12 @@ import (
 )
 
 type MasqueradeConfigGenerator struct {
-	handler          netdriver.NetworkHandler
-	vmiSpecIface     *v1.Interface
-	vmiSpecNetwork   *v1.Network
-	podInterfaceName string
-	subdomain        string
+	handler            netdriver.NetworkHandler
+	vmiSpecIface       *v1.Interface
+	vmiSpecNetwork     *v1.Network
+	podInterfaceName   string
+	subdomain          string
+	serviceDomainInfix string
 }
 
 func (d *MasqueradeConfigGenerator) Generate() (*cache.DHCPConfig, error) {
@@ -45,6 +46,7 @@ func (d *MasqueradeConfigGenerator) Generate() (*cache.DHCPConfig, error) {
 
 	dhcpConfig.Name = podNicLink.Attrs().Name
 	dhcpConfig.Subdomain = d.subdomain
+	dhcpConfig.ServiceDomainInfix = d.serviceDomainInfix
 	dhcpConfig.Mtu = uint16(podNicLink.Attrs().MTU)
 
 	ipv4Enabled, err := d.handler.HasIPv4GlobalUnicastAddress(d.podInterfaceName)
@@ -64,8 +64,9 @@ var _ = Describe("Masquerade DHCP configurator", func() {
 
 		generateExpectedConfig := func(vmiSpecNetwork *v1.Network, macString *string, mtu int, ifaceName string, subdomain string) cache.DHCPConfig {
 			expectedConfig := cache.DHCPConfig{Name: ifaceName,
-				Mtu:       uint16(mtu),
-				Subdomain: subdomain,
+				Mtu:                uint16(mtu),
+				Subdomain:          subdomain,
+				ServiceDomainInfix: serviceDomainInfix,
 			}
 
 			if macString != nil {
@@ -116,11 +117,12 @@ var _ = Describe("Masquerade DHCP configurator", func() {
 			subdomain = "subdomain"
 
 			generator = MasqueradeConfigGenerator{
-				handler:          mockHandler,
-				vmiSpecIface:     vmiSpecIface,
-				vmiSpecNetwork:   vmiSpecNetwork,
-				podInterfaceName: ifaceName,
-				subdomain:        subdomain,
+				handler:            mockHandler,
+				vmiSpecIface:       vmiSpecIface,
+				vmiSpecNetwork:     vmiSpecNetwork,
+				podInterfaceName:   ifaceName,
+				subdomain:          subdomain,
+				serviceDomainInfix: serviceDomainInfix,
 			}
 
 			mtu = 1410
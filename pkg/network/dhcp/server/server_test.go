@@ -182,6 +182,16 @@ var _ = Describe("DHCP Server", func() {
 			Expect(options[dhcp4.OptionDomainName]).To(Equal([]byte("14wg5xngig6vzfqjww4kocnky3c9dqjpwkewzlwpf.com")))
 		})
 
+		DescribeTable("should contain the interface MTU option", func(mtu uint16, expectedOption []byte) {
+			ip := net.ParseIP("192.168.2.1")
+			options, err := prepareDHCPOptions(ip.DefaultMask(), ip, nil, nil, nil, mtu, "myhost", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[dhcp4.OptionInterfaceMTU]).To(Equal(expectedOption))
+		},
+			Entry("with the default MTU", uint16(1500), []byte{0x05, 0xdc}),
+			Entry("with a jumbo frame MTU", uint16(9000), []byte{0x23, 0x28}),
+		)
+
 		It("should contain custom options", func() {
 			searchDomains := []string{
 				"pix3ob5ymm5jbsjessf0o4e84uvij588rz23iz0o.com",
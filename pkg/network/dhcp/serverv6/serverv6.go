@@ -20,6 +20,7 @@
 package serverv6
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"time"
@@ -36,11 +37,24 @@ const (
 )
 
 type DHCPv6Handler struct {
-	clientIP  net.IP
+	clientIPs []net.IP
 	modifiers []dhcpv6.Modifier
 }
 
+// SingleClientDHCPv6Server serves DHCPv6 to a single client until the server fails.
 func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string) error {
+	return SingleClientDHCPv6ServerWithContext(context.Background(), clientIP, serverIfaceName)
+}
+
+// SingleClientDHCPv6ServerWithContext serves DHCPv6 to a single client until the context is cancelled.
+// The server connection is closed on cancellation and nil is returned.
+func SingleClientDHCPv6ServerWithContext(ctx context.Context, clientIP net.IP, serverIfaceName string) error {
+	return SingleClientMultiAddressDHCPv6Server(ctx, []net.IP{clientIP}, serverIfaceName)
+}
+
+// SingleClientMultiAddressDHCPv6Server serves DHCPv6 to a single client until the context is cancelled.
+// All the client IPs are advertised as addresses of the same IANA.
+func SingleClientMultiAddressDHCPv6Server(ctx context.Context, clientIPs []net.IP, serverIfaceName string) error {
 	log.Log.Info("Starting SingleClientDHCPv6Server")
 
 	iface, err := net.InterfaceByName(serverIfaceName)
@@ -48,10 +62,10 @@ func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string) error {
 		return fmt.Errorf("couldn't create DHCPv6 server, couldn't get the dhcp6 server interface: %v", err)
 	}
 
-	modifiers := prepareDHCPv6Modifiers(clientIP, iface.HardwareAddr)
+	modifiers := prepareDHCPv6Modifiers(clientIPs, iface.HardwareAddr)
 
 	handler := &DHCPv6Handler{
-		clientIP:  clientIP,
+		clientIPs: clientIPs,
 		modifiers: modifiers,
 	}
 
@@ -65,12 +79,30 @@ func SingleClientDHCPv6Server(clientIP net.IP, serverIfaceName string) error {
 		return fmt.Errorf("couldn't create DHCPv6 server: %v", err)
 	}
 
-	err = s.Serve()
-	if err != nil {
-		return fmt.Errorf("failed to run DHCPv6 server: %v", err)
-	}
+	return serve(ctx, s)
+}
 
-	return nil
+func serve(ctx context.Context, s *server6.Server) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.Serve()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			return fmt.Errorf("failed to run DHCPv6 server: %v", err)
+		}
+		return nil
+	case <-ctx.Done():
+		log.Log.Info("Stopping SingleClientDHCPv6Server")
+		if err := s.Close(); err != nil {
+			return fmt.Errorf("failed to stop DHCPv6 server: %v", err)
+		}
+		// Serve fails reading from the closed connection, which is the expected way out
+		<-serveErr
+		return nil
+	}
 }
 
 func (h *DHCPv6Handler) ServeDHCPv6(conn net.PacketConn, peer net.Addr, m dhcpv6.DHCPv6) {
@@ -121,9 +153,27 @@ func (h *DHCPv6Handler) buildResponse(msg dhcpv6.DHCPv6) (*dhcpv6.Message, error
 	return response, nil
 }
 
-func prepareDHCPv6Modifiers(clientIP net.IP, serverInterfaceMac net.HardwareAddr) []dhcpv6.Modifier {
-	optIAAddress := dhcpv6.OptIAAddress{IPv6Addr: clientIP, PreferredLifetime: infiniteLease, ValidLifetime: infiniteLease}
+func prepareDHCPv6Modifiers(clientIPs []net.IP, serverInterfaceMac net.HardwareAddr) []dhcpv6.Modifier {
 	duid := &dhcpv6.DUIDLL{HWType: iana.HWTypeEthernet, LinkLayerAddr: serverInterfaceMac}
 
-	return []dhcpv6.Modifier{dhcpv6.WithIANA(optIAAddress), dhcpv6.WithServerID(duid)}
+	return []dhcpv6.Modifier{withIANAAddresses(clientIPs), dhcpv6.WithServerID(duid)}
+}
+
+// withIANAAddresses adds an IA address with an infinite lease for each of the IPs to the IANA option.
+// dhcpv6.WithIANA is not used as it adds the same address multiple times when it's given more than one.
+func withIANAAddresses(ips []net.IP) dhcpv6.Modifier {
+	return func(d dhcpv6.DHCPv6) {
+		msg, ok := d.(*dhcpv6.Message)
+		if !ok {
+			return
+		}
+		optIANA := msg.Options.OneIANA()
+		if optIANA == nil {
+			optIANA = &dhcpv6.OptIANA{}
+		}
+		for _, ip := range ips {
+			optIANA.Options.Add(&dhcpv6.OptIAAddress{IPv6Addr: ip, PreferredLifetime: infiniteLease, ValidLifetime: infiniteLease})
+		}
+		msg.UpdateOption(optIANA)
+	}
 }
@@ -20,9 +20,11 @@
 package serverv6
 
 import (
+	"context"
 	"net"
 
 	"github.com/insomniacslk/dhcp/dhcpv6"
+	"github.com/insomniacslk/dhcp/dhcpv6/server6"
 	"github.com/insomniacslk/dhcp/iana"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -30,11 +32,44 @@ import (
 )
 
 var _ = Describe("DHCPv6", func() {
+	Context("serve", func() {
+		It("should return once the context is cancelled", func() {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			handler := &DHCPv6Handler{}
+			s, err := server6.NewServer("", nil, handler.ServeDHCPv6, server6.WithConn(conn))
+			Expect(err).ToNot(HaveOccurred())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			serveErr := make(chan error, 1)
+			go func() {
+				serveErr <- serve(ctx, s)
+			}()
+
+			Consistently(serveErr).ShouldNot(Receive())
+			cancel()
+			Eventually(serveErr).Should(Receive(BeNil()))
+
+			_, err = conn.WriteTo([]byte("ping"), conn.LocalAddr())
+			Expect(err).To(MatchError(net.ErrClosed))
+		})
+
+		It("should report the server failure", func() {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			handler := &DHCPv6Handler{}
+			s, err := server6.NewServer("", nil, handler.ServeDHCPv6, server6.WithConn(conn))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conn.Close()).To(Succeed())
+
+			Expect(serve(context.Background(), s)).To(MatchError(ContainSubstring("failed to run DHCPv6 server")))
+		})
+	})
 	Context("prepareDHCPv6Modifiers", func() {
 		It("should contain ianaAdrress and duid", func() {
 			clientIP := net.ParseIP("fd10:0:2::2")
 			serverInterfaceMac, _ := net.ParseMAC("12:34:56:78:9A:BC")
-			modifiers := prepareDHCPv6Modifiers(clientIP, serverInterfaceMac)
+			modifiers := prepareDHCPv6Modifiers([]net.IP{clientIP}, serverInterfaceMac)
 			Expect(modifiers).To(HaveLen(2))
 
 			msg := &dhcpv6.Message{
@@ -59,10 +94,10 @@ var _ = Describe("DHCPv6", func() {
 		BeforeEach(func() {
 			clientIP := net.ParseIP("fd10:0:2::2")
 			serverInterfaceMac, _ := net.ParseMAC("12:34:56:78:9A:BC")
-			modifiers := prepareDHCPv6Modifiers(clientIP, serverInterfaceMac)
+			modifiers := prepareDHCPv6Modifiers([]net.IP{clientIP}, serverInterfaceMac)
 
 			handler = &DHCPv6Handler{
-				clientIP:  clientIP,
+				clientIPs: []net.IP{clientIP},
 				modifiers: modifiers,
 			}
 		})
@@ -121,6 +156,26 @@ var _ = Describe("DHCPv6", func() {
 			_, err = handler.buildResponse(clientMessage)
 			Expect(err).ToNot(HaveOccurred())
 		})
+		It("all the client addresses in the iana option", func() {
+			clientIPs := []net.IP{net.ParseIP("fd10:0:2::2"), net.ParseIP("fd10:0:3::2")}
+			serverInterfaceMac, _ := net.ParseMAC("12:34:56:78:9A:BC")
+			handler = &DHCPv6Handler{
+				clientIPs: clientIPs,
+				modifiers: prepareDHCPv6Modifiers(clientIPs, serverInterfaceMac),
+			}
+			clientMessage, err := newMessage(dhcpv6.MessageTypeRequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			replyMessage, err := handler.buildResponse(clientMessage)
+			Expect(err).ToNot(HaveOccurred())
+			addresses := replyMessage.Options.OneIANA().Options.Addresses()
+			Expect(addresses).To(HaveLen(2))
+			for i, address := range addresses {
+				Expect(address.IPv6Addr.Equal(clientIPs[i])).To(BeTrue())
+				Expect(address.PreferredLifetime).To(Equal(infiniteLease))
+				Expect(address.ValidLifetime).To(Equal(infiniteLease))
+			}
+		})
 	})
 })
 
@@ -3,9 +3,13 @@ package dns
 import (
 	"bufio"
 	"bytes"
+	"errors"
+	"fmt"
 	"net"
 	"os"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"kubevirt.io/client-go/log"
@@ -16,8 +20,22 @@ const (
 	nameserverPrefix    = "nameserver"
 	defaultDNS          = "8.8.8.8"
 	defaultSearchDomain = "cluster.local"
+
+	// DefaultServiceInfix is the infix of the Kubernetes service search domains, e.g. namespace.svc.cluster.local
+	DefaultServiceInfix = ".svc."
+	optionsPrefix       = "options"
+	sortListPrefix      = "sortlist"
+
+	// glibc resolver limits, see resolv.conf(5)
+	maxNameservers      = 3
+	maxSearchDomains    = 6
+	maxSearchLineLength = 256
+	maxSortListEntries  = 10
 )
 
+// ErrNoServiceDomain is returned when a domain name can't be built as there is no service search domain
+var ErrNoServiceDomain = errors.New("no service search domain found")
+
 func ParseNameservers(content string) ([][]byte, error) {
 	var nameservers [][]byte
 
@@ -50,6 +68,38 @@ func ParseNameservers(content string) ([][]byte, error) {
 	return nameservers, nil
 }
 
+// ParseNameserversLimited parses the nameservers like ParseNameservers, keeping at most limit nameservers
+// of each IP family, as the resolver ignores the ones beyond MAXNS and they may not fit in the DHCP options.
+// A limit lower than 1 defaults to the resolver limit of 3 nameservers.
+func ParseNameserversLimited(content string, limit int) ([][]byte, error) {
+	nameservers, err := ParseNameservers(content)
+	if err != nil {
+		return nameservers, err
+	}
+
+	if limit < 1 {
+		limit = maxNameservers
+	}
+
+	var kept [][]byte
+	var dropped []string
+	perFamily := map[int]int{}
+	for _, nameserver := range nameservers {
+		family := len(nameserver)
+		if perFamily[family] == limit {
+			dropped = append(dropped, net.IP(nameserver).String())
+			continue
+		}
+		perFamily[family]++
+		kept = append(kept, nameserver)
+	}
+
+	if len(dropped) > 0 {
+		log.Log.Warningf("Dropping the nameservers beyond the first %d of each IP family: %s", limit, strings.Join(dropped, " "))
+	}
+	return kept, nil
+}
+
 func ParseSearchDomains(content string) ([]string, error) {
 	var searchDomains []string
 
@@ -77,9 +127,71 @@ func ParseSearchDomains(content string) ([]string, error) {
 	return searchDomains, nil
 }
 
+// ParseSortList returns the networks of the sortlist directives.
+// Each entry is an IPv4 address with an optional netmask, in dotted or prefix length form,
+// the natural mask of the address class is used when it is missing.
+// Malformed entries are skipped.
+func ParseSortList(content string) ([]*net.IPNet, error) {
+	var sortList []*net.IPNet
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != sortListPrefix {
+			continue
+		}
+		for _, entry := range fields[1:] {
+			network, err := parseSortListEntry(entry)
+			if err != nil {
+				log.Log.Reason(err).Warningf("Ignoring malformed sortlist entry %q", entry)
+				continue
+			}
+			sortList = append(sortList, network)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sortList, nil
+}
+
+func parseSortListEntry(entry string) (*net.IPNet, error) {
+	address, mask, hasMask := strings.Cut(entry, "/")
+
+	ip := net.ParseIP(address).To4()
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IPv4 address %q", address)
+	}
+
+	if !hasMask {
+		return &net.IPNet{IP: ip.Mask(ip.DefaultMask()), Mask: ip.DefaultMask()}, nil
+	}
+
+	if prefixLength, err := strconv.Atoi(mask); err == nil {
+		if prefixLength < 0 || prefixLength > net.IPv4len*8 {
+			return nil, fmt.Errorf("invalid prefix length %d", prefixLength)
+		}
+		ipMask := net.CIDRMask(prefixLength, net.IPv4len*8)
+		return &net.IPNet{IP: ip.Mask(ipMask), Mask: ipMask}, nil
+	}
+
+	maskIP := net.ParseIP(mask).To4()
+	if maskIP == nil {
+		return nil, fmt.Errorf("invalid netmask %q", mask)
+	}
+	ipMask := net.IPMask(maskIP)
+	if ones, bits := ipMask.Size(); ones == 0 && bits == 0 {
+		return nil, fmt.Errorf("non canonical netmask %q", mask)
+	}
+	return &net.IPNet{IP: ip.Mask(ipMask), Mask: ipMask}, nil
+}
+
 // GetLongestServiceDomainName returns the longest service search domain entry
-func GetLongestServiceDomainName(searchDomains []string) string {
-	serviceDomains := GetServiceDomainList(searchDomains)
+func GetLongestServiceDomainName(searchDomains []string, serviceInfix string) string {
+	serviceDomains := GetServiceDomainList(searchDomains, serviceInfix)
 	return GetDomainName(serviceDomains)
 }
 
@@ -94,13 +206,16 @@ func GetDomainName(searchDomains []string) string {
 	return selected
 }
 
-// GetServiceDomainList returns a list of search domains which are a service entry
-func GetServiceDomainList(searchDomains []string) []string {
-	const k8sServiceInfix = ".svc."
+// GetServiceDomainList returns a list of search domains which are a service entry,
+// identified by the service infix. DefaultServiceInfix is used when the infix is empty.
+func GetServiceDomainList(searchDomains []string, serviceInfix string) []string {
+	if serviceInfix == "" {
+		serviceInfix = DefaultServiceInfix
+	}
 
 	serviceDomains := []string{}
 	for _, d := range searchDomains {
-		if strings.Contains(d, k8sServiceInfix) {
+		if strings.Contains(d, serviceInfix) {
 			serviceDomains = append(serviceDomains, d)
 		}
 	}
@@ -114,17 +229,113 @@ func GetServiceDomainList(searchDomains []string) []string {
 // Due to this limitation subdomain.namespace.svc.cluster.local DNS was not added by k8s to the pod /etc/resolv.conf.
 // This function calculates the missing domain, which will be added by kubevirt.
 // see https://github.com/kubernetes/kubernetes/issues/48019 for more details.
-func DomainNameWithSubdomain(searchDomains []string, subdomain string) string {
-	if subdomain == "" {
+// The service search domains are identified by the service infix, see GetServiceDomainList.
+func DomainNameWithSubdomain(searchDomains []string, subdomain, serviceInfix string) string {
+	domainName, err := DomainNameWithSubdomainE(searchDomains, subdomain, serviceInfix)
+	if err != nil {
 		return ""
 	}
+	return domainName
+}
+
+// DomainNameWithSubdomainE is DomainNameWithSubdomain, reporting ErrNoServiceDomain
+// when the subdomain can't be added because there is no service search domain.
+func DomainNameWithSubdomainE(searchDomains []string, subdomain, serviceInfix string) (string, error) {
+	if subdomain == "" {
+		return "", nil
+	}
+
+	domainName := GetLongestServiceDomainName(searchDomains, serviceInfix)
+	if domainName == "" {
+		return "", ErrNoServiceDomain
+	}
+	if strings.HasPrefix(domainName, subdomain+".") {
+		return "", nil
+	}
+
+	return subdomain + "." + domainName, nil
+}
+
+// BuildResolvConf returns the content of a resolv.conf for the guest.
+// Nameservers, search domains and sortlist entries beyond the glibc limits are dropped,
+// options are rendered as "key:value", or just "key" when the value is empty.
+func BuildResolvConf(nameservers []net.IP, searchDomains []string, sortList []*net.IPNet, options map[string]string) string {
+	var content strings.Builder
+
+	for i, nameserver := range nameservers {
+		if i == maxNameservers {
+			log.Log.Warningf("Dropping the nameservers beyond the first %d: %v", maxNameservers, nameservers[maxNameservers:])
+			break
+		}
+		content.WriteString(nameserverPrefix + " " + nameserver.String() + "\n")
+	}
 
-	domainName := GetLongestServiceDomainName(searchDomains)
-	if domainName != "" && !strings.HasPrefix(domainName, subdomain+".") {
-		return subdomain + "." + domainName
+	if searchLine := buildSearchLine(searchDomains); searchLine != "" {
+		content.WriteString(searchLine + "\n")
 	}
 
-	return ""
+	if len(sortList) > 0 {
+		content.WriteString(sortListPrefix)
+		for i, network := range sortList {
+			if i == maxSortListEntries {
+				log.Log.Warningf("Dropping the sortlist entries beyond the first %d: %v", maxSortListEntries, sortList[maxSortListEntries:])
+				break
+			}
+			content.WriteString(" " + network.IP.String() + "/" + net.IP(network.Mask).String())
+		}
+		content.WriteString("\n")
+	}
+
+	if len(options) > 0 {
+		keys := make([]string, 0, len(options))
+		for key := range options {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		content.WriteString(optionsPrefix)
+		for _, key := range keys {
+			content.WriteString(" " + key)
+			if value := options[key]; value != "" {
+				content.WriteString(":" + value)
+			}
+		}
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+func buildSearchLine(searchDomains []string) string {
+	line := ""
+	for i, domain := range searchDomains {
+		if i == maxSearchDomains || len(line)+len(domain)+1 > maxSearchLineLength {
+			log.Log.Warningf("Dropping the search domains not fitting in the resolv.conf limits: %v", searchDomains[i:])
+			break
+		}
+		if line == "" {
+			line = domainSearchPrefix
+		}
+		line += " " + domain
+	}
+	return line
+}
+
+// NameserversIPv6First returns a copy of the nameservers with the IPv6 ones ahead of the IPv4 ones,
+// keeping the relative order of each family
+func NameserversIPv6First(nameservers []net.IP) []net.IP {
+	ordered := make([]net.IP, 0, len(nameservers))
+	for _, nameserver := range nameservers {
+		if nameserver.To4() == nil {
+			ordered = append(ordered, nameserver)
+		}
+	}
+	for _, nameserver := range nameservers {
+		if nameserver.To4() != nil {
+			ordered = append(ordered, nameserver)
+		}
+	}
+	return ordered
 }
 
 // GetResolvConfDetailsFromPod reads and parses the DNS resolver's configuration file.
@@ -137,7 +348,7 @@ func GetResolvConfDetailsFromPod() ([][]byte, []string, error) {
 		return nil, nil, err
 	}
 
-	nameservers, err := ParseNameservers(string(b))
+	nameservers, err := ParseNameserversLimited(string(b), maxNameservers)
 	if err != nil {
 		return nil, nil, err
 	}
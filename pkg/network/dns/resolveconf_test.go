@@ -3,6 +3,7 @@ package dns
 import (
 	"fmt"
 	"net"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -34,6 +35,26 @@ var _ = Describe("Resolveconf", func() {
 		})
 	})
 
+	Context("Function ParseNameserversLimited()", func() {
+		const resolvConf = "nameserver 10.0.0.1\nnameserver 10.0.0.2\nnameserver fd00::1\nnameserver 10.0.0.3\nnameserver 10.0.0.4\nnameserver 10.0.0.5\n"
+
+		DescribeTable("should keep at most the limit of nameservers", func(limit int, expected [][]byte) {
+			nameservers, err := ParseNameserversLimited(resolvConf, limit)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nameservers).To(Equal(expected))
+		},
+			Entry("defaulting to the resolver limit", 0, [][]byte{{10, 0, 0, 1}, {10, 0, 0, 2}, {10, 0, 0, 3}}),
+			Entry("with a custom limit", 4, [][]byte{{10, 0, 0, 1}, {10, 0, 0, 2}, {10, 0, 0, 3}, {10, 0, 0, 4}}),
+			Entry("with a limit above the number of nameservers", 10, [][]byte{{10, 0, 0, 1}, {10, 0, 0, 2}, {10, 0, 0, 3}, {10, 0, 0, 4}, {10, 0, 0, 5}}),
+		)
+
+		It("should return the default nameserver if none is parsed", func() {
+			nameservers, err := ParseNameserversLimited("", 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nameservers).To(Equal([][]byte{net.ParseIP(defaultDNS).To4()}))
+		})
+	})
+
 	Context("Function ParseSearchDomains()", func() {
 		It("should return a string of search domains", func() {
 			resolvConf := "search cluster.local svc.cluster.local example.com\nnameserver 8.8.8.8\n"
@@ -97,7 +118,7 @@ var _ = Describe("Resolveconf", func() {
 			searchDomains := []string{"default.svc.cluster.local", "svc.cluster.local", "cluster.local"}
 
 			const subdomain = "subdomain"
-			domain := DomainNameWithSubdomain(searchDomains, subdomain)
+			domain := DomainNameWithSubdomain(searchDomains, subdomain, DefaultServiceInfix)
 			Expect(domain).To(Equal(subdomain + "." + searchDomains[0]))
 		})
 
@@ -105,7 +126,7 @@ var _ = Describe("Resolveconf", func() {
 			searchDomains := []string{"default.svc.cluster.local", "svc.cluster.local", "cluster.local"}
 
 			const subdomain = ""
-			domain := DomainNameWithSubdomain(searchDomains, subdomain)
+			domain := DomainNameWithSubdomain(searchDomains, subdomain, DefaultServiceInfix)
 			Expect(domain).To(Equal(""))
 		})
 
@@ -113,7 +134,7 @@ var _ = Describe("Resolveconf", func() {
 			searchDomains := []string{"svc.cluster.local", "cluster.local", "default.svc.cluster.local"}
 
 			const subdomain = "subdomain"
-			domain := DomainNameWithSubdomain(searchDomains, subdomain)
+			domain := DomainNameWithSubdomain(searchDomains, subdomain, DefaultServiceInfix)
 			Expect(domain).To(Equal(subdomain + "." + searchDomains[2]))
 		})
 
@@ -121,7 +142,7 @@ var _ = Describe("Resolveconf", func() {
 			searchDomains := []string{"svc.cluster.local", "cluster.local", "subdomain.default.svc.cluster.local"}
 
 			const subdomain = "subdomain"
-			domain := DomainNameWithSubdomain(searchDomains, subdomain)
+			domain := DomainNameWithSubdomain(searchDomains, subdomain, DefaultServiceInfix)
 			Expect(domain).To(Equal(""))
 		})
 
@@ -130,7 +151,7 @@ var _ = Describe("Resolveconf", func() {
 				"cluster.local", "this.is.a.very.very.very.long.entry"}
 
 			const subdomain = "subdomain"
-			domain := DomainNameWithSubdomain(searchDomains, subdomain)
+			domain := DomainNameWithSubdomain(searchDomains, subdomain, DefaultServiceInfix)
 			Expect(domain).To(Equal(subdomain + "." + searchDomains[0]))
 		})
 
@@ -138,8 +159,165 @@ var _ = Describe("Resolveconf", func() {
 			searchDomains := []string{"example.com"}
 
 			const subdomain = "subdomain"
-			domain := DomainNameWithSubdomain(searchDomains, subdomain)
+			domain := DomainNameWithSubdomain(searchDomains, subdomain, DefaultServiceInfix)
 			Expect(domain).To(Equal(""))
 		})
+
+		It("should be added to the longest service domain with a custom service infix", func() {
+			searchDomains := []string{"default.services.example.org", "services.example.org", "default.svc.cluster.local"}
+
+			const subdomain = "subdomain"
+			domain := DomainNameWithSubdomain(searchDomains, subdomain, ".services.")
+			Expect(domain).To(Equal(subdomain + "." + searchDomains[0]))
+		})
+
+		It("should use the default service infix when none is given", func() {
+			searchDomains := []string{"default.services.example.org", "default.svc.cluster.local"}
+
+			const subdomain = "subdomain"
+			domain := DomainNameWithSubdomain(searchDomains, subdomain, "")
+			Expect(domain).To(Equal(subdomain + "." + searchDomains[1]))
+		})
+	})
+
+	Context("function DomainNameWithSubdomainE", func() {
+		It("should return the domain with the subdomain", func() {
+			searchDomains := []string{"default.svc.cluster.local", "svc.cluster.local", "cluster.local"}
+			Expect(DomainNameWithSubdomainE(searchDomains, "subdomain", DefaultServiceInfix)).To(Equal("subdomain.default.svc.cluster.local"))
+		})
+
+		It("should return nothing to add when the longest service domain already has the subdomain", func() {
+			searchDomains := []string{"svc.cluster.local", "subdomain.default.svc.cluster.local"}
+			Expect(DomainNameWithSubdomainE(searchDomains, "subdomain", DefaultServiceInfix)).To(BeEmpty())
+		})
+
+		It("should return nothing to add when the subdomain is empty", func() {
+			Expect(DomainNameWithSubdomainE([]string{"example.com"}, "", DefaultServiceInfix)).To(BeEmpty())
+		})
+
+		It("should fail when there is no service domain", func() {
+			domain, err := DomainNameWithSubdomainE([]string{"example.com", "cluster.local"}, "subdomain", DefaultServiceInfix)
+			Expect(err).To(MatchError(ErrNoServiceDomain))
+			Expect(domain).To(BeEmpty())
+		})
+	})
+
+	Context("function GetServiceDomainList", func() {
+		DescribeTable("should return the service domains matching the infix", func(serviceInfix string, expected []string) {
+			searchDomains := []string{"default.svc.cluster.local", "default.services.example.org", "cluster.local"}
+			Expect(GetServiceDomainList(searchDomains, serviceInfix)).To(Equal(expected))
+		},
+			Entry("with the default infix", DefaultServiceInfix, []string{"default.svc.cluster.local"}),
+			Entry("with an empty infix", "", []string{"default.svc.cluster.local"}),
+			Entry("with a custom infix", ".services.", []string{"default.services.example.org"}),
+			Entry("with an infix matching nothing", ".srv.", []string{}),
+		)
+	})
+
+	Context("function BuildResolvConf", func() {
+		It("should be parsed back into the same nameservers and search domains", func() {
+			nameservers := []net.IP{net.ParseIP("10.96.0.10").To4(), net.ParseIP("8.8.8.8").To4()}
+			searchDomains := []string{"default.svc.cluster.local", "svc.cluster.local", "cluster.local"}
+
+			resolvConf := BuildResolvConf(nameservers, searchDomains, nil, nil)
+
+			parsedNameservers, err := ParseNameservers(resolvConf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsedNameservers).To(Equal([][]byte{nameservers[0], nameservers[1]}))
+			parsedSearchDomains, err := ParseSearchDomains(resolvConf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsedSearchDomains).To(Equal(searchDomains))
+		})
+
+		It("should render the options sorted by key", func() {
+			resolvConf := BuildResolvConf(
+				[]net.IP{net.ParseIP("fd10:0:2::3")},
+				[]string{"cluster.local"},
+				nil,
+				map[string]string{"ndots": "5", "edns0": "", "attempts": "2"},
+			)
+			Expect(resolvConf).To(Equal("nameserver fd10:0:2::3\nsearch cluster.local\noptions attempts:2 edns0 ndots:5\n"))
+		})
+
+		It("should be parsed back into the same sortlist", func() {
+			sortList := []*net.IPNet{
+				{IP: net.IPv4(130, 155, 160, 0).To4(), Mask: net.IPv4Mask(255, 255, 240, 0)},
+				{IP: net.IPv4(10, 0, 0, 0).To4(), Mask: net.CIDRMask(8, 32)},
+			}
+			resolvConf := BuildResolvConf([]net.IP{net.ParseIP("8.8.8.8")}, nil, sortList, nil)
+			Expect(resolvConf).To(ContainSubstring("sortlist 130.155.160.0/255.255.240.0 10.0.0.0/255.0.0.0\n"))
+
+			parsedSortList, err := ParseSortList(resolvConf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsedSortList).To(Equal(sortList))
+		})
+
+		It("should omit the empty search and options lines", func() {
+			Expect(BuildResolvConf([]net.IP{net.ParseIP("8.8.8.8")}, nil, nil, nil)).To(Equal("nameserver 8.8.8.8\n"))
+		})
+
+		It("should keep at most 3 nameservers", func() {
+			nameservers := []net.IP{
+				net.ParseIP("1.1.1.1").To4(), net.ParseIP("2.2.2.2").To4(), net.ParseIP("3.3.3.3").To4(), net.ParseIP("4.4.4.4").To4(),
+			}
+			parsedNameservers, err := ParseNameservers(BuildResolvConf(nameservers, nil, nil, nil))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsedNameservers).To(Equal([][]byte{nameservers[0], nameservers[1], nameservers[2]}))
+		})
+
+		It("should keep at most 6 search domains", func() {
+			searchDomains := []string{"a.local", "b.local", "c.local", "d.local", "e.local", "f.local", "g.local"}
+			parsedSearchDomains, err := ParseSearchDomains(BuildResolvConf(nil, searchDomains, nil, nil))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsedSearchDomains).To(Equal(searchDomains[:6]))
+		})
+
+		It("should keep the search line within 256 characters", func() {
+			searchDomains := []string{strings.Repeat("a", 120) + ".local", strings.Repeat("b", 120) + ".local", "cluster.local"}
+			resolvConf := BuildResolvConf(nil, searchDomains, nil, nil)
+			Expect(len(strings.TrimSuffix(resolvConf, "\n"))).To(BeNumerically("<=", 256))
+
+			parsedSearchDomains, err := ParseSearchDomains(resolvConf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsedSearchDomains).To(Equal(searchDomains[:1]))
+		})
+	})
+
+	Context("Function ParseSortList()", func() {
+		It("should parse the sortlist entries", func() {
+			resolvConf := "nameserver 8.8.8.8\nsortlist 130.155.160.0/255.255.240.0 10.1.2.3/16\nsortlist 192.168.1.7\n"
+			sortList, err := ParseSortList(resolvConf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sortList).To(Equal([]*net.IPNet{
+				{IP: net.IPv4(130, 155, 160, 0).To4(), Mask: net.IPv4Mask(255, 255, 240, 0)},
+				{IP: net.IPv4(10, 1, 0, 0).To4(), Mask: net.CIDRMask(16, 32)},
+				{IP: net.IPv4(192, 168, 1, 0).To4(), Mask: net.IPv4Mask(255, 255, 255, 0)},
+			}))
+		})
+
+		It("should ignore the malformed sortlist entries", func() {
+			resolvConf := "sortlist 10.0.0.0/8 300.1.1.1 10.2.0.0/255.0.255.0 fd10::/64 10.3.0.0/33 not-an-ip 172.16.0.0/12\n"
+			sortList, err := ParseSortList(resolvConf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sortList).To(Equal([]*net.IPNet{
+				{IP: net.IPv4(10, 0, 0, 0).To4(), Mask: net.CIDRMask(8, 32)},
+				{IP: net.IPv4(172, 16, 0, 0).To4(), Mask: net.CIDRMask(12, 32)},
+			}))
+		})
+
+		It("should return no sortlist when there is no sortlist directive", func() {
+			sortList, err := ParseSortList("nameserver 8.8.8.8\nsearch cluster.local\nsortlisting 10.0.0.0/8\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sortList).To(BeEmpty())
+		})
+	})
+
+	Context("function NameserversIPv6First", func() {
+		It("should order the IPv6 nameservers before the IPv4 ones keeping the order of each family", func() {
+			nameservers := []net.IP{
+				net.ParseIP("10.96.0.10"), net.ParseIP("fd10::10"), net.ParseIP("8.8.8.8"), net.ParseIP("2001:4860:4860::8888"),
+			}
+			Expect(NameserversIPv6First(nameservers)).To(Equal([]net.IP{nameservers[1], nameservers[3], nameservers[0], nameservers[2]}))
+		})
 	})
 })
@@ -165,7 +165,7 @@ func (h *NetworkUtilsHandler) StartDHCP(nic *cache.DHCPConfig, bridgeInterfaceNa
 		return fmt.Errorf("Failed to get DNS servers from resolv.conf: %v", err)
 	}
 
-	domain := dns.DomainNameWithSubdomain(searchDomains, nic.Subdomain)
+	domain := dns.DomainNameWithSubdomain(searchDomains, nic.Subdomain, nic.ServiceDomainInfix)
 	if domain != "" {
 		searchDomains = append([]string{domain}, searchDomains...)
 	}
@@ -91,6 +91,32 @@ var _ = Describe("Network Name Scheme", func() {
 				"network1": "net1",
 				"network2": "net2",
 			}),
+		Entry("ordinal, when the default multus network is between secondary networks",
+			namescheme.CreateOrdinalNetworkNameScheme,
+			[]virtv1.Network{
+				createMultusSecondaryNetwork("network1", "default/nad1"),
+				createMultusDefaultNetwork("network0", "default/nad0"),
+				createMultusSecondaryNetwork("network2", "default/nad2"),
+			},
+			map[string]string{
+				"network0": namescheme.PrimaryPodInterfaceName,
+				"network1": "net1",
+				"network2": "net2",
+			}),
+		Entry("ordinal, when the default multus network is the last network",
+			namescheme.CreateOrdinalNetworkNameScheme,
+			[]virtv1.Network{
+				createMultusSecondaryNetwork("network1", "default/nad1"),
+				createMultusSecondaryNetwork("network2", "default/nad2"),
+				createMultusSecondaryNetwork("network3", "default/nad3"),
+				createMultusDefaultNetwork("network0", "default/nad0"),
+			},
+			map[string]string{
+				"network0": namescheme.PrimaryPodInterfaceName,
+				"network1": "net1",
+				"network2": "net2",
+				"network3": "net3",
+			}),
 		Entry("ordinal, when default pod networks exist",
 			namescheme.CreateOrdinalNetworkNameScheme,
 			[]virtv1.Network{
@@ -101,14 +101,18 @@ func (c *NetConf) Setup(vmi *v1.VirtualMachineInstance, networks []v1.Network, l
 	if util.IsNonRootVMI(vmi) {
 		ownerID = util.NonRootUID
 	}
-	queuesCapacity := int(converter.NetworkQueuesCapacity(vmi))
+	ifaces := vmispec.FilterInterfacesByNetworks(vmi.Spec.Domain.Devices.Interfaces, networks)
+	queuesByIface := map[string]int{}
+	for i := range ifaces {
+		queuesByIface[ifaces[i].Name] = int(converter.CalculateNetworkQueues(vmi, &ifaces[i]))
+	}
 	netpod := netpod.NewNetPod(
 		networks,
-		vmispec.FilterInterfacesByNetworks(vmi.Spec.Domain.Devices.Interfaces, networks),
+		ifaces,
 		string(vmi.UID),
 		launcherPid,
 		ownerID,
-		queuesCapacity,
+		queuesByIface,
 		state,
 		netpod.WithMasqueradeAdapter(newMasqueradeAdapter(vmi)),
 		netpod.WithCacheCreator(c.cacheCreator),
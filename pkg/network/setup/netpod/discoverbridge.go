@@ -20,6 +20,7 @@
 package netpod
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
@@ -33,6 +34,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/network/cache"
 	"kubevirt.io/kubevirt/pkg/network/driver/nmstate"
+	"kubevirt.io/kubevirt/pkg/network/vmispec"
 )
 
 func (n NetPod) storeBridgeBindingDHCPInterfaceData(currentStatus *nmstate.Status, podIfaceStatus nmstate.Interface, vmiSpecIface v1.Interface, podIfaceName string) error {
@@ -57,7 +59,12 @@ func (n NetPod) storeBridgeBindingDHCPInterfaceData(currentStatus *nmstate.Statu
 		if err != nil {
 			return err
 		}
-		dhcpConfig.Gateway = net.ParseIP(linkRoutes[0].NextHopAddress)
+		gateway := defaultGatewayIPv4(linkRoutes)
+		// Secondary networks may legitimately have no default route, e.g. when their IPAM only adds a subnet route.
+		if gateway == nil && n.isPrimaryNetwork(vmiSpecIface.Name) {
+			return fmt.Errorf("%w in the %d routes of %s", errNoDefaultGateway, len(linkRoutes), podIfaceName)
+		}
+		dhcpConfig.Gateway = gateway
 
 		otherRoutes, err := filterRoutesByNonLocalDestination(linkRoutes, addr)
 		if err != nil {
@@ -158,11 +165,30 @@ func filterIPv4RoutesByInterface(currentStatus *nmstate.Status, podIfaceName str
 		}
 	}
 	if len(linkRoutes) == 0 {
-		return nil, fmt.Errorf("no gateway address found in routes for %s", podIfaceName)
+		return nil, fmt.Errorf("no routes found for %s", podIfaceName)
 	}
 	return linkRoutes, nil
 }
 
+// errNoDefaultGateway is returned when the primary interface has routes but none of them is a default route.
+// The default route may be installed shortly after the pod started, therefore this error is retried.
+var errNoDefaultGateway = errors.New("no default gateway found")
+
+func defaultGatewayIPv4(linkRoutes []nmstate.Route) net.IP {
+	defaultDestination := nmstate.DefaultDestinationRoute(vishnetlink.FAMILY_V4).String()
+	for _, route := range linkRoutes {
+		if route.Destination == defaultDestination && route.NextHopAddress != "" {
+			return net.ParseIP(route.NextHopAddress)
+		}
+	}
+	return nil
+}
+
+func (n NetPod) isPrimaryNetwork(networkName string) bool {
+	network := vmispec.LookupNetworkByName(n.vmiSpecNets, networkName)
+	return network != nil && !vmispec.IsSecondaryMultusNetwork(*network)
+}
+
 func resolveMacAddress(macAddressFromCurrent string, macAddressFromVMISpec string) (net.HardwareAddr, error) {
 	macAddress := macAddressFromCurrent
 	if macAddressFromVMISpec != "" {
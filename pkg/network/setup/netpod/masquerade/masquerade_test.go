@@ -22,6 +22,7 @@ package masquerade_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -339,6 +340,61 @@ family ip6 table nat chain output rulespec [ip6 daddr { ::1 } tcp dport 8080 cou
 		Expect(nftStub.String()).To(Equal(expectedConfig), fmt.Sprintf("actual:\n%s\n\nexpected:\n%s", nftStub.String(), expectedConfig))
 	})
 
+	It("setup with IPv4 and IPv6, including an SCTP port", func() {
+		nftStub := &nftableStub{}
+		masqPod := masquerade.New(masquerade.WithNftableAdapter(nftStub))
+
+		err := masqPod.Setup(
+			&nmstate.Interface{
+				Name:       "k6t-eth0",
+				Index:      1,
+				TypeName:   nmstate.TypeBridge,
+				State:      nmstate.IfaceStateUp,
+				MacAddress: "bb:bb:bb:bb:bb:bb",
+				IPv4: nmstate.IP{
+					Enabled: pointer.P(true),
+					Address: []nmstate.IPAddress{{IP: "10.0.2.1", PrefixLen: 24}},
+				},
+				IPv6: nmstate.IP{
+					Enabled: pointer.P(true),
+					Address: []nmstate.IPAddress{{IP: "fd10:0:2::1", PrefixLen: 120}},
+				},
+				Metadata: &nmstate.IfaceMetadata{Pid: 0, NetworkName: "default"},
+			},
+			&nmstate.Interface{
+				Name:       "eth0",
+				Index:      0,
+				TypeName:   nmstate.TypeVETH,
+				State:      nmstate.IfaceStateUp,
+				MacAddress: "aa:aa:aa:aa:aa:aa",
+				MTU:        1500,
+				IPv4: nmstate.IP{
+					Enabled: pointer.P(true),
+					Address: []nmstate.IPAddress{{IP: "10.222.222.1", PrefixLen: 30}},
+				},
+				IPv6: nmstate.IP{
+					Enabled: pointer.P(true),
+					Address: []nmstate.IPAddress{{IP: "2001::1", PrefixLen: 64}},
+				},
+				Metadata: &nmstate.IfaceMetadata{Pid: 0, NetworkName: "default"},
+			},
+			v1.Interface{
+				Name:                   "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+				Ports:                  []v1.Port{{Name: "diameter", Protocol: "SCTP", Port: 3868}},
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Split(nftStub.String(), "\n")).To(ContainElements(
+			"family ip table nat chain KUBEVIRT_PREINBOUND rulespec [sctp dport { 3868 } counter dnat to 10.0.2.2]",
+			"family ip table nat chain KUBEVIRT_POSTINBOUND rulespec [sctp dport 3868 ip saddr { 127.0.0.1 } counter snat to 10.0.2.1]",
+			"family ip table nat chain output rulespec [ip daddr { 127.0.0.1 } sctp dport 3868 counter dnat to 10.0.2.2]",
+			"family ip6 table nat chain KUBEVIRT_PREINBOUND rulespec [sctp dport { 3868 } counter dnat to fd10:0:2::2]",
+			"family ip6 table nat chain KUBEVIRT_POSTINBOUND rulespec [sctp dport 3868 ip6 saddr { ::1 } counter snat to fd10:0:2::1]",
+			"family ip6 table nat chain output rulespec [ip6 daddr { ::1 } sctp dport 3868 counter dnat to fd10:0:2::2]",
+		))
+	})
+
 	Context("with ISTIO", func() {
 		It("setup with IPv4 and IPv6, no ports", func() {
 			nftStub := &nftableStub{}
@@ -21,9 +21,11 @@ package netpod
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 
@@ -69,7 +71,7 @@ type NetPod struct {
 	vmiUID        string
 	podPID        int
 	ownerID       int
-	queuesCap     int
+	queuesByIface map[string]int
 
 	nmstateAdapter    nmstateAdapter
 	masqueradeAdapter masqueradeAdapter
@@ -77,19 +79,27 @@ type NetPod struct {
 	cacheCreator cacheCreator
 	state        *State
 
+	gatewayDiscoveryAttempts int
+	gatewayDiscoveryInterval time.Duration
+
 	log *log.FilteredLogger
 }
 
+const (
+	defaultGatewayDiscoveryAttempts = 5
+	defaultGatewayDiscoveryInterval = time.Second
+)
+
 type option func(*NetPod)
 
-func NewNetPod(vmiNetworks []v1.Network, vmiIfaces []v1.Interface, vmiUID string, podPID, ownerID, queuesCapacity int, state *State, opts ...option) NetPod {
+func NewNetPod(vmiNetworks []v1.Network, vmiIfaces []v1.Interface, vmiUID string, podPID, ownerID int, queuesByIface map[string]int, state *State, opts ...option) NetPod {
 	n := NetPod{
 		vmiSpecIfaces: vmiIfaces,
 		vmiSpecNets:   vmiNetworks,
 		vmiUID:        vmiUID,
 		podPID:        podPID,
 		ownerID:       ownerID,
-		queuesCap:     queuesCapacity,
+		queuesByIface: queuesByIface,
 		state:         state,
 
 		nmstateAdapter:    nmstate.New(),
@@ -97,6 +107,9 @@ func NewNetPod(vmiNetworks []v1.Network, vmiIfaces []v1.Interface, vmiUID string
 
 		cacheCreator: cache.CacheCreator{},
 
+		gatewayDiscoveryAttempts: defaultGatewayDiscoveryAttempts,
+		gatewayDiscoveryInterval: defaultGatewayDiscoveryInterval,
+
 		log: log.Log,
 	}
 	for _, opt := range opts {
@@ -129,6 +142,13 @@ func WithLogger(logger *log.FilteredLogger) option {
 	}
 }
 
+func WithGatewayDiscoveryRetry(attempts int, interval time.Duration) option {
+	return func(n *NetPod) {
+		n.gatewayDiscoveryAttempts = attempts
+		n.gatewayDiscoveryInterval = interval
+	}
+}
+
 func (n NetPod) Setup() error {
 	// Not all network bindings are processed in the network setup.
 	filteredNets, err := filterSupportedBindingNetworks(n.vmiSpecNets, n.vmiSpecIfaces)
@@ -157,21 +177,11 @@ func (n NetPod) Setup() error {
 	}
 
 	err = n.state.NSExec.Do(func() error {
-		currentStatus, err := n.nmstateAdapter.Read()
+		currentStatus, err := n.readAndDiscover()
 		if err != nil {
 			return err
 		}
 
-		currentStatusBytes, err := json.Marshal(currentStatus)
-		if err != nil {
-			return err
-		}
-		n.log.Infof("Current pod network: %s", currentStatusBytes)
-
-		if derr := n.discover(currentStatus); derr != nil {
-			return derr
-		}
-
 		if serr := n.state.SetStarted(pendingNets); serr != nil {
 			return serr
 		}
@@ -199,6 +209,34 @@ func (n NetPod) Setup() error {
 	return nil
 }
 
+// readAndDiscover reads the current pod network status and discovers it.
+// When the default gateway is not found, the status is re-read a bounded number of times,
+// as the default route may be learned shortly after the pod has started.
+func (n NetPod) readAndDiscover() (*nmstate.Status, error) {
+	for attempt := 1; ; attempt++ {
+		currentStatus, err := n.nmstateAdapter.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		currentStatusBytes, err := json.Marshal(currentStatus)
+		if err != nil {
+			return nil, err
+		}
+		n.log.Infof("Current pod network: %s", currentStatusBytes)
+
+		err = n.discover(currentStatus)
+		if err == nil {
+			return currentStatus, nil
+		}
+		if !errors.Is(err, errNoDefaultGateway) || attempt >= n.gatewayDiscoveryAttempts {
+			return nil, err
+		}
+		n.log.Infof("Default gateway not available yet (attempt %d/%d): %v", attempt, n.gatewayDiscoveryAttempts, err)
+		time.Sleep(n.gatewayDiscoveryInterval)
+	}
+}
+
 func (n NetPod) validateNoNetworkReconfigured(startedNets []v1.Network) error {
 	if len(startedNets) > 0 {
 		for _, net := range startedNets {
@@ -380,15 +418,7 @@ func (n NetPod) bridgeBindingSpec(podIfaceName string, vmiIfaceIndex int, ifaceS
 }
 
 func (n NetPod) networkQueues(vmiIfaceIndex int) int {
-	ifaceModel := n.vmiSpecIfaces[vmiIfaceIndex].Model
-	if ifaceModel == "" {
-		ifaceModel = v1.VirtIO
-	}
-	var queues int
-	if ifaceModel == v1.VirtIO {
-		queues = n.queuesCap
-	}
-	return queues
+	return n.queuesByIface[n.vmiSpecIfaces[vmiIfaceIndex].Name]
 }
 
 func (n NetPod) masqueradeBindingSpec(podIfaceName string, vmiIfaceIndex int, ifaceStatusByName map[string]nmstate.Interface) ([]nmstate.Interface, error) {
@@ -89,7 +89,7 @@ var _ = Describe("netpod", func() {
 				Name:                   defaultPodNetworkName,
 				InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
 			}},
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(&nmstateStub{readErr: errNMStateRead}),
 			netpod.WithCacheCreator(&baseCacheCreator),
 		)
@@ -103,7 +103,7 @@ var _ = Describe("netpod", func() {
 				Name:                   defaultPodNetworkName,
 				InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
 			}},
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(&nmstateStub{
 				applyErr: errNMStateApply,
 				status: nmstate.Status{
@@ -130,7 +130,7 @@ var _ = Describe("netpod", func() {
 		netPod := netpod.NewNetPod(
 			[]v1.Network{*v1.DefaultPodNetwork()},
 			[]v1.Interface{{Name: defaultPodNetworkName}},
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(&nmstateStub{status: nmstate.Status{
 				Interfaces: []nmstate.Interface{{
 					Name:       "eth0",
@@ -154,7 +154,7 @@ var _ = Describe("netpod", func() {
 				Name:                   defaultPodNetworkName,
 				InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
 			}},
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(&nmstateStub{status: nmstate.Status{
 				Interfaces: []nmstate.Interface{{
 					Name:       "eth0",
@@ -175,7 +175,7 @@ var _ = Describe("netpod", func() {
 		netPod := netpod.NewNetPod(
 			[]v1.Network{*v1.DefaultPodNetwork()},
 			[]v1.Interface{{Name: defaultPodNetworkName, InterfaceBindingMethod: binding}},
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(&nmstateStub{status: nmstate.Status{
 				Interfaces: []nmstate.Interface{{Name: "other0"}},
 			}}),
@@ -227,7 +227,7 @@ var _ = Describe("netpod", func() {
 		netPod := netpod.NewNetPod(
 			[]v1.Network{*v1.DefaultPodNetwork()},
 			[]v1.Interface{vmiIface},
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(&nmstatestub),
 			netpod.WithMasqueradeAdapter(&masqstub),
 			netpod.WithCacheCreator(&baseCacheCreator),
@@ -280,6 +280,44 @@ var _ = Describe("netpod", func() {
 		}))
 	})
 
+	It("setup masquerade binding with the queues of the interface", func() {
+		const queues = 4
+		nmstatestub := nmstateStub{status: nmstate.Status{
+			Interfaces: []nmstate.Interface{{
+				Name:       "eth0",
+				Index:      0,
+				TypeName:   nmstate.TypeVETH,
+				State:      nmstate.IfaceStateUp,
+				MacAddress: "12:34:56:78:90:ab",
+				MTU:        1500,
+				IPv4: nmstate.IP{
+					Enabled: pointer.P(true),
+					Address: []nmstate.IPAddress{{
+						IP:        primaryIPv4Address,
+						PrefixLen: 30,
+					}},
+				},
+			}},
+		}}
+
+		netPod := netpod.NewNetPod(
+			[]v1.Network{*v1.DefaultPodNetwork()},
+			[]v1.Interface{{
+				Name:                   defaultPodNetworkName,
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}},
+			}},
+			vmiUID, 0, 0, map[string]int{defaultPodNetworkName: queues}, state,
+			netpod.WithNMStateAdapter(&nmstatestub),
+			netpod.WithMasqueradeAdapter(&masqueradeStub{}),
+			netpod.WithCacheCreator(&baseCacheCreator),
+		)
+		Expect(netPod.Setup()).To(Succeed())
+		Expect(nmstatestub.spec.Interfaces).To(ContainElement(And(
+			HaveField("Name", "tap0"),
+			HaveField("Tap", Equal(&nmstate.TapDevice{Queues: queues, UID: 0, GID: 0})),
+		)))
+	})
+
 	It("setup bridge binding with IP and a static route", func() {
 		const (
 			defaultGatewayIP4Address = "10.222.222.254"
@@ -347,7 +385,7 @@ var _ = Describe("netpod", func() {
 		netPod := netpod.NewNetPod(
 			[]v1.Network{*v1.DefaultPodNetwork()},
 			[]v1.Interface{vmiIface},
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(&nmstatestub),
 			netpod.WithCacheCreator(&baseCacheCreator),
 		)
@@ -433,6 +471,125 @@ var _ = Describe("netpod", func() {
 		}))
 	})
 
+	Context("bridge binding default gateway discovery", func() {
+		const (
+			defaultGatewayIP4Address = "10.222.222.254"
+			podIfaceOrignalMAC       = "12:34:56:78:90:ab"
+		)
+
+		newBridgeNetPod := func(nmstatestub *nmstateStub) netpod.NetPod {
+			return netpod.NewNetPod(
+				[]v1.Network{*v1.DefaultPodNetwork()},
+				[]v1.Interface{{
+					Name:                   defaultPodNetworkName,
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				}},
+				vmiUID, 0, 0, nil, state,
+				netpod.WithNMStateAdapter(nmstatestub),
+				netpod.WithCacheCreator(&baseCacheCreator),
+				netpod.WithGatewayDiscoveryRetry(3, 0),
+			)
+		}
+
+		podStatusWithRoutes := func(routes ...nmstate.Route) nmstate.Status {
+			return nmstate.Status{
+				Interfaces: []nmstate.Interface{{
+					Name:       "eth0",
+					Index:      0,
+					TypeName:   nmstate.TypeVETH,
+					State:      nmstate.IfaceStateUp,
+					MacAddress: podIfaceOrignalMAC,
+					MTU:        1500,
+					IPv4: nmstate.IP{
+						Enabled: pointer.P(true),
+						Address: []nmstate.IPAddress{{
+							IP:        primaryIPv4Address,
+							PrefixLen: 30,
+						}},
+					},
+					IPv6: ipDisabled,
+				}},
+				Routes: nmstate.Routes{Running: routes},
+			}
+		}
+
+		localRoute := nmstate.Route{
+			Destination:      "10.222.222.0/30",
+			NextHopInterface: "eth0",
+			NextHopAddress:   primaryIPv4Address,
+		}
+		defaultRoute := nmstate.Route{
+			Destination:      "0.0.0.0/0",
+			NextHopInterface: "eth0",
+			NextHopAddress:   defaultGatewayIP4Address,
+		}
+
+		It("succeeds when the default gateway shows up after the first read", func() {
+			nmstatestub := nmstateStub{
+				pendingStatuses: []nmstate.Status{podStatusWithRoutes(localRoute)},
+				status:          podStatusWithRoutes(defaultRoute, localRoute),
+			}
+
+			Expect(newBridgeNetPod(&nmstatestub).Setup()).To(Succeed())
+			Expect(nmstatestub.reads).To(Equal(2))
+
+			dhcpConfig, err := cache.ReadDHCPInterfaceCache(&baseCacheCreator, "0", "eth0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dhcpConfig.Gateway).To(Equal(net.ParseIP(defaultGatewayIP4Address)))
+		})
+
+		It("fails after the bounded retries when routes exist but no default gateway", func() {
+			nmstatestub := nmstateStub{status: podStatusWithRoutes(localRoute)}
+
+			err := newBridgeNetPod(&nmstatestub).Setup()
+			Expect(err).To(MatchError(ContainSubstring("no default gateway found in the 1 routes of eth0")))
+			Expect(nmstatestub.reads).To(Equal(3))
+		})
+
+		It("fails without retrying when there are no routes at all", func() {
+			nmstatestub := nmstateStub{status: podStatusWithRoutes()}
+
+			err := newBridgeNetPod(&nmstatestub).Setup()
+			Expect(err).To(MatchError("no routes found for eth0"))
+			Expect(nmstatestub.reads).To(Equal(1))
+		})
+
+		It("succeeds without a gateway and without retrying on a secondary network with no default route", func() {
+			const (
+				secondaryNetworkName      = "secondnetwork"
+				secondaryPodInterfaceName = "pod914f438d88d"
+			)
+			status := podStatusWithRoutes(nmstate.Route{
+				Destination:      "10.222.222.0/30",
+				NextHopInterface: secondaryPodInterfaceName,
+				NextHopAddress:   primaryIPv4Address,
+			})
+			status.Interfaces[0].Name = secondaryPodInterfaceName
+			nmstatestub := nmstateStub{status: status}
+
+			netPod := netpod.NewNetPod(
+				[]v1.Network{{
+					Name:          secondaryNetworkName,
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "somenad"}},
+				}},
+				[]v1.Interface{{
+					Name:                   secondaryNetworkName,
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				}},
+				vmiUID, 0, 0, nil, state,
+				netpod.WithNMStateAdapter(&nmstatestub),
+				netpod.WithCacheCreator(&baseCacheCreator),
+				netpod.WithGatewayDiscoveryRetry(3, 0),
+			)
+			Expect(netPod.Setup()).To(Succeed())
+			Expect(nmstatestub.reads).To(Equal(1))
+
+			dhcpConfig, err := cache.ReadDHCPInterfaceCache(&baseCacheCreator, "0", secondaryPodInterfaceName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dhcpConfig.Gateway).To(BeNil())
+		})
+	})
+
 	It("setup bridge binding without IP", func() {
 		const podIfaceOrignalMAC = "12:34:56:78:90:ab"
 		const linklocalIPv6Address = "fe80::1"
@@ -461,7 +618,7 @@ var _ = Describe("netpod", func() {
 				Name:                   defaultPodNetworkName,
 				InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
 			}},
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(&nmstatestub),
 			netpod.WithCacheCreator(&baseCacheCreator),
 		)
@@ -614,7 +771,7 @@ var _ = Describe("netpod", func() {
 			netPod := netpod.NewNetPod(
 				initialNetworksToPlug,
 				initialInterfacesToPlug,
-				vmiUID, 0, 0, 0, state,
+				vmiUID, 0, 0, nil, state,
 				netpod.WithNMStateAdapter(&nmstatestub),
 				netpod.WithMasqueradeAdapter(&masqstub),
 				netpod.WithCacheCreator(&baseCacheCreator),
@@ -665,7 +822,7 @@ var _ = Describe("netpod", func() {
 			netPod = netpod.NewNetPod(
 				specNetworks,
 				specInterfaces,
-				vmiUID, 0, 0, 0, state,
+				vmiUID, 0, 0, nil, state,
 				netpod.WithNMStateAdapter(&nmstatestub),
 				netpod.WithMasqueradeAdapter(&masqstub),
 				netpod.WithCacheCreator(&baseCacheCreator),
@@ -742,7 +899,7 @@ var _ = Describe("netpod", func() {
 			netPod := netpod.NewNetPod(
 				specNetworks,
 				specInterfaces,
-				vmiUID, 0, 0, 0, state,
+				vmiUID, 0, 0, nil, state,
 				netpod.WithNMStateAdapter(&nmstatestub),
 				netpod.WithMasqueradeAdapter(&masqstub),
 				netpod.WithCacheCreator(&baseCacheCreator),
@@ -823,7 +980,7 @@ var _ = Describe("netpod", func() {
 			netPod := netpod.NewNetPod(
 				specNetworks,
 				specInterfaces,
-				vmiUID, 0, 0, 0, state,
+				vmiUID, 0, 0, nil, state,
 				netpod.WithNMStateAdapter(&nmstatestub),
 				netpod.WithMasqueradeAdapter(&masqstub),
 				netpod.WithCacheCreator(&baseCacheCreator),
@@ -943,7 +1100,7 @@ var _ = Describe("netpod", func() {
 		netPod := netpod.NewNetPod(
 			[]v1.Network{*v1.DefaultPodNetwork()},
 			[]v1.Interface{vmiIface},
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(&nmstatestub),
 			netpod.WithCacheCreator(&baseCacheCreator),
 		)
@@ -985,7 +1142,7 @@ var _ = Describe("netpod", func() {
 				},
 			},
 			[]v1.Interface{{Name: "somenet", InterfaceBindingMethod: binding}},
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(&nmstatestub),
 			netpod.WithCacheCreator(&baseCacheCreator),
 		)
@@ -1063,7 +1220,7 @@ var _ = Describe("netpod", func() {
 			netPod := netpod.NewNetPod(
 				specNetworks,
 				specInterfaces,
-				vmiUID, 0, 0, 0, state,
+				vmiUID, 0, 0, nil, state,
 				netpod.WithNMStateAdapter(nmstatestub),
 				netpod.WithCacheCreator(&baseCacheCreator),
 			)
@@ -1085,7 +1242,7 @@ var _ = Describe("netpod", func() {
 			netPod := netpod.NewNetPod(
 				specNetworks,
 				specInterfaces,
-				vmiUID, 0, 0, 0, state,
+				vmiUID, 0, 0, nil, state,
 				netpod.WithNMStateAdapter(nmstatestub),
 				netpod.WithCacheCreator(&baseCacheCreator),
 			)
@@ -1200,7 +1357,7 @@ var _ = Describe("netpod", func() {
 			netPod := netpod.NewNetPod(
 				specNetworks,
 				specInterfaces,
-				vmiUID, 0, 0, 0, state,
+				vmiUID, 0, 0, nil, state,
 				netpod.WithNMStateAdapter(nmstatestub),
 				netpod.WithCacheCreator(&baseCacheCreator),
 			)
@@ -1312,7 +1469,7 @@ var _ = Describe("netpod", func() {
 			netPod := netpod.NewNetPod(
 				specNetworks,
 				specInterfaces,
-				vmiUID, 0, 0, 0, state,
+				vmiUID, 0, 0, nil, state,
 				netpod.WithNMStateAdapter(nmstatestub),
 				netpod.WithCacheCreator(&baseCacheCreator),
 			)
@@ -1483,7 +1640,7 @@ var _ = Describe("netpod", func() {
 		netPod := netpod.NewNetPod(
 			specNetworks[:2],
 			specInterfaces[:2],
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(nmstatestub),
 			netpod.WithCacheCreator(&baseCacheCreator),
 		)
@@ -1494,7 +1651,7 @@ var _ = Describe("netpod", func() {
 		netPod = netpod.NewNetPod(
 			specNetworks[2:],
 			specInterfaces[2:],
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(nmstatestub),
 			netpod.WithCacheCreator(&baseCacheCreator),
 		)
@@ -1513,7 +1670,7 @@ var _ = Describe("netpod", func() {
 		netPod = netpod.NewNetPod(
 			specNetworks,
 			specInterfaces,
-			vmiUID, 0, 0, 0, state,
+			vmiUID, 0, 0, nil, state,
 			netpod.WithNMStateAdapter(nmstatestub),
 			netpod.WithCacheCreator(&baseCacheCreator),
 		)
@@ -1628,6 +1785,10 @@ type nmstateStub struct {
 	readErr  error
 	spec     nmstate.Spec
 	status   nmstate.Status
+
+	// pendingStatuses are returned by Read (one per call) before falling back to status.
+	pendingStatuses []nmstate.Status
+	reads           int
 }
 
 var (
@@ -1644,6 +1805,12 @@ func (n *nmstateStub) Apply(spec *nmstate.Spec) error {
 }
 
 func (n *nmstateStub) Read() (*nmstate.Status, error) {
+	n.reads++
+	if len(n.pendingStatuses) > 0 {
+		status := n.pendingStatuses[0]
+		n.pendingStatuses = n.pendingStatuses[1:]
+		return &status, n.readErr
+	}
 	return &n.status, n.readErr
 }
 
@@ -30,10 +30,11 @@ import (
 )
 
 type VMNetworkConfigurator struct {
-	vmi               *v1.VirtualMachineInstance
-	handler           netdriver.NetworkHandler
-	cacheCreator      cacheCreator
-	domainAttachments map[string]string
+	vmi                *v1.VirtualMachineInstance
+	handler            netdriver.NetworkHandler
+	cacheCreator       cacheCreator
+	domainAttachments  map[string]string
+	serviceDomainInfix string
 }
 
 type vmNetConfiguratorOption func(v *VMNetworkConfigurator)
@@ -56,6 +57,13 @@ func WithDomainAttachments(domainAttachments map[string]string) vmNetConfigurato
 	}
 }
 
+// WithServiceDomainInfix sets the infix identifying the service search domains advertised to the guest
+func WithServiceDomainInfix(serviceDomainInfix string) vmNetConfiguratorOption {
+	return func(v *VMNetworkConfigurator) {
+		v.serviceDomainInfix = serviceDomainInfix
+	}
+}
+
 func (v VMNetworkConfigurator) getPhase2NICs(domain *api.Domain, networks []v1.Network) ([]podNIC, error) {
 	var nics []podNIC
 
@@ -70,7 +78,7 @@ func (v VMNetworkConfigurator) getPhase2NICs(domain *api.Domain, networks []v1.N
 			continue
 		}
 
-		nic, err := newPhase2PodNIC(v.vmi, &networks[i], iface, v.handler, v.cacheCreator, domain, v.domainAttachments[iface.Name])
+		nic, err := newPhase2PodNIC(v.vmi, &networks[i], iface, v.handler, v.cacheCreator, domain, v.domainAttachments[iface.Name], v.serviceDomainInfix)
 		if err != nil {
 			return nil, err
 		}
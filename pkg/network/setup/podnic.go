@@ -40,22 +40,24 @@ import (
 const defaultState = cache.PodIfaceNetworkPreparationPending
 
 type podNIC struct {
-	vmi              *v1.VirtualMachineInstance
-	podInterfaceName string
-	launcherPID      *int
-	vmiSpecIface     *v1.Interface
-	vmiSpecNetwork   *v1.Network
-	handler          netdriver.NetworkHandler
-	cacheCreator     cacheCreator
-	dhcpConfigurator dhcpconfigurator.Configurator
-	domainGenerator  domainspec.LibvirtSpecGenerator
+	vmi                *v1.VirtualMachineInstance
+	podInterfaceName   string
+	launcherPID        *int
+	vmiSpecIface       *v1.Interface
+	vmiSpecNetwork     *v1.Network
+	handler            netdriver.NetworkHandler
+	cacheCreator       cacheCreator
+	dhcpConfigurator   dhcpconfigurator.Configurator
+	domainGenerator    domainspec.LibvirtSpecGenerator
+	serviceDomainInfix string
 }
 
-func newPhase2PodNIC(vmi *v1.VirtualMachineInstance, network *v1.Network, iface *v1.Interface, handler netdriver.NetworkHandler, cacheCreator cacheCreator, domain *api.Domain, domainAttachment string) (*podNIC, error) {
+func newPhase2PodNIC(vmi *v1.VirtualMachineInstance, network *v1.Network, iface *v1.Interface, handler netdriver.NetworkHandler, cacheCreator cacheCreator, domain *api.Domain, domainAttachment, serviceDomainInfix string) (*podNIC, error) {
 	podnic, err := newPodNIC(vmi, network, iface, handler, cacheCreator, nil)
 	if err != nil {
 		return nil, err
 	}
+	podnic.serviceDomainInfix = serviceDomainInfix
 
 	ifaceLink, err := link.DiscoverByNetwork(podnic.handler, podnic.vmi.Spec.Networks, *podnic.vmiSpecNetwork)
 	if err != nil {
@@ -122,7 +124,8 @@ func (l *podNIC) newDHCPConfigurator() dhcpconfigurator.Configurator {
 			l.podInterfaceName,
 			l.vmi.Spec.Domain.Devices.Interfaces,
 			l.vmiSpecIface,
-			l.vmi.Spec.Subdomain)
+			l.vmi.Spec.Subdomain,
+			l.serviceDomainInfix)
 	} else if l.vmiSpecIface.Masquerade != nil {
 		dhcpConfigurator = dhcpconfigurator.NewMasqueradeConfigurator(
 			link.GenerateBridgeName(l.podInterfaceName),
@@ -130,7 +133,8 @@ func (l *podNIC) newDHCPConfigurator() dhcpconfigurator.Configurator {
 			l.vmiSpecIface,
 			l.vmiSpecNetwork,
 			l.podInterfaceName,
-			l.vmi.Spec.Subdomain)
+			l.vmi.Spec.Subdomain,
+			l.serviceDomainInfix)
 	}
 	return dhcpConfigurator
 }
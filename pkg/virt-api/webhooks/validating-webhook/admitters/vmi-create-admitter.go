@@ -119,6 +119,7 @@ func (admitter *VMICreateAdmitter) Admit(_ context.Context, ar *admissionv1.Admi
 
 	netValidator := netadmitter.NewValidator(k8sfield.NewPath("spec"), &vmi.Spec, admitter.ClusterConfig)
 	causes = append(causes, netValidator.ValidateCreation()...)
+	causes = append(causes, netadmitter.ValidateIstioPorts(k8sfield.NewPath("spec"), vmi)...)
 
 	causes = append(causes, ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, admitter.ClusterConfig)...)
 	// We only want to validate that volumes are mapped to disks or filesystems during VMI admittance, thus this logic is seperated from the above call that is shared with the VM admitter.
@@ -1782,6 +1783,28 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			}
 		}
 
+		if emptyDisk := volume.EmptyDisk; emptyDisk != nil {
+			switch emptyDisk.Format {
+			case "", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskFormatRaw:
+			default:
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s' or '%s'", field.Index(idx).Child("emptyDisk", "format").String(), emptyDisk.Format, v1.EmptyDiskFormatQCOW2, v1.EmptyDiskFormatRaw),
+					Field:   field.Index(idx).Child("emptyDisk", "format").String(),
+				})
+			}
+
+			switch emptyDisk.Preallocation {
+			case "", v1.EmptyDiskPreallocationOff, v1.EmptyDiskPreallocationFalloc, v1.EmptyDiskPreallocationFull:
+			default:
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s', '%s' or '%s'", field.Index(idx).Child("emptyDisk", "preallocation").String(), emptyDisk.Preallocation, v1.EmptyDiskPreallocationOff, v1.EmptyDiskPreallocationFalloc, v1.EmptyDiskPreallocationFull),
+					Field:   field.Index(idx).Child("emptyDisk", "preallocation").String(),
+				})
+			}
+		}
+
 		if volume.ConfigMap != nil {
 			if volume.ConfigMap.LocalObjectReference.Name == "" {
 				causes = append(causes, metav1.StatusCause{
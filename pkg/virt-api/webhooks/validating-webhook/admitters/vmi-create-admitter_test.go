@@ -2609,6 +2609,34 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(BeEmpty())
 		})
 
+		DescribeTable("should validate the emptyDisk format and preallocation", func(format v1.EmptyDiskFormat, preallocation v1.EmptyDiskPreallocation, expectedFields ...string) {
+			vmi := api.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testEmptyDisk",
+				VolumeSource: v1.VolumeSource{
+					EmptyDisk: &v1.EmptyDiskSource{
+						Capacity:      resource.MustParse("1Gi"),
+						Format:        format,
+						Preallocation: preallocation,
+					},
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			Entry("should accept the defaults", v1.EmptyDiskFormat(""), v1.EmptyDiskPreallocation("")),
+			Entry("should accept qcow2 with falloc", v1.EmptyDiskFormatQCOW2, v1.EmptyDiskPreallocationFalloc),
+			Entry("should accept raw with full", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationFull),
+			Entry("should accept raw with off", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocationOff),
+			Entry("should reject an unknown format", v1.EmptyDiskFormat("vmdk"), v1.EmptyDiskPreallocation(""), "fake[0].emptyDisk.format"),
+			Entry("should reject an unknown preallocation", v1.EmptyDiskFormatRaw, v1.EmptyDiskPreallocation("metadata"), "fake[0].emptyDisk.preallocation"),
+			Entry("should reject both", v1.EmptyDiskFormat("vmdk"), v1.EmptyDiskPreallocation("metadata"), "fake[0].emptyDisk.format", "fake[0].emptyDisk.preallocation"),
+		)
+
 		It("should accept sysprep volumes", func() {
 			vmi := api.NewMinimalVMI("fake-vmi")
 			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
@@ -427,6 +427,8 @@ func ValidateVirtualMachineSpec(field *k8sfield.Path, spec *v1.VirtualMachineSpe
 
 	causes = append(causes, ValidateVirtualMachineInstanceMetadata(field.Child("template", "metadata"), &spec.Template.ObjectMeta, config, accountName)...)
 	causes = append(causes, ValidateVirtualMachineInstanceSpec(field.Child("template", "spec"), &spec.Template.Spec, config)...)
+	templateVMI := &v1.VirtualMachineInstance{ObjectMeta: spec.Template.ObjectMeta, Spec: spec.Template.Spec}
+	causes = append(causes, netadmitter.ValidateIstioPorts(field.Child("template", "spec"), templateVMI)...)
 
 	causes = append(causes, validateDataVolumeTemplate(field, spec)...)
 	causes = append(causes, validateRunStrategy(field, spec)...)
@@ -52,7 +52,9 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 
 	"kubevirt.io/kubevirt/pkg/instancetype"
+	"kubevirt.io/kubevirt/pkg/libvmi"
 	"kubevirt.io/kubevirt/pkg/liveupdate/memory"
+	"kubevirt.io/kubevirt/pkg/network/istio"
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -317,6 +319,38 @@ var _ = Describe("Validating VM Admitter", func() {
 		Expect(resp.Allowed).To(BeTrue())
 	})
 
+	Context("with the Istio proxy injected", func() {
+		newVM := func(ports ...v1.Port) *v1.VirtualMachine {
+			return libvmi.NewVirtualMachine(libvmi.New(
+				libvmi.WithInterface(libvmi.InterfaceDeviceWithMasqueradeBinding(ports...)),
+				libvmi.WithNetwork(v1.DefaultPodNetwork()),
+				libvmi.WithAnnotation(istio.InjectSidecarAnnotation, "true"),
+			))
+		}
+
+		DescribeTable("should reject a VM template with", func(port v1.Port, expectedCause metav1.StatusCause) {
+			resp := admitVm(vmsAdmitter, newVM(v1.Port{Port: 80}, port))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(ConsistOf(expectedCause))
+		},
+			Entry("a port reserved by the proxy", v1.Port{Port: istio.EnvoyOutboundPort}, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "port 15001 of interface default is reserved by the Istio proxy",
+				Field:   "spec.template.spec.domain.devices.interfaces[0].ports[1].port",
+			}),
+			Entry("an SCTP port", v1.Port{Port: 9000, Protocol: "SCTP"}, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: "port 9000 of interface default uses SCTP, which is not supported with the Istio proxy",
+				Field:   "spec.template.spec.domain.devices.interfaces[0].ports[1].protocol",
+			}),
+		)
+
+		It("should accept a VM template with unreserved ports", func() {
+			resp := admitVm(vmsAdmitter, newVM(v1.Port{Port: 80}, v1.Port{Port: 8080}))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+
 	DescribeTable("should reject VolumeRequests on a migrating vm", func(requests []v1.VirtualMachineVolumeRequest) {
 		now := metav1.Now()
 		vmi := api.NewMinimalVMI("testvmi")
@@ -426,6 +426,10 @@ func (c *ClusterConfig) GetKSMConfiguration() *v1.KSMConfiguration {
 	return c.GetConfig().KSMConfiguration
 }
 
+func (c *ClusterConfig) GetMetricsConfiguration() *v1.MetricsConfiguration {
+	return c.GetConfig().MetricsConfiguration
+}
+
 func (c *ClusterConfig) GetMaximumCpuSockets() (numOfSockets uint32) {
 	liveConfig := c.GetConfig().LiveUpdateConfiguration
 	if liveConfig != nil && liveConfig.MaxCpuSockets != nil {
@@ -461,6 +465,14 @@ func (c *ClusterConfig) IsVMRolloutStrategyLiveUpdate() bool {
 	return liveConfig != nil && *liveConfig == v1.VMRolloutStrategyLiveUpdate
 }
 
+// GetServiceDomainInfix returns the infix identifying the service search domains, empty when the default is used
+func (c *ClusterConfig) GetServiceDomainInfix() string {
+	if networkConfig := c.GetConfig().NetworkConfiguration; networkConfig != nil {
+		return networkConfig.ServiceDomainInfix
+	}
+	return ""
+}
+
 func (c *ClusterConfig) GetNetworkBindings() map[string]v1.InterfaceBindingPlugin {
 	networkConfig := c.GetConfig().NetworkConfiguration
 	if networkConfig != nil {
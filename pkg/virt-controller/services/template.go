@@ -84,6 +84,13 @@ const virtiofsDebugLogs = "virtiofsdDebugLogs"
 
 const qemuTimeoutJitterRange = 120
 
+const (
+	// Seconds added to the vmi grace period for virt-launcher, and again for the pod
+	launcherGracePeriodPadding = 15
+	// Seconds left to virt-launcher to report the killed vmi process before the pod is killed
+	launcherKillGracePeriodMargin = 5
+)
+
 const (
 	CAP_NET_BIND_SERVICE = "NET_BIND_SERVICE"
 	CAP_SYS_NICE         = "SYS_NICE"
@@ -346,8 +353,9 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, i
 	// Ideally we want virt-handler to handle tearing down
 	// the vmi without virt-launcher's termination forcing
 	// the vmi down.
-	gracePeriodSeconds = gracePeriodSeconds + int64(15)
-	gracePeriodKillAfter := gracePeriodSeconds + int64(15)
+	gracePeriodSeconds = gracePeriodSeconds + int64(launcherGracePeriodPadding)
+	gracePeriodKillAfter := gracePeriodSeconds + int64(launcherGracePeriodPadding)
+	killGracePeriodSeconds := killGracePeriodInSeconds(vmi)
 
 	networkToResourceMap, err := network.GetNetworkToResourceMap(t.virtClient, vmi)
 	if err != nil {
@@ -387,6 +395,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, i
 			"--ephemeral-disk-dir", t.ephemeralDiskDir,
 			"--container-disk-dir", t.containerDiskDir,
 			"--grace-period-seconds", strconv.Itoa(int(gracePeriodSeconds)),
+			"--kill-grace-period-seconds", strconv.Itoa(int(killGracePeriodSeconds)),
 			"--hook-sidecars", strconv.Itoa(len(requestedHookSidecarList)),
 			"--ovmf-path", ovmfPath,
 		}
@@ -848,6 +857,13 @@ func gracePeriodInSeconds(vmi *v1.VirtualMachineInstance) int64 {
 	return v1.DefaultGracePeriodSeconds
 }
 
+// killGracePeriodInSeconds returns how long virt-launcher waits after its grace period before
+// sending SIGKILL to the vmi process. It fits in the padding of the pod grace period, so that
+// the vmi process gets killed before the pod is.
+func killGracePeriodInSeconds(vmi *v1.VirtualMachineInstance) int64 {
+	return min(gracePeriodInSeconds(vmi), int64(launcherGracePeriodPadding-launcherKillGracePeriodMargin))
+}
+
 func sidecarContainerName(i int) string {
 	return fmt.Sprintf("hook-sidecar-%d", i)
 }
@@ -451,6 +451,7 @@ var _ = Describe("Template", func() {
 					"--ephemeral-disk-dir", "/var/run/kubevirt-ephemeral-disks",
 					"--container-disk-dir", "/var/run/kubevirt/container-disks",
 					"--grace-period-seconds", "45",
+					"--kill-grace-period-seconds", "10",
 					"--hook-sidecars", "1",
 					"--ovmf-path", ovmfPath}))
 				Expect(pod.Spec.Containers[1].Name).To(Equal("hook-sidecar-0"))
@@ -1028,6 +1029,7 @@ var _ = Describe("Template", func() {
 					"--ephemeral-disk-dir", "/var/run/kubevirt-ephemeral-disks",
 					"--container-disk-dir", "/var/run/kubevirt/container-disks",
 					"--grace-period-seconds", "45",
+					"--kill-grace-period-seconds", "10",
 					"--hook-sidecars", "1",
 					"--ovmf-path", ovmfPath}))
 				Expect(pod.Spec.Containers[1].Name).To(Equal("hook-sidecar-0"))
@@ -1052,6 +1054,23 @@ var _ = Describe("Template", func() {
 				Entry("on arm64", "arm64", "/usr/share/AAVMF"),
 			)
 
+			DescribeTable("should kill the vmi process before the pod grace period expires", func(vmiGracePeriod int64, expectedKillGracePeriod string) {
+				config, kvStore, svc = configFactory(defaultArch)
+				vmi := libvmi.New(libvmi.WithNamespace("default"), libvmi.WithTerminationGracePeriod(vmiGracePeriod))
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Command).To(ContainElements(
+					"--grace-period-seconds", strconv.FormatInt(vmiGracePeriod+15, 10),
+					"--kill-grace-period-seconds", expectedKillGracePeriod,
+				))
+				Expect(*pod.Spec.TerminationGracePeriodSeconds).To(Equal(vmiGracePeriod + 30))
+			},
+				Entry("with a short vmi grace period", int64(3), "3"),
+				Entry("with a long vmi grace period", int64(180), "10"),
+			)
+
 			It("should add node selector for node discovery feature to template", func() {
 				config, kvStore, svc = configFactory(defaultArch)
 				vmiCpuModel := "Conroe"
@@ -695,6 +695,7 @@ var _ = Describe("Pool", func() {
 			expectControllerRevisionCreation(poolRevision)
 
 			controller.Execute()
+			testutils.ExpectEvent(recorder, virtcontroller.ReleasedReason)
 			testutils.ExpectEvent(recorder, common.SuccessfulCreateVirtualMachineReason)
 			testutils.ExpectEvent(recorder, common.SuccessfulCreateVirtualMachineReason)
 			testutils.ExpectEvent(recorder, common.SuccessfulCreateVirtualMachineReason)
@@ -729,6 +729,13 @@ func (c *Controller) handleCPUChangeRequest(vm *virtv1.VirtualMachine, vmi *virt
 		return nil
 	}
 
+	for _, iface := range vmCopyWithInstancetype.Spec.Template.Spec.Domain.Devices.Interfaces {
+		if iface.MultiQueue != nil && *iface.MultiQueue && iface.Queues == nil {
+			setRestartRequired(vm, fmt.Sprintf("Changes to CPU sockets require a restart when MultiQueue is enabled on interface %s", iface.Name))
+			return nil
+		}
+	}
+
 	if err := c.VMICPUsPatch(vmCopyWithInstancetype, vmi); err != nil {
 		log.Log.Object(vmi).Errorf("unable to patch vmi to add cpu topology status: %v", err)
 		return err
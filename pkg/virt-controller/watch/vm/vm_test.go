@@ -5869,6 +5869,34 @@ var _ = Describe("VirtualMachine", func() {
 						"Status":  Equal(k8sv1.ConditionTrue),
 					}))
 				})
+
+				It("should set a restartRequired condition if MultiQueue is enabled on an interface", func() {
+					vm, _ := watchtesting.DefaultVirtualMachine(true)
+					vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{
+						Sockets: 2,
+					}
+					vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+						Name:       "default",
+						MultiQueue: pointer.P(true),
+					}}
+
+					vmi := api.NewMinimalVMI(vm.Name)
+					vmi.Spec.Domain.CPU = &v1.CPU{
+						Sockets:    1,
+						MaxSockets: 4,
+					}
+
+					Expect(controller.handleCPUChangeRequest(vm, vmi)).To(Succeed())
+
+					vmCondManager := virtcontroller.NewVirtualMachineConditionManager()
+					cond := vmCondManager.GetCondition(vm, v1.VirtualMachineRestartRequired)
+					Expect(cond).To(Not(BeNil()))
+					Expect(*cond).To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+						"Type":    Equal(v1.VirtualMachineRestartRequired),
+						"Message": ContainSubstring("when MultiQueue is enabled on interface default"),
+						"Status":  Equal(k8sv1.ConditionTrue),
+					}))
+				})
 			})
 
 			Context("Memory", func() {
@@ -0,0 +1,116 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package virthandler
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"libvirt.org/go/libvirtxml"
+
+	"kubevirt.io/client-go/log"
+)
+
+type capabilitiesProvider interface {
+	GetCapabilities() *libvirtxml.Caps
+}
+
+func (d *VirtualMachineController) hostCapabilities() *libvirtxml.Caps {
+	if d.capabilities == nil {
+		return nil
+	}
+	return d.capabilities.GetCapabilities()
+}
+
+// CapabilitiesCache holds the host capabilities written by the node-labeller.
+// The capabilities file is parsed once and parsed again only when it is rewritten,
+// which is detected through its modification time and size.
+type CapabilitiesCache struct {
+	path string
+	load func(path string) (*libvirtxml.Caps, error)
+
+	lock         sync.Mutex
+	capabilities *libvirtxml.Caps
+	modTime      time.Time
+	size         int64
+}
+
+// NewCapabilitiesCache creates a cache for the capabilities file at path and loads it
+func NewCapabilitiesCache(path string) (*CapabilitiesCache, error) {
+	return newCapabilitiesCache(path, loadCapabilities)
+}
+
+func newCapabilitiesCache(path string, load func(path string) (*libvirtxml.Caps, error)) (*CapabilitiesCache, error) {
+	c := &CapabilitiesCache{
+		path: path,
+		load: load,
+	}
+
+	if err := c.refresh(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetCapabilities returns the host capabilities, reloading them if the file has been rewritten.
+// If the file can't be reloaded, the last known capabilities are returned.
+func (c *CapabilitiesCache) GetCapabilities() *libvirtxml.Caps {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.refresh(); err != nil {
+		log.Log.Reason(err).Warningf("Failed to reload the host capabilities, using the cached ones")
+	}
+	return c.capabilities
+}
+
+func (c *CapabilitiesCache) refresh() error {
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return err
+	}
+	if c.capabilities != nil && info.ModTime().Equal(c.modTime) && info.Size() == c.size {
+		return nil
+	}
+
+	capabilities, err := c.load(c.path)
+	if err != nil {
+		return err
+	}
+	c.capabilities = capabilities
+	c.modTime = info.ModTime()
+	c.size = info.Size()
+	return nil
+}
+
+func loadCapabilities(path string) (*libvirtxml.Caps, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := &libvirtxml.Caps{}
+	if err := capabilities.Unmarshal(string(content)); err != nil {
+		return nil, fmt.Errorf("failed to parse the host capabilities %s: %v", path, err)
+	}
+	return capabilities, nil
+}
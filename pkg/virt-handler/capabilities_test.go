@@ -0,0 +1,125 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package virthandler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"libvirt.org/go/libvirtxml"
+)
+
+var _ = Describe("Capabilities cache", func() {
+	const (
+		capabilitiesTemplate = `<capabilities>
+  <host>
+    <uuid>%s</uuid>
+    <topology>
+      <cells num="1">
+        <cell id="0">
+          <memory unit="KiB">16256896</memory>
+          <pages unit="KiB" size="4">4064224</pages>
+          <distances>
+            <sibling id="0" value="10"/>
+          </distances>
+          <cpus num="2">
+            <cpu id="0" socket_id="0" core_id="0" siblings="0"/>
+            <cpu id="1" socket_id="0" core_id="1" siblings="1"/>
+          </cpus>
+        </cell>
+      </cells>
+    </topology>
+  </host>
+</capabilities>`
+	)
+
+	capabilitiesV1 := fmt.Sprintf(capabilitiesTemplate, "host-1")
+	capabilitiesV2 := fmt.Sprintf(capabilitiesTemplate, "host-2")
+
+	var (
+		capabilitiesPath string
+		parses           int
+	)
+
+	countingLoad := func(path string) (*libvirtxml.Caps, error) {
+		parses++
+		return loadCapabilities(path)
+	}
+
+	writeCapabilities := func(content string, modTime time.Time) {
+		Expect(os.WriteFile(capabilitiesPath, []byte(content), 0644)).To(Succeed())
+		Expect(os.Chtimes(capabilitiesPath, modTime, modTime)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		capabilitiesPath = filepath.Join(GinkgoT().TempDir(), "capabilities.xml")
+		parses = 0
+	})
+
+	It("should parse the capabilities once for many option builds", func() {
+		writeCapabilities(capabilitiesV1, time.Now())
+		cache, err := newCapabilitiesCache(capabilitiesPath, countingLoad)
+		Expect(err).ToNot(HaveOccurred())
+
+		const optionBuilds = 1000
+		for i := 0; i < optionBuilds; i++ {
+			options := virtualMachineOptions(nil, 0, nil, cache.GetCapabilities(), nil, nil)
+			Expect(options.Topology.NumaCells).To(HaveLen(1))
+		}
+
+		Expect(parses).To(Equal(1))
+		Expect(cache.GetCapabilities().Host.UUID).To(Equal("host-1"))
+	})
+
+	It("should parse the capabilities again when the file is rewritten", func() {
+		modTime := time.Now().Add(-time.Minute)
+		writeCapabilities(capabilitiesV1, modTime)
+		cache, err := newCapabilitiesCache(capabilitiesPath, countingLoad)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cache.GetCapabilities().Host.UUID).To(Equal("host-1"))
+
+		writeCapabilities(capabilitiesV2, modTime.Add(time.Second))
+
+		Expect(cache.GetCapabilities().Host.UUID).To(Equal("host-2"))
+		Expect(cache.GetCapabilities().Host.UUID).To(Equal("host-2"))
+		Expect(parses).To(Equal(2))
+	})
+
+	It("should keep serving the cached capabilities when the file can't be reloaded", func() {
+		writeCapabilities(capabilitiesV1, time.Now().Add(-time.Minute))
+		cache, err := newCapabilitiesCache(capabilitiesPath, countingLoad)
+		Expect(err).ToNot(HaveOccurred())
+
+		writeCapabilities("<capabilities", time.Now())
+		Expect(cache.GetCapabilities().Host.UUID).To(Equal("host-1"))
+
+		Expect(os.Remove(capabilitiesPath)).To(Succeed())
+		Expect(cache.GetCapabilities().Host.UUID).To(Equal("host-1"))
+	})
+
+	It("should fail to create the cache when the capabilities can't be loaded", func() {
+		_, err := NewCapabilitiesCache(capabilitiesPath)
+		Expect(err).To(HaveOccurred())
+	})
+})
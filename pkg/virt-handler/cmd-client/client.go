@@ -67,6 +67,7 @@ var (
 const StandardLauncherSocketFileName = "launcher-sock"
 const StandardInitLauncherSocketFileName = "launcher-init-sock"
 const StandardLauncherUnresponsiveFileName = "launcher-unresponsive"
+const StandardLauncherHeartbeatFileName = "launcher-heartbeat"
 
 const MultiThreadedQemuMigrationAnnotation = "kubevirt.io/multiThreadedQemuMigration"
 
@@ -80,6 +81,22 @@ type MigrationOptions struct {
 	ParallelMigrationThreads *uint
 }
 
+// LaunchInfo describes the qemu process and the network backends a
+// virt-launcher started its domain with. It is meant for debugging only.
+type LaunchInfo struct {
+	QemuPid    int                   `json:"qemuPid,omitempty"`
+	DomainUUID string                `json:"domainUUID,omitempty"`
+	Interfaces []LaunchInterfaceInfo `json:"interfaces,omitempty"`
+}
+
+type LaunchInterfaceInfo struct {
+	Name    string `json:"name"`
+	Type    string `json:"type,omitempty"`
+	Tap     string `json:"tap,omitempty"`
+	Bridge  string `json:"bridge,omitempty"`
+	Backend string `json:"backend,omitempty"`
+}
+
 type LauncherClient interface {
 	SyncVirtualMachine(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
 	PauseVirtualMachine(vmi *v1.VirtualMachineInstance) error
@@ -112,6 +129,7 @@ type LauncherClient interface {
 	GetLaunchMeasurement(*v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error)
 	InjectLaunchSecret(*v1.VirtualMachineInstance, *v1.SEVSecretOptions) error
 	SyncVirtualMachineMemory(vmi *v1.VirtualMachineInstance, options *cmdv1.VirtualMachineOptions) error
+	GetLaunchInfo() (*LaunchInfo, error)
 }
 
 type VirtLauncherClient struct {
@@ -744,6 +762,25 @@ func (c *VirtLauncherClient) GetSEVInfo() (*v1.SEVPlatformInfo, error) {
 	return sevPlatformInfo, nil
 }
 
+func (c *VirtLauncherClient) GetLaunchInfo() (*LaunchInfo, error) {
+	request := &cmdv1.EmptyRequest{}
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+
+	launchInfoResponse, err := c.v1client.GetLaunchInfo(ctx, request)
+	if err = handleError(err, "GetLaunchInfo", launchInfoResponse.GetResponse()); err != nil {
+		return nil, err
+	}
+
+	launchInfo := &LaunchInfo{}
+	if err := json.Unmarshal(launchInfoResponse.GetLaunchInfo(), launchInfo); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling launch info response")
+		return nil, err
+	}
+
+	return launchInfo, nil
+}
+
 func (c *VirtLauncherClient) GetLaunchMeasurement(vmi *v1.VirtualMachineInstance) (*v1.SEVMeasurementInfo, error) {
 	vmiJson, err := json.Marshal(vmi)
 	if err != nil {
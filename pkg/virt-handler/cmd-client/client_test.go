@@ -174,6 +174,26 @@ var _ = Describe("Virt remote commands", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(qemuVersion).To(Equal(fakeQemuVersion))
 			})
+			It("calls cmdclient.GetLaunchInfo", func() {
+				mockCmdClient.EXPECT().GetLaunchInfo(gomock.Any(), &cmdv1.EmptyRequest{}).Return(&cmdv1.LaunchInfoResponse{
+					Response:   &cmdv1.Response{Success: true},
+					LaunchInfo: []byte(`{"qemuPid":1234,"domainUUID":"5dbb6ad7-aa02-4cb8-8e5b-e24d2fd1b50b","interfaces":[{"name":"default","type":"ethernet","tap":"tap0"}]}`),
+				}, nil)
+				launchInfo, err := client.GetLaunchInfo()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(launchInfo).To(Equal(&LaunchInfo{
+					QemuPid:    1234,
+					DomainUUID: "5dbb6ad7-aa02-4cb8-8e5b-e24d2fd1b50b",
+					Interfaces: []LaunchInterfaceInfo{{Name: "default", Type: "ethernet", Tap: "tap0"}},
+				}))
+			})
+			It("returns the server error of cmdclient.GetLaunchInfo", func() {
+				mockCmdClient.EXPECT().GetLaunchInfo(gomock.Any(), &cmdv1.EmptyRequest{}).Return(&cmdv1.LaunchInfoResponse{
+					Response: &cmdv1.Response{Success: false, Message: "connection lost"},
+				}, nil)
+				_, err := client.GetLaunchInfo()
+				Expect(err).To(MatchError(ContainSubstring("connection lost")))
+			})
 			It("calls cmdclient.Exec", func() {
 				expectExec().Times(1)
 				client.Exec(testDomainName, testCommand, testArgs, testTimeoutSeconds)
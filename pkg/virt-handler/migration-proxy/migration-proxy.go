@@ -21,6 +21,7 @@ package migrationproxy
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -29,6 +30,8 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"kubevirt.io/client-go/log"
 
@@ -57,8 +60,36 @@ type ProxyManager interface {
 	OpenListenerCount() int
 
 	InitiateGracefulShutdown()
+
+	SetConnectionObserver(observer ConnectionObserver)
 }
 
+const (
+	// DirectionInbound is the traffic from the outbound leg back to the peer which connected to the proxy
+	DirectionInbound = "inbound"
+	// DirectionOutbound is the traffic from the peer which connected to the proxy to the outbound leg
+	DirectionOutbound = "outbound"
+)
+
+// ConnectionObserver gets notified about the connections the proxies handle,
+// e.g. to expose them as metrics.
+type ConnectionObserver interface {
+	ConnectionOpened()
+	ConnectionClosed()
+	BytesCopied(direction string, bytes int)
+	ConnectionFailed()
+	// Reconnected is called when a proxy accepts a connection after an earlier one of it failed
+	Reconnected()
+}
+
+type noopConnectionObserver struct{}
+
+func (noopConnectionObserver) ConnectionOpened()       {}
+func (noopConnectionObserver) ConnectionClosed()       {}
+func (noopConnectionObserver) BytesCopied(string, int) {}
+func (noopConnectionObserver) ConnectionFailed()       {}
+func (noopConnectionObserver) Reconnected()            {}
+
 type migrationProxyManager struct {
 	sourceProxies   map[string][]*migrationProxy
 	targetProxies   map[string][]*migrationProxy
@@ -68,6 +99,7 @@ type migrationProxyManager struct {
 
 	isShuttingDown bool
 	config         *virtconfig.ClusterConfig
+	observer       ConnectionObserver
 }
 
 type MigrationProxyListener interface {
@@ -89,7 +121,10 @@ type migrationProxy struct {
 	serverTLSConfig *tls.Config
 	clientTLSConfig *tls.Config
 
-	logger *log.FilteredLogger
+	observer ConnectionObserver
+	// lastConnectionFailed tells whether the last connection failed, the next one is a reconnection
+	lastConnectionFailed atomic.Bool
+	logger               *log.FilteredLogger
 }
 
 func (m *migrationProxyManager) InitiateGracefulShutdown() {
@@ -99,6 +134,15 @@ func (m *migrationProxyManager) InitiateGracefulShutdown() {
 	m.isShuttingDown = true
 }
 
+// SetConnectionObserver sets the observer of the connections of all proxies
+// which are started afterwards.
+func (m *migrationProxyManager) SetConnectionObserver(observer ConnectionObserver) {
+	m.managerLock.Lock()
+	defer m.managerLock.Unlock()
+
+	m.observer = observer
+}
+
 func (m *migrationProxyManager) OpenListenerCount() int {
 	m.managerLock.Lock()
 	defer m.managerLock.Unlock()
@@ -121,6 +165,7 @@ func NewMigrationProxyManager(serverTLSConfig *tls.Config, clientTLSConfig *tls.
 		serverTLSConfig: serverTLSConfig,
 		clientTLSConfig: clientTLSConfig,
 		config:          config,
+		observer:        noopConnectionObserver{},
 	}
 }
 
@@ -178,6 +223,7 @@ func (m *migrationProxyManager) StartTargetListener(key string, targetUnixFiles
 	for _, targetUnixFile := range targetUnixFiles {
 		// 0 means random port is used
 		proxy := NewTargetProxy(zeroAddress, 0, serverTLSConfig, clientTLSConfig, targetUnixFile, key)
+		proxy.observer = m.observer
 
 		err := proxy.Start()
 		if err != nil {
@@ -308,9 +354,8 @@ func (m *migrationProxyManager) StartSourceListener(key string, targetAddress st
 		targetFullAddr := net.JoinHostPort(targetAddress, destPort)
 		filePath := SourceUnixFile(baseDir, proxyKey)
 
-		os.RemoveAll(filePath)
-
 		proxy := NewSourceProxy(filePath, targetFullAddr, serverTLSConfig, clientTLSConfig, key)
+		proxy.observer = m.observer
 
 		err := proxy.Start()
 		if err != nil {
@@ -355,7 +400,8 @@ func NewSourceProxy(unixSocketPath string, tcpTargetAddress string, serverTLSCon
 		fdChan:          make(chan net.Conn, 1),
 		listenErrChan:   make(chan error, 1),
 		serverTLSConfig: serverTLSConfig,
-		clientTLSConfig: clientTLSConfig,
+		clientTLSConfig: sourceTLSConfigForMigration(clientTLSConfig, vmiUID),
+		observer:        noopConnectionObserver{},
 		logger:          log.Log.With("uid", vmiUID).With("listening", filepath.Base(unixSocketPath)).With("outbound", tcpTargetAddress),
 	}
 }
@@ -370,13 +416,49 @@ func NewTargetProxy(tcpBindAddress string, tcpBindPort int, serverTLSConfig *tls
 		stopChan:        make(chan struct{}),
 		fdChan:          make(chan net.Conn, 1),
 		listenErrChan:   make(chan error, 1),
-		serverTLSConfig: serverTLSConfig,
+		serverTLSConfig: targetTLSConfigForMigration(serverTLSConfig, vmiUID),
 		clientTLSConfig: clientTLSConfig,
+		observer:        noopConnectionObserver{},
 		logger:          log.Log.With("uid", vmiUID).With("outbound", filepath.Base(virtqemudSocketPath)),
 	}
 
 }
 
+// sourceTLSConfigForMigration makes the source proxy announce the migration
+// it belongs to as server name, see targetTLSConfigForMigration.
+func sourceTLSConfigForMigration(config *tls.Config, key string) *tls.Config {
+	if config == nil {
+		return nil
+	}
+	config = config.Clone()
+	config.ServerName = key
+	return config
+}
+
+// targetTLSConfigForMigration keeps source proxies from connecting to the
+// listener of another migration, e.g. when its port got reused. This is no
+// authentication, the client chooses the server name itself and the
+// certificates of virt-handler are issued per node. Handshakes without a
+// server name come from source virt-handlers which predate it and are
+// accepted, so that migrations keep working during an upgrade.
+func targetTLSConfigForMigration(config *tls.Config, key string) *tls.Config {
+	if config == nil {
+		return nil
+	}
+	getConfigForClient := config.GetConfigForClient
+	config = config.Clone()
+	config.GetConfigForClient = func(info *tls.ClientHelloInfo) (*tls.Config, error) {
+		if info.ServerName != "" && info.ServerName != key {
+			return nil, fmt.Errorf("refusing connection for migration %s on the proxy of migration %s", info.ServerName, key)
+		}
+		if getConfigForClient != nil {
+			return getConfigForClient(info)
+		}
+		return nil, nil
+	}
+	return config
+}
+
 func (m *migrationProxy) createTcpListener() error {
 	var listener net.Listener
 	var err error
@@ -403,9 +485,27 @@ func (m *migrationProxy) createTcpListener() error {
 	return nil
 }
 
+// removeStaleUnixSocket removes a socket file which was left behind, e.g. by
+// a migration which was not cleaned up or by a previous virt-handler. A socket
+// which still accepts connections belongs to a live listener and is kept.
+func removeStaleUnixSocket(path string) error {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("unix socket %s is still in use by a live listener", path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
 func (m *migrationProxy) createUnixListener() error {
 
-	os.RemoveAll(m.unixSocketPath)
+	if err := removeStaleUnixSocket(m.unixSocketPath); err != nil {
+		m.logger.Reason(err).Error("unable to remove stale unix socket for proxy service")
+		return err
+	}
 	err := util.MkdirAllWithNosec(filepath.Dir(m.unixSocketPath))
 	if err != nil {
 		m.logger.Reason(err).Error("unable to create directory for unix socket")
@@ -436,12 +536,63 @@ func (m *migrationProxy) Stop() {
 	}
 }
 
+// copyChunkSize is the amount of bytes copied between two reports of the progress of a connection
+const copyChunkSize = 1 << 20
+
+// copyCounting copies from src to dst like io.Copy and reports the copied bytes after every chunk,
+// so that long running migrations show progress before their connection ends. io.CopyN keeps the
+// splice fast path between sockets: the reader it hands to the writer's ReadFrom is an io.LimitedReader,
+// which the net package unwraps, unlike a wrapper counting in Read or Write.
+func (m *migrationProxy) copyCounting(dst io.Writer, src io.Reader, direction string) (int64, error) {
+	var written int64
+	for {
+		n, err := io.CopyN(dst, src, copyChunkSize)
+		written += n
+		if n > 0 {
+			m.observer.BytesCopied(direction, int(n))
+		}
+		if err == io.EOF {
+			return written, nil
+		} else if err != nil {
+			return written, err
+		}
+	}
+}
+
+func (m *migrationProxy) connectionFailed() {
+	m.lastConnectionFailed.Store(true)
+	m.observer.ConnectionFailed()
+}
+
+func peerAddress(conn net.Conn) string {
+	if addr := conn.RemoteAddr(); addr != nil && addr.String() != "" {
+		return addr.String()
+	}
+	return conn.LocalAddr().String()
+}
+
 func (m *migrationProxy) handleConnection(fd net.Conn) {
 	defer fd.Close()
 
+	start := time.Now()
+	logger := m.logger.With("peer", peerAddress(fd))
+	if m.lastConnectionFailed.Swap(false) {
+		m.observer.Reconnected()
+	}
+
 	outBoundErr := make(chan error, 1)
 	inBoundErr := make(chan error, 1)
 
+	// complete the handshake of inbound TLS connections before the outbound
+	// leg is opened, so that refused peers never reach the target
+	if tlsConn, ok := fd.(*tls.Conn); ok {
+		if err := tlsConn.Handshake(); err != nil {
+			m.connectionFailed()
+			logger.Reason(err).Error("refusing inbound connection, the TLS handshake failed")
+			return
+		}
+	}
+
 	var conn net.Conn
 	var err error
 	if m.targetProtocol == "tcp" && m.clientTLSConfig != nil {
@@ -450,34 +601,46 @@ func (m *migrationProxy) handleConnection(fd net.Conn) {
 		conn, err = net.Dial(m.targetProtocol, m.targetAddress)
 	}
 	if err != nil {
-		m.logger.Reason(err).Error("unable to create outbound leg of proxy to host")
+		m.connectionFailed()
+		logger.Reason(err).Error("unable to create outbound leg of proxy to host")
 		return
 	}
+	// stops the copy of the other direction once one ends
+	defer conn.Close()
+
+	m.observer.ConnectionOpened()
+	logger.Info("proxy connection opened")
+	defer func() {
+		m.observer.ConnectionClosed()
+		logger.With("duration", time.Since(start).String()).Info("proxy connection closed")
+	}()
 
 	go func() {
 		//from outbound connection to proxy
-		n, err := io.Copy(fd, conn)
-		m.logger.Infof("%d bytes copied outbound to inbound", n)
+		n, err := m.copyCounting(fd, conn, DirectionInbound)
+		logger.Infof("%d bytes copied outbound to inbound", n)
 		inBoundErr <- err
 	}()
 	go func() {
 		//from proxy to outbound connection
-		n, err := io.Copy(conn, fd)
-		m.logger.Infof("%d bytes copied from inbound to outbound", n)
+		n, err := m.copyCounting(conn, fd, DirectionOutbound)
+		logger.Infof("%d bytes copied from inbound to outbound", n)
 		outBoundErr <- err
 	}()
 
 	select {
 	case err = <-outBoundErr:
 		if err != nil {
-			m.logger.Reason(err).Errorf("error encountered copying data to outbound connection")
+			m.connectionFailed()
+			logger.Reason(err).Errorf("error encountered copying data to outbound connection")
 		}
 	case err = <-inBoundErr:
 		if err != nil {
-			m.logger.Reason(err).Errorf("error encountered copying data into inbound connection")
+			m.connectionFailed()
+			logger.Reason(err).Errorf("error encountered copying data into inbound connection")
 		}
 	case <-m.stopChan:
-		m.logger.Info("stop channel terminated proxy")
+		logger.Info("stop channel terminated proxy")
 	}
 }
 
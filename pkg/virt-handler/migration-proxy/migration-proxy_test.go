@@ -21,16 +21,22 @@ package migrationproxy
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/kubevirt/pkg/certificates"
+	"kubevirt.io/kubevirt/pkg/certificates/triple"
 	ephemeraldiskutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
 	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/testutils"
@@ -258,5 +264,266 @@ var _ = Describe("MigrationProxy", func() {
 				Entry("with TLS disabled", &v1.MigrationConfiguration{DisableTLS: pointer.P(true)}),
 			)
 		})
+
+		Context("with a connection observer", func() {
+			var observer *fakeConnectionObserver
+			var sourceSock string
+
+			BeforeEach(func() {
+				observer = &fakeConnectionObserver{bytes: map[string]int{}}
+				sourceSock = filepath.Join(tmpDir, "source-sock")
+			})
+
+			It("should count the connection and the bytes in each direction", func() {
+				echoListener, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+				Expect(err).ToNot(HaveOccurred())
+				defer echoListener.Close()
+				go func() {
+					defer GinkgoRecover()
+					fd, err := echoListener.Accept()
+					if err != nil {
+						return
+					}
+					defer fd.Close()
+					io.Copy(fd, fd)
+				}()
+
+				sourceProxy := NewSourceProxy(sourceSock, echoListener.Addr().String(), tlsConfig, tlsConfig, "123")
+				sourceProxy.observer = observer
+				Expect(sourceProxy.Start()).To(Succeed())
+				defer sourceProxy.Stop()
+
+				conn, err := net.Dial("unix", sourceSock)
+				Expect(err).ToNot(HaveOccurred())
+				message := []byte("some message")
+				_, err = conn.Write(message)
+				Expect(err).ToNot(HaveOccurred())
+				echo := make([]byte, len(message))
+				_, err = io.ReadFull(conn, echo)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(echo).To(Equal(message))
+
+				Eventually(observer.snapshot).Should(Equal(fakeConnectionObserver{
+					opened: 1,
+					bytes:  map[string]int{},
+				}))
+				conn.Close()
+				Eventually(observer.snapshot).Should(Equal(fakeConnectionObserver{
+					opened: 1,
+					closed: 1,
+					bytes:  map[string]int{DirectionOutbound: len(message), DirectionInbound: len(message)},
+				}))
+			})
+
+			It("should count connections which can't reach the target", func() {
+				sourceProxy := NewSourceProxy(sourceSock, "127.0.0.1:1", tlsConfig, tlsConfig, "123")
+				sourceProxy.observer = observer
+				Expect(sourceProxy.Start()).To(Succeed())
+				defer sourceProxy.Stop()
+
+				conn, err := net.Dial("unix", sourceSock)
+				Expect(err).ToNot(HaveOccurred())
+				defer conn.Close()
+
+				Eventually(observer.snapshot).Should(Equal(fakeConnectionObserver{failed: 1, bytes: map[string]int{}}))
+			})
+
+			It("should count the connections following a failed one as reconnections", func() {
+				sourceProxy := NewSourceProxy(sourceSock, "127.0.0.1:1", tlsConfig, tlsConfig, "123")
+				sourceProxy.observer = observer
+				Expect(sourceProxy.Start()).To(Succeed())
+				defer sourceProxy.Stop()
+
+				for i := 0; i < 2; i++ {
+					conn, err := net.Dial("unix", sourceSock)
+					Expect(err).ToNot(HaveOccurred())
+					defer conn.Close()
+					Eventually(func() int { return observer.snapshot().failed }).Should(Equal(i + 1))
+				}
+
+				Expect(observer.snapshot()).To(Equal(fakeConnectionObserver{failed: 2, reconnected: 1, bytes: map[string]int{}}))
+			})
+
+			It("should be handed to the proxies started by the manager", func() {
+				config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+				manager := NewMigrationProxyManager(tlsConfig, tlsConfig, config)
+				manager.SetConnectionObserver(observer)
+				Expect(manager.StartTargetListener("mykey", []string{filepath.Join(tmpDir, "virtqemud-sock")})).To(Succeed())
+				defer manager.StopTargetListener("mykey")
+
+				Expect(manager.(*migrationProxyManager).targetProxies["mykey"][0].observer).To(BeIdenticalTo(observer))
+			})
+		})
+
+		Context("with unix sockets left behind", func() {
+			var manager ProxyManager
+			var destSrcPortMap map[string]int
+
+			BeforeEach(func() {
+				config, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{})
+				manager = NewMigrationProxyManager(tlsConfig, tlsConfig, config)
+				destSrcPortMap = map[string]int{"12345": 0}
+			})
+
+			It("should be able to start the source proxy again after an aborted migration", func() {
+				Expect(manager.StartSourceListener("mykey", "127.0.0.1", destSrcPortMap, tmpDir)).To(Succeed())
+				manager.StopSourceListener("mykey")
+				Expect(manager.StartSourceListener("mykey", "127.0.0.1", destSrcPortMap, tmpDir)).To(Succeed())
+				defer manager.StopSourceListener("mykey")
+
+				files := manager.GetSourceListenerFiles("mykey")
+				Expect(files).To(HaveLen(1))
+				conn, err := net.Dial("unix", files[0])
+				Expect(err).ToNot(HaveOccurred())
+				conn.Close()
+			})
+
+			It("should replace a stale socket file", func() {
+				socket := SourceUnixFile(tmpDir, ConstructProxyKey("mykey", 0))
+				Expect(os.MkdirAll(filepath.Dir(socket), 0755)).To(Succeed())
+				listener, err := net.Listen("unix", socket)
+				Expect(err).ToNot(HaveOccurred())
+				// leave the file behind like a crashed process would
+				listener.(*net.UnixListener).SetUnlinkOnClose(false)
+				Expect(listener.Close()).To(Succeed())
+				Expect(socket).To(BeAnExistingFile())
+
+				Expect(manager.StartSourceListener("mykey", "127.0.0.1", destSrcPortMap, tmpDir)).To(Succeed())
+				defer manager.StopSourceListener("mykey")
+			})
+
+			It("should not take over the socket of a live listener", func() {
+				socket := SourceUnixFile(tmpDir, ConstructProxyKey("mykey", 0))
+				Expect(os.MkdirAll(filepath.Dir(socket), 0755)).To(Succeed())
+				listener, err := net.Listen("unix", socket)
+				Expect(err).ToNot(HaveOccurred())
+				defer listener.Close()
+
+				err = manager.StartSourceListener("mykey", "127.0.0.1", destSrcPortMap, tmpDir)
+				Expect(err).To(MatchError(ContainSubstring("still in use by a live listener")))
+			})
+		})
+
+		Context("with mutual TLS", func() {
+			var serverTLSConfig, clientTLSConfig *tls.Config
+			var targetProxy *migrationProxy
+
+			BeforeEach(func() {
+				ca, err := triple.NewCA("kubevirt.io", time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				caPool := x509.NewCertPool()
+				caPool.AddCert(ca.Cert)
+				serverKeyPair, err := triple.NewServerKeyPair(ca, "kubevirt.io:system:node:virt-handler", "virt-handler", "kubevirt", "cluster.local", nil, nil, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				clientKeyPair, err := triple.NewClientKeyPair(ca, "kubevirt.io:system:client:virt-handler", nil, time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+
+				serverTLSConfig = &tls.Config{
+					MinVersion:   tls.VersionTLS12,
+					Certificates: []tls.Certificate{{Certificate: [][]byte{serverKeyPair.Cert.Raw}, PrivateKey: serverKeyPair.Key}},
+					ClientCAs:    caPool,
+					ClientAuth:   tls.RequireAndVerifyClientCert,
+				}
+				clientTLSConfig = &tls.Config{
+					MinVersion: tls.VersionTLS12,
+					// like virt-handler, the certificate is verified without looking at the server name
+					InsecureSkipVerify: true,
+					Certificates:       []tls.Certificate{{Certificate: [][]byte{clientKeyPair.Cert.Raw}, PrivateKey: clientKeyPair.Key}},
+				}
+
+				virtqemudSock := filepath.Join(tmpDir, "virtqemud-sock")
+				virtqemudListener, err := net.Listen("unix", virtqemudSock)
+				Expect(err).ToNot(HaveOccurred())
+				DeferCleanup(virtqemudListener.Close)
+
+				targetProxy = NewTargetProxy("127.0.0.1", 0, serverTLSConfig, clientTLSConfig, virtqemudSock, "migration-a")
+				Expect(targetProxy.Start()).To(Succeed())
+				DeferCleanup(targetProxy.Stop)
+			})
+
+			handshake := func(config *tls.Config) error {
+				conn, err := tls.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(targetProxy.tcpBindPort)), config)
+				if err != nil {
+					return err
+				}
+				defer conn.Close()
+				// with TLS 1.3 the server checks the client certificate after the client finished its handshake
+				Expect(conn.SetReadDeadline(time.Now().Add(time.Second))).To(Succeed())
+				_, err = conn.Read(make([]byte, 1))
+				if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+					return nil
+				}
+				return err
+			}
+
+			DescribeTable("should accept the source proxy", func(key string) {
+				Expect(handshake(sourceTLSConfigForMigration(clientTLSConfig, key))).To(Succeed())
+			},
+				Entry("of the same migration", "migration-a"),
+				Entry("which does not announce its migration", ""),
+			)
+
+			It("should refuse the source proxy of another migration", func() {
+				err := handshake(sourceTLSConfigForMigration(clientTLSConfig, "migration-b"))
+				Expect(err).To(MatchError(ContainSubstring("remote error")))
+			})
+
+			It("should refuse a client without a certificate", func() {
+				config := sourceTLSConfigForMigration(clientTLSConfig, "migration-a")
+				config.Certificates = nil
+				err := handshake(config)
+				Expect(err).To(MatchError(ContainSubstring("remote error")))
+			})
+		})
 	})
 })
+
+type fakeConnectionObserver struct {
+	lock   sync.Mutex
+	opened int
+	closed int
+	failed int
+	bytes  map[string]int
+
+	reconnected int
+}
+
+func (o *fakeConnectionObserver) ConnectionOpened() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.opened++
+}
+
+func (o *fakeConnectionObserver) ConnectionClosed() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.closed++
+}
+
+func (o *fakeConnectionObserver) BytesCopied(direction string, bytes int) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.bytes[direction] += bytes
+}
+
+func (o *fakeConnectionObserver) ConnectionFailed() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.failed++
+}
+
+func (o *fakeConnectionObserver) Reconnected() {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.reconnected++
+}
+
+func (o *fakeConnectionObserver) snapshot() fakeConnectionObserver {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	bytes := map[string]int{}
+	for direction, n := range o.bytes {
+		bytes[direction] = n
+	}
+	return fakeConnectionObserver{opened: o.opened, closed: o.closed, failed: o.failed, reconnected: o.reconnected, bytes: bytes}
+}
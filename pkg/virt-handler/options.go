@@ -17,10 +17,14 @@ limitations under the License.
 package virthandler
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/log"
 	"libvirt.org/go/libvirtxml"
 
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -29,6 +33,12 @@ import (
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 )
 
+// maxMemBalloonStatsPeriod bounds the memballoon stats period a VMI can request through its annotation
+const maxMemBalloonStatsPeriod = 300
+
+// nodeSysfsDir is where the kernel exposes the host NUMA nodes and their hugepage pools
+var nodeSysfsDir = "/sys/devices/system/node"
+
 func virtualMachineOptions(
 	smbios *v1.SMBiosConfiguration,
 	period uint32,
@@ -50,6 +60,7 @@ func virtualMachineOptions(
 			Manufacturer: smbios.Manufacturer,
 			Sku:          smbios.Sku,
 			Version:      smbios.Version,
+			AssetTag:     smbios.AssetTag,
 		}
 	}
 
@@ -60,12 +71,39 @@ func virtualMachineOptions(
 			FreePageReportingDisabled: clusterConfig.IsFreePageReportingDisabled(),
 			BochsDisplayForEFIGuests:  clusterConfig.BochsDisplayForEFIGuestsEnabled(),
 			SerialConsoleLogDisabled:  clusterConfig.IsSerialConsoleLogDisabled(),
+			ServiceDomainInfix:        clusterConfig.GetServiceDomainInfix(),
 		}
 	}
 
 	return options
 }
 
+// memBalloonStatsPeriod returns the cluster wide memballoon stats period unless the VMI overrides it
+// with a valid MemBalloonStatsPeriodAnnotation
+func memBalloonStatsPeriod(vmi *v1.VirtualMachineInstance, clusterPeriod uint32) uint32 {
+	value, exists := vmi.Annotations[v1.MemBalloonStatsPeriodAnnotation]
+	if !exists {
+		return clusterPeriod
+	}
+
+	period, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || period > maxMemBalloonStatsPeriod {
+		log.Log.Object(vmi).Warningf("Ignoring the invalid %s annotation value %q, expected a number of seconds between 0 and %d",
+			v1.MemBalloonStatsPeriodAnnotation, value, maxMemBalloonStatsPeriod)
+		return clusterPeriod
+	}
+	return uint32(period)
+}
+
+// smbiosSerial returns the SMBIOS system serial number of the VMI, taken from its SMBiosSerialAnnotation
+// and defaulting to the VMI UID
+func smbiosSerial(vmi *v1.VirtualMachineInstance) string {
+	if serial := vmi.Annotations[v1.SMBiosSerialAnnotation]; serial != "" {
+		return serial
+	}
+	return string(vmi.UID)
+}
+
 func capabilitiesToTopology(capabilities *libvirtxml.Caps) *cmdv1.Topology {
 	topology := &cmdv1.Topology{}
 	if capabilities == nil {
@@ -89,6 +127,11 @@ func cellToCell(cell libvirtxml.CapsHostNUMACell) *cmdv1.Cell {
 
 	for _, page := range cell.PageInfo {
 		c.Pages = append(c.Pages, pageToPage(page))
+		// The capabilities only report the size of the pools, the free pages are read from sysfs.
+		// Base pages have no hugepage pool and are skipped.
+		if free, err := freePagesToPages(cell.ID, page); err == nil {
+			c.FreePages = append(c.FreePages, free)
+		}
 	}
 
 	for _, distance := range cell.Distances.Siblings {
@@ -110,6 +153,29 @@ func pageToPage(pages libvirtxml.CapsHostNUMAPageInfo) *cmdv1.Pages {
 	}
 }
 
+func freePagesToPages(cellID int, pages libvirtxml.CapsHostNUMAPageInfo) (*cmdv1.Pages, error) {
+	if pages.Unit != "" && pages.Unit != "KiB" {
+		return nil, fmt.Errorf("unsupported page size unit %s", pages.Unit)
+	}
+
+	freePagesPath := filepath.Join(nodeSysfsDir, fmt.Sprintf("node%d", cellID), "hugepages",
+		fmt.Sprintf("hugepages-%dkB", pages.Size), "free_hugepages")
+	content, err := os.ReadFile(freePagesPath)
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", freePagesPath, err)
+	}
+
+	return &cmdv1.Pages{
+		Count: count,
+		Unit:  pages.Unit,
+		Size:  uint32(pages.Size),
+	}, nil
+}
+
 func distanceToDistance(distance libvirtxml.CapsHostNUMASibling) *cmdv1.Sibling {
 	return &cmdv1.Sibling{
 		Id:    uint32(distance.ID),
@@ -17,16 +17,32 @@ limitations under the License.
 package virthandler
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
 	"libvirt.org/go/libvirtxml"
 
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
 )
 
 var _ = Describe("Parsing VMI Options", func() {
 	Context("virt-handler VM processes VMI options during update", func() {
 		const memoryUnit = "KiB"
+
+		BeforeEach(func() {
+			originalNodeSysfsDir := nodeSysfsDir
+			nodeSysfsDir = GinkgoT().TempDir()
+			DeferCleanup(func() {
+				nodeSysfsDir = originalNodeSysfsDir
+			})
+		})
+
 		DescribeTable("should convert libvirtxml.Caps to cmdv1.Topology when Caps is nil or empty", func(caps *libvirtxml.Caps) {
 			actualTopology := capabilitiesToTopology(caps)
 
@@ -290,6 +306,54 @@ var _ = Describe("Parsing VMI Options", func() {
 
 			Expect(actualTopology).To(Equal(expectedTopology))
 		})
+		It("should report the free hugepages of every page size of the NUMA nodes", func() {
+			writeFreeHugepages := func(cellID int, pageSizeKiB uint, count string) {
+				dir := filepath.Join(nodeSysfsDir, fmt.Sprintf("node%d", cellID), "hugepages", fmt.Sprintf("hugepages-%dkB", pageSizeKiB))
+				Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(dir, "free_hugepages"), []byte(count), 0644)).To(Succeed())
+			}
+			writeFreeHugepages(0, 2048, "12\n")
+			writeFreeHugepages(0, 1048576, "1\n")
+			writeFreeHugepages(1, 2048, "0\n")
+			writeFreeHugepages(1, 1048576, "not-a-number\n")
+
+			pageInfo := []libvirtxml.CapsHostNUMAPageInfo{
+				{Unit: memoryUnit, Size: 4, Count: 314094},
+				{Unit: memoryUnit, Size: 2048, Count: 16},
+				{Unit: memoryUnit, Size: 1048576, Count: 2},
+			}
+			caps := &libvirtxml.Caps{Host: libvirtxml.CapsHost{NUMA: &libvirtxml.CapsHostNUMATopology{}}}
+			caps.Host.NUMA.Cells = &libvirtxml.CapsHostNUMACells{
+				Cells: []libvirtxml.CapsHostNUMACell{
+					{
+						ID:        0,
+						Memory:    &libvirtxml.CapsHostNUMAMemory{Unit: memoryUnit, Size: 1289144},
+						PageInfo:  pageInfo,
+						Distances: &libvirtxml.CapsHostNUMADistances{},
+						CPUS:      &libvirtxml.CapsHostNUMACPUs{},
+					},
+					{
+						ID:        1,
+						Memory:    &libvirtxml.CapsHostNUMAMemory{Unit: memoryUnit, Size: 1223960},
+						PageInfo:  pageInfo,
+						Distances: &libvirtxml.CapsHostNUMADistances{},
+						CPUS:      &libvirtxml.CapsHostNUMACPUs{},
+					},
+				},
+			}
+
+			actualTopology := capabilitiesToTopology(caps)
+
+			Expect(actualTopology.NumaCells).To(HaveLen(2))
+			Expect(actualTopology.NumaCells[0].FreePages).To(Equal([]*cmdv1.Pages{
+				{Unit: memoryUnit, Size: 2048, Count: 12},
+				{Unit: memoryUnit, Size: 1048576, Count: 1},
+			}))
+			Expect(actualTopology.NumaCells[1].FreePages).To(Equal([]*cmdv1.Pages{
+				{Unit: memoryUnit, Size: 2048, Count: 0},
+			}))
+		})
+
 		It("should convert libvirtxml.Caps to cmdv1.Topology when certain fields of Caps are not initialized", func() {
 			caps := &libvirtxml.Caps{Host: libvirtxml.CapsHost{NUMA: &libvirtxml.CapsHostNUMATopology{}}}
 			caps.Host.NUMA.Cells = &libvirtxml.CapsHostNUMACells{
@@ -345,4 +409,53 @@ var _ = Describe("Parsing VMI Options", func() {
 			Expect(actualTopology).To(Equal(expectedTopology))
 		})
 	})
+
+	Context("memballoon stats period", func() {
+		const clusterPeriod = uint32(10)
+
+		newVMI := func(annotations map[string]string) *v1.VirtualMachineInstance {
+			return &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+		}
+
+		DescribeTable("should build the options with the period", func(vmi *v1.VirtualMachineInstance, expectedPeriod uint32) {
+			options := virtualMachineOptions(nil, memBalloonStatsPeriod(vmi, clusterPeriod), nil, nil, nil, nil)
+			Expect(options.MemBalloonStatsPeriod).To(Equal(expectedPeriod))
+		},
+			Entry("of the cluster when the VMI is not annotated", newVMI(nil), clusterPeriod),
+			Entry("of the VMI annotation", newVMI(map[string]string{v1.MemBalloonStatsPeriodAnnotation: "1"}), uint32(1)),
+			Entry("of the VMI annotation disabling the collection", newVMI(map[string]string{v1.MemBalloonStatsPeriodAnnotation: "0"}), uint32(0)),
+			Entry("of the VMI annotation at the upper bound", newVMI(map[string]string{v1.MemBalloonStatsPeriodAnnotation: "300"}), uint32(300)),
+			Entry("of the cluster when the annotation exceeds the upper bound", newVMI(map[string]string{v1.MemBalloonStatsPeriodAnnotation: "301"}), clusterPeriod),
+			Entry("of the cluster when the annotation is negative", newVMI(map[string]string{v1.MemBalloonStatsPeriodAnnotation: "-1"}), clusterPeriod),
+			Entry("of the cluster when the annotation is not a number", newVMI(map[string]string{v1.MemBalloonStatsPeriodAnnotation: "5s"}), clusterPeriod),
+		)
+	})
+
+	Context("SMBIOS", func() {
+		It("should carry the cluster asset tag", func() {
+			smbios := &v1.SMBiosConfiguration{Manufacturer: "KubeVirt", AssetTag: "rack-42"}
+			options := virtualMachineOptions(smbios, 0, nil, nil, nil, nil)
+			Expect(options.VirtualMachineSMBios).To(Equal(&cmdv1.SMBios{Manufacturer: "KubeVirt", AssetTag: "rack-42"}))
+		})
+
+		DescribeTable("should use the serial", func(annotations map[string]string, expectedSerial string) {
+			vmi := &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{UID: "1b9e5a4c-uid", Annotations: annotations}}
+			Expect(smbiosSerial(vmi)).To(Equal(expectedSerial))
+		},
+			Entry("of the VMI UID when the VMI is not annotated", nil, "1b9e5a4c-uid"),
+			Entry("of the VMI UID when the annotation is empty", map[string]string{v1.SMBiosSerialAnnotation: ""}, "1b9e5a4c-uid"),
+			Entry("of the VMI annotation", map[string]string{v1.SMBiosSerialAnnotation: "SN-0042"}, "SN-0042"),
+		)
+	})
+
+	Context("cluster config", func() {
+		DescribeTable("should carry the service domain infix", func(networkConfig *v1.NetworkConfiguration, expectedInfix string) {
+			clusterConfig, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{NetworkConfiguration: networkConfig})
+			options := virtualMachineOptions(nil, 0, nil, nil, nil, clusterConfig)
+			Expect(options.ClusterConfig.ServiceDomainInfix).To(Equal(expectedInfix))
+		},
+			Entry("empty when it is not configured", nil, ""),
+			Entry("of the network configuration", &v1.NetworkConfiguration{ServiceDomainInfix: ".services."}, ".services."),
+		)
+	})
 })
@@ -36,8 +36,6 @@ import (
 	"strings"
 	"time"
 
-	"libvirt.org/go/libvirtxml"
-
 	backendstorage "kubevirt.io/kubevirt/pkg/storage/backend-storage"
 
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
@@ -103,6 +101,7 @@ import (
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
+	exitstatus "kubevirt.io/kubevirt/pkg/virt-launcher/exit-status"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
@@ -163,6 +162,10 @@ const (
 	memoryHotplugFailedReason = "Memory Hotplug Failed"
 )
 
+// the process monitor of virt-launcher touches its heartbeat file every second,
+// so a much older heartbeat means that its monitoring loop got stuck
+const launcherHeartbeatTimeout = 1 * time.Minute
+
 var RequiredGuestAgentCommands = []string{
 	"guest-ping",
 	"guest-get-time",
@@ -215,7 +218,7 @@ func NewController(
 	podIsolationDetector isolation.PodIsolationDetector,
 	migrationProxy migrationproxy.ProxyManager,
 	downwardMetricsManager downwardMetricsManager,
-	capabilities *libvirtxml.Caps,
+	capabilities capabilitiesProvider,
 	hostCpuModel string,
 	netConf netconf,
 	netStat netstat,
@@ -245,6 +248,7 @@ func NewController(
 		vmiTargetStore:                   vmiTargetInformer.GetStore(),
 		domainStore:                      domainInformer.GetStore(),
 		heartBeatInterval:                1 * time.Minute,
+		launcherHeartbeatTimeout:         launcherHeartbeatTimeout,
 		migrationProxy:                   migrationProxy,
 		podIsolationDetector:             podIsolationDetector,
 		containerDiskMounter:             container_disk.NewMounter(podIsolationDetector, containerDiskState, clusterConfig),
@@ -339,6 +343,7 @@ type VirtualMachineController struct {
 	domainStore              cache.Store
 	launcherClients          virtcache.LauncherClientInfoByVMI
 	heartBeatInterval        time.Duration
+	launcherHeartbeatTimeout time.Duration
 	deviceManagerController  *device_manager.DeviceController
 	migrationProxy           migrationproxy.ProxyManager
 	podIsolationDetector     isolation.PodIsolationDetector
@@ -355,7 +360,7 @@ type VirtualMachineController struct {
 	domainNotifyPipes           map[string]string
 	virtLauncherFSRunDirPattern string
 	heartBeat                   *heartbeat.HeartBeat
-	capabilities                *libvirtxml.Caps
+	capabilities                capabilitiesProvider
 	hostCpuModel                string
 	vmiExpectations             *controller.UIDTrackingControllerExpectations
 	ioErrorRetryManager         *FailRetryManager
@@ -1427,10 +1432,88 @@ func (d *VirtualMachineController) updateVMIConditions(vmi *v1.VirtualMachineIns
 		return err
 	}
 	d.updatePausedConditions(vmi, domain, condManager)
+	d.updateQemuExitedCondition(vmi, condManager)
+	d.updateLauncherUnresponsiveCondition(vmi, condManager)
 
 	return nil
 }
 
+// updateQemuExitedCondition tells why a VMI failed, based on the exit status
+// of qemu which virt-launcher stores next to its command socket.
+func (d *VirtualMachineController) updateQemuExitedCondition(vmi *v1.VirtualMachineInstance, condManager *controller.VirtualMachineInstanceConditionManager) {
+	if vmi.Status.Phase != v1.Failed || condManager.HasCondition(vmi, v1.VirtualMachineInstanceQemuExited) {
+		return
+	}
+	socketDir, err := cmdclient.FindPodDirOnHost(vmi)
+	if err != nil {
+		return
+	}
+	status, err := exitstatus.Read(filepath.Join(socketDir, exitstatus.FileName))
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Warning("Failed to read the exit status of qemu")
+		return
+	}
+	if status == nil || !status.Failed() {
+		return
+	}
+
+	reason := v1.VirtualMachineInstanceReasonQemuExitCode
+	if status.Signal != 0 {
+		reason = v1.VirtualMachineInstanceReasonQemuSignaled
+	}
+	now := metav1.NewTime(time.Now())
+	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+		Type:               v1.VirtualMachineInstanceQemuExited,
+		Status:             k8sv1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            status.String(),
+	})
+}
+
+// updateLauncherUnresponsiveCondition flags a running VMI whose virt-launcher
+// process monitor stopped touching its heartbeat file, since nothing would
+// take care of qemu anymore in that case.
+func (d *VirtualMachineController) updateLauncherUnresponsiveCondition(vmi *v1.VirtualMachineInstance, condManager *controller.VirtualMachineInstanceConditionManager) {
+	age, found := launcherHeartbeatAge(vmi)
+	if vmi.Status.Phase != v1.Running || !found || age <= d.launcherHeartbeatTimeout {
+		if condManager.HasCondition(vmi, v1.VirtualMachineInstanceLauncherUnresponsive) {
+			log.Log.Object(vmi).V(3).Info("Removing launcher unresponsive condition")
+			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceLauncherUnresponsive)
+		}
+		return
+	}
+	if condManager.HasCondition(vmi, v1.VirtualMachineInstanceLauncherUnresponsive) {
+		return
+	}
+
+	now := metav1.NewTime(time.Now())
+	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+		Type:               v1.VirtualMachineInstanceLauncherUnresponsive,
+		Status:             k8sv1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             v1.VirtualMachineInstanceReasonLauncherHeartbeatExpired,
+		Message:            fmt.Sprintf("The process monitor of virt-launcher did not report for %s", age.Round(time.Second)),
+	})
+}
+
+// launcherHeartbeatAge returns how long ago the virt-launcher process monitor
+// touched its heartbeat file. A launcher without a running monitor, e.g.
+// before the domain started, has no heartbeat file.
+func launcherHeartbeatAge(vmi *v1.VirtualMachineInstance) (time.Duration, bool) {
+	socketDir, err := cmdclient.FindPodDirOnHost(vmi)
+	if err != nil {
+		return 0, false
+	}
+	info, err := os.Stat(filepath.Join(socketDir, cmdclient.StandardLauncherHeartbeatFileName))
+	if err != nil {
+		return 0, false
+	}
+	return time.Since(info.ModTime()), true
+}
+
 func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineInstance, domain *api.Domain, syncError error) (err error) {
 	condManager := controller.NewVirtualMachineInstanceConditionManager()
 
@@ -2943,7 +3026,7 @@ func (d *VirtualMachineController) vmUpdateHelperMigrationTarget(origVMI *v1.Vir
 		}
 	}
 
-	options := virtualMachineOptions(nil, 0, nil, d.capabilities, disksInfo, d.clusterConfig)
+	options := virtualMachineOptions(nil, 0, nil, d.hostCapabilities(), disksInfo, d.clusterConfig)
 	options.InterfaceDomainAttachment = domainspec.DomainAttachmentByInterfaceName(vmi.Spec.Domain.Devices.Interfaces, d.clusterConfig.GetNetworkBindings())
 
 	if err := client.SyncMigrationTarget(vmi, options); err != nil {
@@ -3240,9 +3323,12 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 	}
 
 	smbios := d.clusterConfig.GetSMBIOS()
-	period := d.clusterConfig.GetMemBalloonStatsPeriod()
+	period := memBalloonStatsPeriod(vmi, d.clusterConfig.GetMemBalloonStatsPeriod())
 
-	options := virtualMachineOptions(smbios, period, preallocatedVolumes, d.capabilities, disksInfo, d.clusterConfig)
+	options := virtualMachineOptions(smbios, period, preallocatedVolumes, d.hostCapabilities(), disksInfo, d.clusterConfig)
+	if options.VirtualMachineSMBios != nil {
+		options.VirtualMachineSMBios.Serial = smbiosSerial(vmi)
+	}
 	options.InterfaceDomainAttachment = domainspec.DomainAttachmentByInterfaceName(vmi.Spec.Domain.Devices.Interfaces, d.clusterConfig.GetNetworkBindings())
 
 	err = client.SyncVirtualMachine(vmi, options)
@@ -3607,7 +3693,7 @@ func (d *VirtualMachineController) reportDedicatedCPUSetForMigratingVMI(vmi *v1.
 }
 
 func (d *VirtualMachineController) reportTargetTopologyForMigratingVMI(vmi *v1.VirtualMachineInstance) error {
-	options := virtualMachineOptions(nil, 0, nil, d.capabilities, map[string]*containerdisk.DiskInfo{}, d.clusterConfig)
+	options := virtualMachineOptions(nil, 0, nil, d.hostCapabilities(), map[string]*containerdisk.DiskInfo{}, d.clusterConfig)
 	topology, err := json.Marshal(options.Topology)
 	if err != nil {
 		return err
@@ -3679,7 +3765,7 @@ func (d *VirtualMachineController) hotplugCPU(vmi *v1.VirtualMachineInstance, cl
 		nil,
 		0,
 		nil,
-		d.capabilities,
+		d.hostCapabilities(),
 		nil,
 		d.clusterConfig)
 
@@ -3731,7 +3817,7 @@ func (d *VirtualMachineController) hotplugMemory(vmi *v1.VirtualMachineInstance,
 		return fmt.Errorf("amount of requested guest memory (%s) exceeds the launcher memory request (%s)", vmi.Spec.Domain.Memory.Guest.String(), podMemReqStr)
 	}
 
-	options := virtualMachineOptions(nil, 0, nil, d.capabilities, nil, d.clusterConfig)
+	options := virtualMachineOptions(nil, 0, nil, d.hostCapabilities(), nil, d.clusterConfig)
 
 	if err := client.SyncVirtualMachineMemory(vmi, options); err != nil {
 		// mark hotplug as failed
@@ -71,6 +71,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	notifyserver "kubevirt.io/kubevirt/pkg/virt-handler/notify-server"
+	exitstatus "kubevirt.io/kubevirt/pkg/virt-launcher/exit-status"
 	notifyclient "kubevirt.io/kubevirt/pkg/virt-launcher/notify-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
@@ -1032,6 +1033,71 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(updatedVMI.Status.Phase).To(Equal(v1.Failed))
 		})
 
+		DescribeTable("should set the QemuExited condition", func(phase v1.VirtualMachineInstancePhase, status *exitstatus.ExitStatus, expectedReason string) {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.Status.Phase = phase
+			vmi = addActivePods(vmi, podTestUUID, host)
+			if status != nil {
+				Expect(exitstatus.Write(filepath.Join(filepath.Dir(sockFile), exitstatus.FileName), status)).To(Succeed())
+			}
+
+			controller.updateQemuExitedCondition(vmi, virtcontroller.NewVirtualMachineInstanceConditionManager())
+			if expectedReason == "" {
+				Expect(vmi.Status.Conditions).To(BeEmpty())
+				return
+			}
+			Expect(vmi.Status.Conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Type":    Equal(v1.VirtualMachineInstanceQemuExited),
+				"Reason":  Equal(expectedReason),
+				"Message": Equal(status.String()),
+			})))
+		},
+			Entry("with the exit code of a failed VMI", v1.Failed, &exitstatus.ExitStatus{ExitCode: 1}, v1.VirtualMachineInstanceReasonQemuExitCode),
+			Entry("with the signal of a failed VMI", v1.Failed, &exitstatus.ExitStatus{Signal: 9}, v1.VirtualMachineInstanceReasonQemuSignaled),
+			Entry("not for a failed VMI without exit status", v1.Failed, nil, ""),
+			Entry("not for a clean exit", v1.Failed, &exitstatus.ExitStatus{}, ""),
+			Entry("not for a running VMI", v1.Running, &exitstatus.ExitStatus{ExitCode: 1}, ""),
+		)
+
+		DescribeTable("should flag a VMI whose launcher heartbeat is outdated", func(phase v1.VirtualMachineInstancePhase, heartbeatAge time.Duration, hadCondition, expectCondition bool) {
+			vmi := api2.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.Status.Phase = phase
+			vmi = addActivePods(vmi, podTestUUID, host)
+			if hadCondition {
+				vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{{
+					Type:   v1.VirtualMachineInstanceLauncherUnresponsive,
+					Status: k8sv1.ConditionTrue,
+				}}
+			}
+			if heartbeatAge >= 0 {
+				heartbeat := filepath.Join(filepath.Dir(sockFile), cmdclient.StandardLauncherHeartbeatFileName)
+				Expect(os.WriteFile(heartbeat, nil, 0644)).To(Succeed())
+				modTime := time.Now().Add(-heartbeatAge)
+				Expect(os.Chtimes(heartbeat, modTime, modTime)).To(Succeed())
+			}
+
+			controller.updateLauncherUnresponsiveCondition(vmi, virtcontroller.NewVirtualMachineInstanceConditionManager())
+			if !expectCondition {
+				Expect(vmi.Status.Conditions).To(BeEmpty())
+				return
+			}
+			Expect(vmi.Status.Conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Type":    Equal(v1.VirtualMachineInstanceLauncherUnresponsive),
+				"Status":  Equal(k8sv1.ConditionTrue),
+				"Reason":  Equal(v1.VirtualMachineInstanceReasonLauncherHeartbeatExpired),
+				"Message": ContainSubstring("did not report for 2m"),
+			})))
+		},
+			Entry("with an outdated heartbeat", v1.Running, 2*time.Minute, false, true),
+			Entry("not with a recent heartbeat", v1.Running, time.Duration(0), false, false),
+			Entry("not without a heartbeat", v1.Running, time.Duration(-1), false, false),
+			Entry("not for a VMI which is not running", v1.Failed, 2*time.Minute, false, false),
+			Entry("and clear the flag once the heartbeat is recent again", v1.Running, time.Duration(0), true, false),
+			Entry("and clear the flag once the heartbeat is gone", v1.Running, time.Duration(-1), true, false),
+		)
+
 		It("should move VirtualMachineInstance to Failed if configuring the networks on the virt-launcher fails with critical error", func() {
 			vmi := api2.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -0,0 +1,174 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+// Package exitstatus carries the way the qemu process terminated from the
+// virt-launcher container to virt-handler.
+//
+// qemu is not a child of virt-launcher, so only virt-launcher-monitor, which
+// reaps it, sees its wait status. It records the status of the qemu
+// processes it reaps in ReapedDir, virt-launcher picks up the one of the
+// qemu pid and stores it as FileName next to the launcher socket, where
+// virt-handler reads it.
+package exitstatus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"kubevirt.io/kubevirt/pkg/util"
+)
+
+const FileName = "qemu-exit-status"
+
+var (
+	ReapedDir = filepath.Join(util.VirtPrivateDir, "reaped")
+	procDir   = "/proc"
+)
+
+type ExitStatus struct {
+	ExitCode int `json:"exitCode"`
+	Signal   int `json:"signal,omitempty"`
+}
+
+func FromWaitStatus(status syscall.WaitStatus) *ExitStatus {
+	if status.Signaled() {
+		return &ExitStatus{Signal: int(status.Signal())}
+	}
+	return &ExitStatus{ExitCode: status.ExitStatus()}
+}
+
+// FromProcStat reads the exit status of a zombie process, which the kernel
+// keeps in the exit_code field of /proc/<pid>/stat until it is reaped.
+func FromProcStat(pid int) (*ExitStatus, error) {
+	_, fields, err := readStat(pid)
+	if err != nil {
+		return nil, err
+	}
+	const exitCodeField = 52 - 3
+	if len(fields) <= exitCodeField {
+		return nil, fmt.Errorf("stat of pid %d has no exit code", pid)
+	}
+	status, err := strconv.Atoi(fields[exitCodeField])
+	if err != nil {
+		return nil, fmt.Errorf("malformed exit code in stat of pid %d: %v", pid, err)
+	}
+	return FromWaitStatus(syscall.WaitStatus(status)), nil
+}
+
+// ZombieChildren returns the command names of the terminated children of
+// the parent which were not reaped yet, by pid. Zombies keep their command
+// name in /proc/<pid>/stat, it is gone once they are reaped.
+func ZombieChildren(parent int) (map[int]string, error) {
+	entries, err := os.ReadDir(procDir)
+	if err != nil {
+		return nil, err
+	}
+	zombies := map[int]string{}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		// the process may be gone since the directory was read
+		command, fields, err := readStat(pid)
+		if err != nil || len(fields) < 2 {
+			continue
+		}
+		if fields[0] == "Z" && fields[1] == strconv.Itoa(parent) {
+			zombies[pid] = command
+		}
+	}
+	return zombies, nil
+}
+
+// IsQemu tells whether the command name is the one of a qemu process.
+func IsQemu(command string) bool {
+	return strings.HasPrefix(command, "qemu-system") || strings.HasPrefix(command, "qemu-kvm")
+}
+
+// readStat returns the command name in /proc/<pid>/stat and the fields
+// following it, starting with the state.
+func readStat(pid int) (string, []string, error) {
+	// #nosec No risk for path injection. Reading specific entries under /proc
+	content, err := os.ReadFile(filepath.Join(procDir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return "", nil, err
+	}
+	// the command name in the second field may contain spaces and parentheses
+	start := strings.IndexByte(string(content), '(')
+	end := strings.LastIndexByte(string(content), ')')
+	if start < 0 || end < start {
+		return "", nil, fmt.Errorf("malformed stat of pid %d", pid)
+	}
+	return string(content[start+1 : end]), strings.Fields(string(content[end+1:])), nil
+}
+
+func (s *ExitStatus) String() string {
+	if s.Signal != 0 {
+		return fmt.Sprintf("qemu exited with signal %d (%s)", s.Signal, syscall.Signal(s.Signal))
+	}
+	return fmt.Sprintf("qemu exited with code %d", s.ExitCode)
+}
+
+func (s *ExitStatus) Failed() bool {
+	return s.Signal != 0 || s.ExitCode != 0
+}
+
+func ReapedPath(reapedDir string, pid int) string {
+	return filepath.Join(reapedDir, strconv.Itoa(pid))
+}
+
+// Write stores the status atomically, so that readers never see a partial file.
+func Write(path string, status *ExitStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if err := util.MkdirAllWithNosec(filepath.Dir(path)); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	// #nosec G306, the exit status is no secret and has to be readable by virt-handler
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Read returns the stored status or nil if none was stored.
+func Read(path string) (*ExitStatus, error) {
+	// #nosec No risk for path injection. The path is built from fixed parts
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	status := &ExitStatus{}
+	if err := json.Unmarshal(data, status); err != nil {
+		return nil, fmt.Errorf("failed to parse the exit status in %s: %v", path, err)
+	}
+	return status, nil
+}
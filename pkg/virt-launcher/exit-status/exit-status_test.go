@@ -0,0 +1,136 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package exitstatus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExitStatus", func() {
+
+	DescribeTable("should be created from a wait status", func(status syscall.WaitStatus, expected *ExitStatus, message string) {
+		exitStatus := FromWaitStatus(status)
+		Expect(exitStatus).To(Equal(expected))
+		Expect(exitStatus.String()).To(Equal(message))
+	},
+		Entry("for a clean exit", syscall.WaitStatus(0), &ExitStatus{}, "qemu exited with code 0"),
+		Entry("for an exit code", syscall.WaitStatus(1<<8), &ExitStatus{ExitCode: 1}, "qemu exited with code 1"),
+		Entry("for a signal", syscall.WaitStatus(syscall.SIGABRT), &ExitStatus{Signal: 6}, "qemu exited with signal 6 (aborted)"),
+		Entry("for a signal with a core dump", syscall.WaitStatus(0x80|syscall.SIGSEGV), &ExitStatus{Signal: 11}, "qemu exited with signal 11 (segmentation fault)"),
+	)
+
+	Context("from /proc", func() {
+		var origProcDir string
+
+		BeforeEach(func() {
+			origProcDir = procDir
+			procDir = GinkgoT().TempDir()
+		})
+
+		AfterEach(func() {
+			procDir = origProcDir
+		})
+
+		writeProcessStat := func(pid int, comm, state string, ppid, exitCode int) {
+			fields := make([]string, 50)
+			for i := range fields {
+				fields[i] = "0"
+			}
+			fields[0] = state
+			fields[1] = fmt.Sprint(ppid)
+			fields[49] = fmt.Sprint(exitCode)
+			Expect(os.MkdirAll(filepath.Join(procDir, fmt.Sprint(pid)), 0755)).To(Succeed())
+			content := fmt.Sprintf("%d (%s) %s\n", pid, comm, strings.Join(fields, " "))
+			Expect(os.WriteFile(filepath.Join(procDir, fmt.Sprint(pid), "stat"), []byte(content), 0644)).To(Succeed())
+		}
+
+		writeStat := func(pid int, comm string, exitCode int) {
+			writeProcessStat(pid, comm, "Z", 0, exitCode)
+		}
+
+		It("should read the exit code of a zombie", func() {
+			writeStat(42, "qemu-kvm", 2<<8)
+			Expect(FromProcStat(42)).To(Equal(&ExitStatus{ExitCode: 2}))
+		})
+
+		It("should read the signal of a zombie with spaces and parentheses in its name", func() {
+			writeStat(42, "qemu (x) 1", int(syscall.SIGKILL))
+			Expect(FromProcStat(42)).To(Equal(&ExitStatus{Signal: 9}))
+		})
+
+		It("should fail on a truncated stat", func() {
+			Expect(os.MkdirAll(filepath.Join(procDir, "42"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(procDir, "42", "stat"), []byte("42 (qemu-kvm) Z 1 2 3"), 0644)).To(Succeed())
+			_, err := FromProcStat(42)
+			Expect(err).To(MatchError(ContainSubstring("has no exit code")))
+		})
+
+		It("should read its own process", func() {
+			procDir = origProcDir
+			Expect(FromProcStat(os.Getpid())).To(Equal(&ExitStatus{}))
+		})
+
+		It("should list the zombie children with their command names", func() {
+			writeProcessStat(42, "qemu-kvm", "Z", 1, 0)
+			writeProcessStat(43, "virt-launcher", "Z", 1, 0)
+			writeProcessStat(44, "qemu-system-x86", "S", 1, 0)
+			writeProcessStat(45, "qemu-kvm", "Z", 2, 0)
+			Expect(os.MkdirAll(filepath.Join(procDir, "self"), 0755)).To(Succeed())
+			Expect(ZombieChildren(1)).To(Equal(map[int]string{42: "qemu-kvm", 43: "virt-launcher"}))
+		})
+	})
+
+	DescribeTable("should tell qemu", func(command string, expected bool) {
+		Expect(IsQemu(command)).To(Equal(expected))
+	},
+		Entry("by the qemu-system prefix", "qemu-system-x86", true),
+		Entry("by the qemu-kvm prefix", "qemu-kvm", true),
+		Entry("apart from other commands", "virt-launcher", false),
+		Entry("apart from commands merely containing qemu", "virtqemud", false),
+	)
+
+	Context("stored in a file", func() {
+		It("should be read back", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "sockets", FileName)
+			Expect(Write(path, &ExitStatus{Signal: 6})).To(Succeed())
+			Expect(Read(path)).To(Equal(&ExitStatus{Signal: 6}))
+			_, err := os.Stat(path + ".tmp")
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+
+		It("should be nil if no status was stored", func() {
+			Expect(Read(filepath.Join(GinkgoT().TempDir(), FileName))).To(BeNil())
+		})
+
+		It("should fail on a corrupted file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), FileName)
+			Expect(os.WriteFile(path, []byte("{"), 0644)).To(Succeed())
+			_, err := Read(path)
+			Expect(err).To(MatchError(ContainSubstring("failed to parse the exit status")))
+		})
+	})
+})
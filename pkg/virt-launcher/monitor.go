@@ -20,6 +20,7 @@
 package virtlauncher
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -29,6 +30,7 @@ import (
 	"syscall"
 	"time"
 
+	exitstatus "kubevirt.io/kubevirt/pkg/virt-launcher/exit-status"
 	cmdserver "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cmd-server"
 
 	"kubevirt.io/client-go/log"
@@ -37,6 +39,11 @@ import (
 	"kubevirt.io/kubevirt/pkg/util"
 )
 
+const (
+	minRefreshInterval = 100 * time.Millisecond
+	maxRefreshInterval = 1 * time.Second
+)
+
 type OnShutdownCallback func(pid int)
 type OnGracefulShutdownCallback func()
 
@@ -49,12 +56,20 @@ type monitor struct {
 	isDone                   bool
 	gracePeriod              int
 	gracePeriodStartTime     int64
+	killGracePeriod          int
+	killGracePeriodStartTime int64
+	reapedDir                string
+	exitStatusFile           string
+	heartbeatFile            string
+	exitStatus               *exitstatus.ExitStatus
 	finalShutdownCallback    OnShutdownCallback
 	gracefulShutdownCallback OnGracefulShutdownCallback
 }
 
 type ProcessMonitor interface {
-	RunForever(startTimeout time.Duration, signalStopChan chan struct{})
+	RunForever(startTimeout time.Duration, signalStopChan chan struct{}) *exitstatus.ExitStatus
+	RunForeverCtx(ctx context.Context, startTimeout time.Duration, signalStopChan chan struct{}) *exitstatus.ExitStatus
+	ExitStatus() *exitstatus.ExitStatus
 }
 
 func InitializePrivateDirectories(baseDir string) error {
@@ -108,13 +123,20 @@ func InitializeDisksDirectories(baseDir string) error {
 
 func NewProcessMonitor(domainName string,
 	pidDir string,
+	exitStatusFile string,
+	heartbeatFile string,
 	gracePeriod int,
+	killGracePeriod int,
 	finalShutdownCallback OnShutdownCallback,
 	gracefulShutdownCallback OnGracefulShutdownCallback) ProcessMonitor {
 	return &monitor{
 		domainName:               domainName,
 		pidDir:                   pidDir,
+		reapedDir:                exitstatus.ReapedDir,
+		exitStatusFile:           exitStatusFile,
+		heartbeatFile:            heartbeatFile,
 		gracePeriod:              gracePeriod,
+		killGracePeriod:          killGracePeriod,
 		finalShutdownCallback:    finalShutdownCallback,
 		gracefulShutdownCallback: gracefulShutdownCallback,
 	}
@@ -130,6 +152,16 @@ func (mon *monitor) isGracePeriodExpired() bool {
 	return false
 }
 
+func (mon *monitor) isKillGracePeriodExpired() bool {
+	if mon.killGracePeriodStartTime != 0 {
+		now := time.Now().UTC().Unix()
+		if (now - mon.killGracePeriodStartTime) > int64(mon.killGracePeriod) {
+			return true
+		}
+	}
+	return false
+}
+
 func (mon *monitor) refresh() {
 	if mon.isDone {
 		log.Log.Error("Called refresh after done!")
@@ -177,6 +209,7 @@ func (mon *monitor) refresh() {
 	}
 	if exists == false {
 		log.Log.Infof("Process %s and pid %d is gone!", mon.domainName, mon.pid)
+		mon.recordExitStatus(mon.readReapedExitStatus())
 		mon.pid = 0
 		mon.isDone = true
 		return
@@ -184,6 +217,11 @@ func (mon *monitor) refresh() {
 
 	if isZombie {
 		log.Log.Infof("Process %s and pid %d is a zombie, sending SIGCHLD to pid 1 to reap process", mon.domainName, mon.pid)
+		status, err := exitstatus.FromProcStat(mon.pid)
+		if err != nil {
+			log.Log.Reason(err).Warningf("Unable to read the exit status of pid %d", mon.pid)
+		}
+		mon.recordExitStatus(status)
 		syscall.Kill(1, syscall.SIGCHLD)
 		mon.pid = 0
 		mon.isDone = true
@@ -191,6 +229,13 @@ func (mon *monitor) refresh() {
 	}
 
 	if expired {
+		if mon.killGracePeriodStartTime == 0 {
+			mon.killGracePeriodStartTime = time.Now().UTC().Unix()
+		} else if mon.isKillGracePeriodExpired() {
+			log.Log.Infof("Process %s and pid %d did not stop after the kill grace period, sending SIGKILL", mon.domainName, mon.pid)
+			killProcessAndGroup(mon.pid)
+			return
+		}
 		log.Log.Infof("Grace Period expired, shutting down.")
 		mon.finalShutdownCallback(mon.pid)
 	}
@@ -198,26 +243,77 @@ func (mon *monitor) refresh() {
 	return
 }
 
-func (mon *monitor) monitorLoop(startTimeout time.Duration, signalStopChan chan struct{}) {
-	// random value, no real rationale
-	rate := 1 * time.Second
+// readReapedExitStatus looks up the exit status virt-launcher-monitor
+// recorded when it reaped the process.
+func (mon *monitor) readReapedExitStatus() *exitstatus.ExitStatus {
+	reapedPath := exitstatus.ReapedPath(mon.reapedDir, mon.pid)
+	status, err := exitstatus.Read(reapedPath)
+	if err != nil {
+		log.Log.Reason(err).Warningf("Unable to read the exit status of pid %d", mon.pid)
+		return nil
+	}
+	if status != nil {
+		if err := os.Remove(reapedPath); err != nil {
+			log.Log.Reason(err).Warningf("Unable to remove %s", reapedPath)
+		}
+	}
+	return status
+}
+
+// recordExitStatus hands the exit status of the process over to virt-handler.
+func (mon *monitor) recordExitStatus(status *exitstatus.ExitStatus) {
+	if status == nil {
+		return
+	}
+	mon.exitStatus = status
+	if mon.exitStatusFile == "" {
+		return
+	}
+	log.Log.Infof("Process %s and pid %d: %s", mon.domainName, mon.pid, status)
+	if err := exitstatus.Write(mon.exitStatusFile, status); err != nil {
+		log.Log.Reason(err).Errorf("Unable to record the exit status of pid %d", mon.pid)
+	}
+}
+
+// nextRefreshInterval polls fast while the process was not found yet, so that
+// it is picked up right after it started, and then backs off exponentially,
+// to keep the /proc scanning on busy nodes low while the process runs.
+func nextRefreshInterval(current time.Duration, pidFound bool) time.Duration {
+	if !pidFound {
+		return minRefreshInterval
+	}
+	if next := current * 2; next < maxRefreshInterval {
+		return next
+	}
+	return maxRefreshInterval
+}
 
+func (mon *monitor) monitorLoop(ctx context.Context, startTimeout time.Duration, signalStopChan chan struct{}) {
 	timeoutRepr := fmt.Sprintf("%v", startTimeout)
 	if startTimeout == 0 {
 		timeoutRepr = "disabled"
 	}
-	log.Log.Infof("Monitoring loop: rate %v start timeout %s", rate, timeoutRepr)
+	log.Log.Infof("Monitoring loop: rate %v-%v start timeout %s", minRefreshInterval, maxRefreshInterval, timeoutRepr)
 
-	ticker := time.NewTicker(rate)
-	defer ticker.Stop()
+	interval := minRefreshInterval
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
 	mon.isDone = false
 	mon.timeout = startTimeout
 	mon.start = time.Now()
+	mon.touchHeartbeat()
+	defer mon.removeHeartbeat()
 
 	for !mon.isDone {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
 			mon.refresh()
+			mon.touchHeartbeat()
+			interval = nextRefreshInterval(interval, mon.pid != 0)
+			timer.Reset(interval)
+		case <-ctx.Done():
+			log.Log.Infof("Monitoring loop of %s stopped: %v", mon.domainName, ctx.Err())
+			return
 		case <-signalStopChan:
 			if mon.gracePeriodStartTime != 0 {
 				continue
@@ -230,33 +326,93 @@ func (mon *monitor) monitorLoop(startTimeout time.Duration, signalStopChan chan
 
 }
 
-func (mon *monitor) RunForever(startTimeout time.Duration, signalStopChan chan struct{}) {
-	mon.monitorLoop(startTimeout, signalStopChan)
+// RunForever monitors the process until it is gone and returns how it exited,
+// or nil if that is unknown.
+func (mon *monitor) RunForever(startTimeout time.Duration, signalStopChan chan struct{}) *exitstatus.ExitStatus {
+	return mon.RunForeverCtx(context.Background(), startTimeout, signalStopChan)
 }
 
-func pidExists(pid int) (exists bool, isZombie bool, err error) {
+// RunForeverCtx behaves like RunForever, but also returns once the context is
+// cancelled. The monitored process is left alone in that case, no shutdown is
+// triggered.
+func (mon *monitor) RunForeverCtx(ctx context.Context, startTimeout time.Duration, signalStopChan chan struct{}) *exitstatus.ExitStatus {
+	mon.monitorLoop(ctx, startTimeout, signalStopChan)
+	return mon.exitStatus
+}
 
-	pathCmdline := fmt.Sprintf("/proc/%d/cmdline", pid)
-	pathStatus := fmt.Sprintf("/proc/%d/status", pid)
+// ExitStatus returns how the monitored process exited, or nil if it did not
+// exit yet or its exit status could not be determined.
+func (mon *monitor) ExitStatus() *exitstatus.ExitStatus {
+	return mon.exitStatus
+}
 
-	exists, err = diskutils.FileExists(pathCmdline)
+// touchHeartbeat updates the modification time of the heartbeat file, which
+// lets virt-handler detect a monitoring loop which stopped making progress.
+func (mon *monitor) touchHeartbeat() {
+	if mon.heartbeatFile == "" {
+		return
+	}
+	now := time.Now()
+	err := os.Chtimes(mon.heartbeatFile, now, now)
+	if errors.Is(err, os.ErrNotExist) {
+		// #nosec G306, the heartbeat is no secret and has to be readable by virt-handler
+		err = os.WriteFile(mon.heartbeatFile, nil, 0644)
+	}
 	if err != nil {
-		return false, false, err
+		log.Log.Reason(err).Warningf("Unable to update the heartbeat file %s", mon.heartbeatFile)
 	}
-	if exists == false {
-		return false, false, nil
+}
+
+func (mon *monitor) removeHeartbeat() {
+	if mon.heartbeatFile == "" {
+		return
 	}
+	if err := os.Remove(mon.heartbeatFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Log.Reason(err).Warningf("Unable to remove the heartbeat file %s", mon.heartbeatFile)
+	}
+}
 
-	dataBytes, err := os.ReadFile(pathStatus)
+// killProcessAndGroup sends SIGKILL to the process and to its process group,
+// unless the group is the one of the launcher itself.
+func killProcessAndGroup(pid int) {
+	pgid, err := syscall.Getpgid(pid)
 	if err != nil {
+		log.Log.Reason(err).Errorf("Unable to get the process group of pid %d", pid)
+	} else if pgid > 1 && pgid != syscall.Getpgrp() {
+		if err := syscall.Kill(-pgid, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+			log.Log.Reason(err).Errorf("Unable to kill process group %d", pgid)
+		}
+	}
+	if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+		log.Log.Reason(err).Errorf("Unable to kill pid %d", pid)
+	}
+}
+
+// pidExists reports whether the process still exists and whether it already
+// terminated, based on the state field of /proc/<pid>/stat. A terminated
+// process stays in the process table until it is reaped, possibly by a new
+// parent after it got reparented, so it has to be treated as gone.
+func pidExists(pid int) (exists bool, isZombie bool, err error) {
+	// #nosec No risk for path injection. Reading specific entries under /proc
+	content, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, false, nil
+	} else if err != nil {
 		return false, false, err
 	}
 
-	if strings.Contains(string(dataBytes), "Z (zombie)") {
-		isZombie = true
+	// the command name in the second field may contain spaces and parentheses
+	end := strings.LastIndexByte(string(content), ')')
+	fields := strings.Fields(string(content[end+1:]))
+	if end < 0 || len(fields) == 0 {
+		return false, false, fmt.Errorf("malformed stat of pid %d", pid)
 	}
 
-	return exists, isZombie, nil
+	switch fields[0] {
+	case "Z", "X":
+		isZombie = true
+	}
+	return true, isZombie, nil
 }
 
 func FindPid(domainName string, pidDir string) (int, error) {
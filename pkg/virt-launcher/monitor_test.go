@@ -20,7 +20,9 @@
 package virtlauncher
 
 import (
+	"context"
 	"flag"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -31,6 +33,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/google/uuid"
+
+	exitstatus "kubevirt.io/kubevirt/pkg/virt-launcher/exit-status"
 )
 
 var fakeQEMUBinary string
@@ -46,8 +50,9 @@ var _ = Describe("VirtLauncher", func() {
 	var pidDir string
 	var processStarted bool
 
-	StartProcess := func() {
-		cmd = exec.Command(fakeQEMUBinary, "--uuid", uuid.New().String(), "--pidfile", filepath.Join(pidDir, "fakens_fakevmi.pid"))
+	StartProcess := func(extraArgs ...string) {
+		args := append([]string{"--uuid", uuid.New().String(), "--pidfile", filepath.Join(pidDir, "fakens_fakevmi.pid")}, extraArgs...)
+		cmd = exec.Command(fakeQEMUBinary, args...)
 		err := cmd.Start()
 		Expect(err).ToNot(HaveOccurred())
 
@@ -100,7 +105,11 @@ var _ = Describe("VirtLauncher", func() {
 		mon = &monitor{
 			domainName:               "fakens_fakevmi",
 			pidDir:                   pidDir,
+			reapedDir:                GinkgoT().TempDir(),
+			exitStatusFile:           filepath.Join(pidDir, exitstatus.FileName),
+			heartbeatFile:            filepath.Join(pidDir, "heartbeat"),
 			gracePeriod:              30,
+			killGracePeriod:          10,
 			finalShutdownCallback:    shutdownCallback,
 			gracefulShutdownCallback: gracefulShutdownCallback,
 		}
@@ -132,6 +141,48 @@ var _ = Describe("VirtLauncher", func() {
 				cmd.Wait()
 			})
 
+			It("verify the process of the domain is found among processes of the same binary", func() {
+				other := exec.Command(fakeQEMUBinary, "--uuid", uuid.New().String(), "--pidfile", filepath.Join(pidDir, "fakens_othervmi.pid"))
+				Expect(other.Start()).To(Succeed())
+				defer func() {
+					other.Process.Kill()
+					other.Wait()
+				}()
+				StartProcess()
+
+				VerifyProcessStarted()
+				Expect(mon.pid).To(Equal(cmd.Process.Pid))
+			})
+
+			It("verify a terminated process which was not reaped yet is treated as gone", func() {
+				StartProcess()
+				VerifyProcessStarted()
+				pid := mon.pid
+				Expect(cmd.Process.Signal(syscall.SIGKILL)).To(Succeed())
+				processStarted = false
+
+				// the test does not wait for its child, which keeps it a zombie
+				Eventually(func() bool {
+					exists, isZombie, err := pidExists(pid)
+					Expect(err).ToNot(HaveOccurred())
+					return exists && isZombie
+				}).WithTimeout(3 * time.Second).Should(BeTrue())
+				VerifyProcessStopped()
+			})
+
+			It("verify a missing process is reported as gone", func() {
+				StartProcess()
+				VerifyProcessStarted()
+				pid := mon.pid
+				StopProcess()
+				cmd.Wait()
+
+				exists, isZombie, err := pidExists(pid)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exists).To(BeFalse())
+				Expect(isZombie).To(BeFalse())
+			})
+
 			It("verify start timeout works", func() {
 				stopChan := make(chan struct{})
 				done := make(chan string)
@@ -144,13 +195,92 @@ var _ = Describe("VirtLauncher", func() {
 				Eventually(done).WithTimeout(3 * time.Second).WithPolling(100 * time.Millisecond).Should(Receive())
 			})
 
+			It("verify monitor loop exits without a shutdown when the context is cancelled", func() {
+				StartProcess()
+				VerifyProcessStarted()
+				ctx, cancel := context.WithCancel(context.Background())
+				done := make(chan string)
+
+				go func() {
+					defer GinkgoRecover()
+					mon.RunForeverCtx(ctx, 0, make(chan struct{}))
+					done <- "exit"
+				}()
+
+				Consistently(done).WithTimeout(2 * time.Second).ShouldNot(Receive())
+				cancel()
+
+				Eventually(done).WithTimeout(3 * time.Second).WithPolling(100 * time.Millisecond).Should(Receive())
+				Expect(gracefulShutdownChannel).ToNot(BeClosed())
+				Expect(syscall.Kill(cmd.Process.Pid, 0)).To(Succeed())
+			})
+
+			It("verify the monitor loop keeps its heartbeat up to date", func() {
+				StartProcess()
+				VerifyProcessStarted()
+				ctx, cancel := context.WithCancel(context.Background())
+				done := make(chan string)
+
+				go func() {
+					defer GinkgoRecover()
+					mon.RunForeverCtx(ctx, 0, make(chan struct{}))
+					done <- "exit"
+				}()
+
+				var firstBeat time.Time
+				Eventually(func() error {
+					info, err := os.Stat(mon.heartbeatFile)
+					if err == nil {
+						firstBeat = info.ModTime()
+					}
+					return err
+				}).WithTimeout(2 * time.Second).Should(Succeed())
+				Eventually(func() time.Time {
+					info, err := os.Stat(mon.heartbeatFile)
+					Expect(err).ToNot(HaveOccurred())
+					return info.ModTime()
+				}).WithTimeout(3 * time.Second).Should(BeTemporally(">", firstBeat))
+
+				cancel()
+				Eventually(done).WithTimeout(3 * time.Second).WithPolling(100 * time.Millisecond).Should(Receive())
+				Expect(mon.heartbeatFile).ToNot(BeAnExistingFile())
+			})
+
+			It("verify a process which starts late is still found within the start timeout", func() {
+				done := make(chan string)
+
+				go func() {
+					defer GinkgoRecover()
+					mon.RunForever(time.Second, make(chan struct{}))
+					done <- "exit"
+				}()
+
+				time.Sleep(500 * time.Millisecond)
+				StartProcess()
+
+				// the loop would give up after the start timeout if it did not find the process
+				Consistently(done).WithTimeout(2 * time.Second).ShouldNot(Receive())
+				StopProcess()
+				cmd.Wait()
+				Eventually(done).WithTimeout(3 * time.Second).WithPolling(100 * time.Millisecond).Should(Receive())
+			})
+
+			DescribeTable("verify the refresh interval", func(current time.Duration, pidFound bool, expected time.Duration) {
+				Expect(nextRefreshInterval(current, pidFound)).To(Equal(expected))
+			},
+				Entry("stays short while the process is missing", 400*time.Millisecond, false, minRefreshInterval),
+				Entry("backs off once the process is found", minRefreshInterval, true, 2*minRefreshInterval),
+				Entry("is capped", 800*time.Millisecond, true, maxRefreshInterval),
+				Entry("stays at the cap", maxRefreshInterval, true, maxRefreshInterval),
+			)
+
 			It("verify monitor loop exits when signal arrives and no pid is present", func() {
 				stopChan := make(chan struct{})
 				done := make(chan string)
 
 				go func() {
 					defer GinkgoRecover()
-					mon.monitorLoop(1*time.Second, stopChan)
+					mon.monitorLoop(context.Background(), 1*time.Second, stopChan)
 					done <- "exit"
 				}()
 
@@ -171,7 +301,7 @@ var _ = Describe("VirtLauncher", func() {
 
 				go func() {
 					defer GinkgoRecover()
-					mon.monitorLoop(1*time.Second, stopChan)
+					mon.monitorLoop(context.Background(), 1*time.Second, stopChan)
 					done <- "exit"
 				}()
 
@@ -189,13 +319,101 @@ var _ = Describe("VirtLauncher", func() {
 				go func() {
 					defer GinkgoRecover()
 					mon.gracePeriod = 1
-					mon.monitorLoop(1*time.Second, stopChan)
+					mon.monitorLoop(context.Background(), 1*time.Second, stopChan)
 					done <- "exit"
 				}()
 
 				close(stopChan)
 				Eventually(done).WithTimeout(10 * time.Second).WithPolling(100 * time.Millisecond).Should(Receive())
 			})
+
+			It("verify SIGKILL is sent when the process ignores SIGTERM after the grace period", func() {
+				stopChan := make(chan struct{})
+				done := make(chan string)
+
+				StartProcess("--ignore-sigterm")
+				VerifyProcessStarted()
+				go func() {
+					defer GinkgoRecover()
+					mon.gracePeriod = 1
+					mon.killGracePeriod = 1
+					mon.monitorLoop(context.Background(), 1*time.Second, stopChan)
+					done <- "exit"
+				}()
+
+				close(stopChan)
+				Eventually(done).WithTimeout(15 * time.Second).WithPolling(100 * time.Millisecond).Should(Receive())
+
+				err := cmd.Wait()
+				processStarted = false
+				Expect(err).To(HaveOccurred())
+				status, ok := cmd.ProcessState.Sys().(syscall.WaitStatus)
+				Expect(ok).To(BeTrue())
+				Expect(status.Signaled()).To(BeTrue())
+				Expect(status.Signal()).To(Equal(syscall.SIGKILL))
+			})
+
+			It("verify the exit code of the process is recorded", func() {
+				StartProcess("--exit-code", "3")
+				VerifyProcessStarted()
+				Expect(cmd.Process.Signal(syscall.SIGTERM)).To(Succeed())
+				// the fake qemu takes a second to exit after SIGTERM
+				Eventually(func() bool {
+					mon.refresh()
+					return mon.pid == 0 && mon.isDone
+				}).WithTimeout(5 * time.Second).Should(BeTrue())
+
+				Expect(exitstatus.Read(mon.exitStatusFile)).To(Equal(&exitstatus.ExitStatus{ExitCode: 3}))
+			})
+
+			It("verify the exit code of the process is returned", func() {
+				StartProcess("--exit-code", "3")
+				done := make(chan *exitstatus.ExitStatus)
+
+				go func() {
+					defer GinkgoRecover()
+					done <- mon.RunForever(0, make(chan struct{}))
+				}()
+
+				// give the loop time to find the process before it exits
+				time.Sleep(500 * time.Millisecond)
+				Expect(cmd.Process.Signal(syscall.SIGTERM)).To(Succeed())
+				processStarted = false
+
+				var status *exitstatus.ExitStatus
+				Eventually(done).WithTimeout(5 * time.Second).Should(Receive(&status))
+				Expect(status).To(Equal(&exitstatus.ExitStatus{ExitCode: 3}))
+				Expect(status.Failed()).To(BeTrue())
+				Expect(mon.ExitStatus()).To(Equal(status))
+			})
+
+			It("verify the signal which killed the process is recorded", func() {
+				StartProcess()
+				VerifyProcessStarted()
+				// the fake qemu is a go binary, which turns most fatal signals into an exit code
+				Expect(cmd.Process.Signal(syscall.SIGKILL)).To(Succeed())
+				VerifyProcessStopped()
+
+				status, err := exitstatus.Read(mon.exitStatusFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status).To(Equal(&exitstatus.ExitStatus{Signal: int(syscall.SIGKILL)}))
+				Expect(status.String()).To(Equal("qemu exited with signal 9 (killed)"))
+			})
+
+			It("verify the exit status recorded by the reaper is picked up", func() {
+				StartProcess()
+				VerifyProcessStarted()
+				pid := mon.pid
+				StopProcess()
+				cmd.Wait()
+				reapedPath := exitstatus.ReapedPath(mon.reapedDir, pid)
+				Expect(exitstatus.Write(reapedPath, &exitstatus.ExitStatus{ExitCode: 1})).To(Succeed())
+				VerifyProcessStopped()
+
+				Expect(exitstatus.Read(mon.exitStatusFile)).To(Equal(&exitstatus.ExitStatus{ExitCode: 1}))
+				_, err := os.Stat(reapedPath)
+				Expect(err).To(MatchError(os.ErrNotExist))
+			})
 		})
 	})
 })
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"kubevirt.io/client-go/log"
 
@@ -102,6 +103,43 @@ type AgentInfo struct {
 	SupportedCommands []v1.GuestAgentCommandInfo `json:"supported_commands,omitempty"`
 }
 
+// SupportsCommand reports whether the agent advertises the command as enabled
+func (a AgentInfo) SupportsCommand(name string) bool {
+	for _, command := range a.SupportedCommands {
+		if command.Name == name {
+			return command.Enabled
+		}
+	}
+	return false
+}
+
+// advertisesCommand reports whether the agent knows the command at all, enabled or not.
+// The agent disables most commands while the filesystems are frozen, so a disabled
+// command may work again once they are thawed.
+func (a AgentInfo) advertisesCommand(name string) bool {
+	for _, command := range a.SupportedCommands {
+		if command.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRequiredCommands returns an error naming every required command
+// which the agent does not support
+func (a AgentInfo) ValidateRequiredCommands(required []string) error {
+	var missing []string
+	for _, name := range required {
+		if !a.SupportsCommand(name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("guest agent %s does not support the required commands: %s", a.Version, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // parseGuestOSInfo parse agent reply string, extract guest os info
 // and converts the response to API domain guest os info
 func parseGuestOSInfo(agentReply string) (api.GuestOSInfo, error) {
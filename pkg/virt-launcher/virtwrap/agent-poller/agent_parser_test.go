@@ -234,6 +234,37 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(parseAgent(jsonInput)).To(Equal(expectedAgent))
 		})
 
+		Context("with an agent missing a required command", func() {
+			jsonInput := `{
+                "return":{
+                    "version":"2.5",
+                    "supported_commands":[
+                        {"name":"guest-info","enabled":true},
+                        {"name":"guest-get-host-name","enabled":true},
+                        {"name":"guest-get-users","enabled":false}
+                    ]
+                }
+            }`
+
+			It("should report which commands are supported", func() {
+				agent, err := parseAgent(jsonInput)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(agent.SupportsCommand("guest-get-host-name")).To(BeTrue())
+				Expect(agent.SupportsCommand("guest-get-users")).To(BeFalse(), "disabled commands are not supported")
+				Expect(agent.SupportsCommand("guest-get-osinfo")).To(BeFalse())
+			})
+
+			It("should fail the validation naming the missing commands", func() {
+				agent, err := parseAgent(jsonInput)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(agent.ValidateRequiredCommands([]string{"guest-info", "guest-get-host-name"})).To(Succeed())
+				Expect(agent.ValidateRequiredCommands([]string{"guest-info", "guest-get-osinfo", "guest-get-users"})).To(
+					MatchError("guest agent 2.5 does not support the required commands: guest-get-osinfo, guest-get-users"))
+			})
+		})
+
 		It("should strip Agent response", func() {
 			jsonInput := `{"return":{"version":"4.1"}}`
 
@@ -259,6 +290,14 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(parseTimezone(jsonInput)).To(Equal(expectedTimezone))
 		})
 
+		DescribeTable("should keep the sign of the Timezone offset", func(jsonInput string, expectedTimezone api.Timezone) {
+			Expect(parseTimezone(jsonInput)).To(Equal(expectedTimezone))
+		},
+			Entry("east of UTC", `{"return":{"zone":"CET","offset":3600}}`, api.Timezone{Zone: "CET", Offset: 3600}),
+			Entry("west of UTC", `{"return":{"zone":"EST","offset":-18000}}`, api.Timezone{Zone: "EST", Offset: -18000}),
+			Entry("without a zone", `{"return":{"offset":0}}`, api.Timezone{Offset: 0}),
+		)
+
 		It("should parse Filesystem", func() {
 
 			jsonInput := `{
@@ -318,5 +357,27 @@ var _ = Describe("Qemu agent poller", func() {
 			}
 			Expect(parseUsers(jsonInput)).To(Equal(expectedUsers))
 		})
+
+		It("should parse multiple Users", func() {
+
+			jsonInput := `{
+                "return":[
+                    {"user":"root","login-time":1700000200.5},
+                    {"user":"admin","domain":"CORP","login-time":1700000100.25},
+                    {"user":"fedora","login-time":1700000300}
+                ]
+            }`
+
+			expectedUsers := []api.User{
+				{Name: "root", LoginTime: 1700000200.5},
+				{Name: "admin", Domain: "CORP", LoginTime: 1700000100.25},
+				{Name: "fedora", LoginTime: 1700000300},
+			}
+			Expect(parseUsers(jsonInput)).To(Equal(expectedUsers))
+		})
+
+		It("should parse no Users", func() {
+			Expect(parseUsers(`{"return":[]}`)).To(BeEmpty())
+		})
 	})
 })
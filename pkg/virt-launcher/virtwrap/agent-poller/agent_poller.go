@@ -340,7 +340,7 @@ func (p *AgentPoller) Stop() {
 
 // With libvirt 5.6.0 direct call to agent can be replaced with call to libvirt Domain.GetGuestInfo
 func executeAgentCommands(commands []AgentCommand, con cli.Connection, agentStore *AsyncAgentStore, domainName string) {
-	for _, command := range commands {
+	for _, command := range supportedCommands(commands, agentStore.GetGA()) {
 		// replace with direct call to libvirt function when 5.6.0 is available
 		cmdResult, err := con.QemuAgentCommand(`{"execute":"`+string(command)+`"}`, domainName)
 		if err != nil {
@@ -410,3 +410,24 @@ func executeAgentCommands(commands []AgentCommand, con cli.Connection, agentStor
 		}
 	}
 }
+
+// supportedCommands drops the commands the agent does not know, so old agents
+// are not asked for data they cannot provide.
+// Commands the agent reports as disabled are kept, the agent information is only
+// polled every few minutes and may have been taken while the filesystems were frozen.
+// Until the agent information has been polled all the commands are kept.
+func supportedCommands(commands []AgentCommand, agent AgentInfo) []AgentCommand {
+	if len(agent.SupportedCommands) == 0 {
+		return commands
+	}
+
+	var supported []AgentCommand
+	for _, command := range commands {
+		if command != GET_AGENT && !agent.advertisesCommand(string(command)) {
+			log.Log.V(3).Infof("Skipping guest agent command %s, it is not supported by agent version %s", command, agent.Version)
+			continue
+		}
+		supported = append(supported, command)
+	}
+	return supported
+}
@@ -22,10 +22,14 @@ package agentpoller
 import (
 	"time"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	v1 "kubevirt.io/api/core/v1"
+
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 )
 
 var _ = Describe("Qemu agent poller", func() {
@@ -172,6 +176,45 @@ var _ = Describe("Qemu agent poller", func() {
 		})
 	})
 
+	Context("filtering the agent commands", func() {
+		commands := []AgentCommand{GET_AGENT, GET_INTERFACES, GET_OSINFO, GET_HOSTNAME}
+
+		It("should keep all the commands while the agent information is unknown", func() {
+			Expect(supportedCommands(commands, AgentInfo{})).To(Equal(commands))
+		})
+
+		It("should skip the commands the agent does not support", func() {
+			agent := AgentInfo{
+				Version: "2.5",
+				SupportedCommands: []v1.GuestAgentCommandInfo{
+					{Name: string(GET_INTERFACES), Enabled: true},
+					{Name: string(GET_HOSTNAME), Enabled: true},
+				},
+			}
+			Expect(supportedCommands(commands, agent)).To(Equal([]AgentCommand{GET_AGENT, GET_INTERFACES, GET_HOSTNAME}))
+		})
+
+		It("should keep polling the commands the agent disabled while the filesystems were frozen", func() {
+			const domainName = "test-domain"
+			agentStore := NewAsyncAgentStore()
+			// the agent information was polled while the filesystems were frozen
+			agentStore.Store(GET_AGENT, AgentInfo{
+				Version: "2.5",
+				SupportedCommands: []v1.GuestAgentCommandInfo{
+					{Name: string(GET_AGENT), Enabled: true},
+					{Name: string(GET_HOSTNAME), Enabled: false},
+				},
+			})
+
+			// once thawed, the hostname is polled again before the agent information is refreshed
+			conn := cli.NewMockConnection(gomock.NewController(GinkgoT()))
+			conn.EXPECT().QemuAgentCommand(`{"execute":"`+string(GET_HOSTNAME)+`"}`, domainName).Return(`{"return":{"host-name":"guest"}}`, nil)
+
+			executeAgentCommands([]AgentCommand{GET_HOSTNAME}, conn, &agentStore, domainName)
+			Expect(agentStore.GetSysInfo().Hostname).To(Equal("guest"))
+		})
+	})
+
 	Context("PollerWorker", func() {
 		It("executes the agent commands at least once", func() {
 			const interval = 1
@@ -0,0 +1,149 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package cmdserver
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"kubevirt.io/client-go/log"
+)
+
+const (
+	// defaultMaxInFlightRequests caps the requests the server works on at
+	// the same time, further requests fail fast instead of queuing up
+	// behind a hung libvirt call.
+	defaultMaxInFlightRequests = 8
+	// defaultMaxInFlightScrapeRequests caps the metrics scrape requests,
+	// which are limited separately.
+	defaultMaxInFlightScrapeRequests = 2
+	// defaultRequestTimeout bounds requests which don't carry a deadline.
+	defaultRequestTimeout = 5 * time.Minute
+)
+
+// unlimitedMethods are cheap, don't touch libvirt and are used by
+// virt-handler to tell whether the launcher is alive, so they are always
+// served.
+var unlimitedMethods = map[string]bool{
+	"/kubevirt.cmd.v1.Cmd/Ping":       true,
+	"/kubevirt.cmd.info.CmdInfo/Info": true,
+}
+
+// lifecycleMethods stop and remove the domain. They are needed the most
+// when hung libvirt calls occupy all slots, so they don't count against
+// the limit. They are still bounded by the request timeout.
+var lifecycleMethods = map[string]bool{
+	"/kubevirt.cmd.v1.Cmd/ShutdownVirtualMachine": true,
+	"/kubevirt.cmd.v1.Cmd/KillVirtualMachine":     true,
+	"/kubevirt.cmd.v1.Cmd/DeleteVirtualMachine":   true,
+	"/kubevirt.cmd.v1.Cmd/SignalTargetPodCleanup": true,
+}
+
+// scrapeMethods are polled by the metrics collector of virt-handler. They
+// get slots of their own, so that scrapes can't crowd out the requests
+// which sync the domain.
+var scrapeMethods = map[string]bool{
+	"/kubevirt.cmd.v1.Cmd/GetDomainStats": true,
+	"/kubevirt.cmd.v1.Cmd/GetGuestInfo":   true,
+	"/kubevirt.cmd.v1.Cmd/GetUsers":       true,
+	"/kubevirt.cmd.v1.Cmd/GetFilesystems": true,
+}
+
+type requestLimiter struct {
+	inFlight       chan struct{}
+	scrapeInFlight chan struct{}
+	defaultTimeout time.Duration
+}
+
+func newRequestLimiter(maxInFlight, maxInFlightScrapes int, defaultTimeout time.Duration) *requestLimiter {
+	return &requestLimiter{
+		inFlight:       make(chan struct{}, maxInFlight),
+		scrapeInFlight: make(chan struct{}, maxInFlightScrapes),
+		defaultTimeout: defaultTimeout,
+	}
+}
+
+type handlerResult struct {
+	response interface{}
+	err      error
+}
+
+// slotsFor returns the slots a method counts against, nil if it is not limited.
+func (r *requestLimiter) slotsFor(method string) chan struct{} {
+	switch {
+	case lifecycleMethods[method]:
+		return nil
+	case scrapeMethods[method]:
+		return r.scrapeInFlight
+	default:
+		return r.inFlight
+	}
+}
+
+func (r *requestLimiter) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if unlimitedMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	slots := r.slotsFor(info.FullMethod)
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+		default:
+			log.Log.Warningf("Rejecting %s, %d requests are already in flight", info.FullMethod, cap(slots))
+			return nil, status.Errorf(codes.ResourceExhausted, "server busy: %d requests are already in flight", cap(slots))
+		}
+	}
+
+	// The deadline of the caller is propagated by grpc, only requests
+	// without one get the default
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.defaultTimeout)
+		defer cancel()
+	}
+
+	done := make(chan handlerResult, 1)
+	go func() {
+		// The slot is only given back once the handler returns, a hung
+		// call keeps counting against the limit
+		if slots != nil {
+			defer func() { <-slots }()
+		}
+		response, err := handler(ctx, req)
+		done <- handlerResult{response, err}
+	}()
+
+	select {
+	case result := <-done:
+		return result.response, result.err
+	case <-ctx.Done():
+		if slots != nil {
+			log.Log.Reason(ctx.Err()).Warningf("Giving up on %s, %d of %d requests are in flight", info.FullMethod, len(slots), cap(slots))
+		} else {
+			log.Log.Reason(ctx.Err()).Warningf("Giving up on %s", info.FullMethod)
+		}
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
@@ -38,6 +38,7 @@ import (
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	launcherErrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
 )
 
@@ -46,16 +47,27 @@ const (
 )
 
 type ServerOptions struct {
-	allowEmulation bool
+	allowEmulation            bool
+	qemuPidLookup             func() (int, error)
+	maxInFlightRequests       int
+	maxInFlightScrapeRequests int
+	requestTimeout            time.Duration
 }
 
-func NewServerOptions(allowEmulation bool) *ServerOptions {
-	return &ServerOptions{allowEmulation: allowEmulation}
+func NewServerOptions(allowEmulation bool, qemuPidLookup func() (int, error)) *ServerOptions {
+	return &ServerOptions{
+		allowEmulation:            allowEmulation,
+		qemuPidLookup:             qemuPidLookup,
+		maxInFlightRequests:       defaultMaxInFlightRequests,
+		maxInFlightScrapeRequests: defaultMaxInFlightScrapeRequests,
+		requestTimeout:            defaultRequestTimeout,
+	}
 }
 
 type Launcher struct {
 	domainManager  virtwrap.DomainManager
 	allowEmulation bool
+	qemuPidLookup  func() (int, error)
 }
 
 func getVMIFromRequest(request *cmdv1.VMI) (*v1.VirtualMachineInstance, *cmdv1.Response) {
@@ -580,14 +592,19 @@ func RunServer(socketPath string,
 	options *ServerOptions) (chan struct{}, error) {
 
 	allowEmulation := false
+	var qemuPidLookup func() (int, error)
+	limiter := newRequestLimiter(defaultMaxInFlightRequests, defaultMaxInFlightScrapeRequests, defaultRequestTimeout)
 	if options != nil {
 		allowEmulation = options.allowEmulation
+		qemuPidLookup = options.qemuPidLookup
+		limiter = newRequestLimiter(options.maxInFlightRequests, options.maxInFlightScrapeRequests, options.requestTimeout)
 	}
 
-	grpcServer := grpc.NewServer([]grpc.ServerOption{}...)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(limiter.intercept))
 	server := &Launcher{
 		domainManager:  domainManager,
 		allowEmulation: allowEmulation,
+		qemuPidLookup:  qemuPidLookup,
 	}
 	registerInfoServer(grpcServer)
 
@@ -665,6 +682,70 @@ func (l *Launcher) GetSEVInfo(_ context.Context, _ *cmdv1.EmptyRequest) (*cmdv1.
 	return sevInfoResponse, nil
 }
 
+func (l *Launcher) GetLaunchInfo(_ context.Context, _ *cmdv1.EmptyRequest) (*cmdv1.LaunchInfoResponse, error) {
+	launchInfoResponse := &cmdv1.LaunchInfoResponse{
+		Response: &cmdv1.Response{
+			Success: true,
+		},
+	}
+
+	list, err := l.domainManager.ListAllDomains()
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to list domains")
+		launchInfoResponse.Response.Success = false
+		launchInfoResponse.Response.Message = getErrorMessage(err)
+		return launchInfoResponse, nil
+	}
+
+	launchInfo := &cmdclient.LaunchInfo{}
+	if len(list) > 0 {
+		launchInfo = newLaunchInfo(list[0])
+	}
+
+	if l.qemuPidLookup != nil {
+		// The pid file only exists while qemu is running, report what is known so far otherwise
+		if pid, err := l.qemuPidLookup(); err != nil {
+			log.Log.Reason(err).V(3).Info("Failed to look up the qemu pid")
+		} else {
+			launchInfo.QemuPid = pid
+		}
+	}
+
+	if launchInfoJson, err := json.Marshal(launchInfo); err != nil {
+		log.Log.Reason(err).Errorf("Failed to marshal launch info")
+		launchInfoResponse.Response.Success = false
+		launchInfoResponse.Response.Message = getErrorMessage(err)
+		return launchInfoResponse, nil
+	} else {
+		launchInfoResponse.LaunchInfo = launchInfoJson
+	}
+
+	return launchInfoResponse, nil
+}
+
+func newLaunchInfo(domain *api.Domain) *cmdclient.LaunchInfo {
+	launchInfo := &cmdclient.LaunchInfo{
+		DomainUUID: domain.Spec.UUID,
+	}
+	for _, iface := range domain.Spec.Devices.Interfaces {
+		ifaceInfo := cmdclient.LaunchInterfaceInfo{
+			Type:   iface.Type,
+			Bridge: iface.Source.Bridge,
+		}
+		if iface.Alias != nil {
+			ifaceInfo.Name = iface.Alias.GetName()
+		}
+		if iface.Target != nil {
+			ifaceInfo.Tap = iface.Target.Device
+		}
+		if iface.Backend != nil {
+			ifaceInfo.Backend = iface.Backend.Type
+		}
+		launchInfo.Interfaces = append(launchInfo.Interfaces, ifaceInfo)
+	}
+	return launchInfo
+}
+
 func (l *Launcher) GetLaunchMeasurement(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.LaunchMeasurementResponse, error) {
 	vmi, response := getVMIFromRequest(request.Vmi)
 	launchMeasurementResponse := &cmdv1.LaunchMeasurementResponse{
@@ -29,11 +29,14 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	v1 "kubevirt.io/api/core/v1"
 
 	"kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/info"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	grpcutil "kubevirt.io/kubevirt/pkg/util/net/grpc"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/agent"
@@ -42,6 +45,8 @@ import (
 )
 
 var _ = Describe("Virt remote commands", func() {
+	const fakeQemuPid = 1234
+
 	var domainManager *virtwrap.MockDomainManager
 	var client cmdclient.LauncherClient
 
@@ -66,7 +71,7 @@ var _ = Describe("Virt remote commands", func() {
 		socketPath := filepath.Join(shareDir, "server.sock")
 
 		allowEmulation = true
-		options = NewServerOptions(allowEmulation)
+		options = NewServerOptions(allowEmulation, func() (int, error) { return fakeQemuPid, nil })
 		RunServer(socketPath, domainManager, stop, options)
 		client, err = cmdclient.NewClient(socketPath)
 		Expect(err).ToNot(HaveOccurred())
@@ -325,6 +330,61 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should return the launch info", func() {
+			domain := api.NewMinimalDomain("testvmi")
+			domain.Spec.UUID = "5dbb6ad7-aa02-4cb8-8e5b-e24d2fd1b50b"
+			domain.Spec.Devices.Interfaces = []api.Interface{
+				{
+					Type:   "ethernet",
+					Alias:  api.NewUserDefinedAlias("default"),
+					Target: &api.InterfaceTarget{Device: "tap0", Managed: "no"},
+				},
+				{
+					Type:    "user",
+					Alias:   api.NewUserDefinedAlias("passt"),
+					Backend: &api.InterfaceBackend{Type: "passt"},
+				},
+				{
+					Type:   "bridge",
+					Alias:  api.NewUserDefinedAlias("secondary"),
+					Source: api.InterfaceSource{Bridge: "k6t-net1"},
+				},
+			}
+			domainManager.EXPECT().ListAllDomains().Return([]*api.Domain{domain}, nil)
+
+			launchInfo, err := client.GetLaunchInfo()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(launchInfo).To(Equal(&cmdclient.LaunchInfo{
+				QemuPid:    fakeQemuPid,
+				DomainUUID: "5dbb6ad7-aa02-4cb8-8e5b-e24d2fd1b50b",
+				Interfaces: []cmdclient.LaunchInterfaceInfo{
+					{Name: "default", Type: "ethernet", Tap: "tap0"},
+					{Name: "passt", Type: "user", Backend: "passt"},
+					{Name: "secondary", Type: "bridge", Bridge: "k6t-net1"},
+				},
+			}))
+		})
+
+		It("should return the launch info without a qemu pid while qemu is not running", func() {
+			server := &Launcher{
+				domainManager: domainManager,
+				qemuPidLookup: func() (int, error) { return 0, os.ErrNotExist },
+			}
+			domainManager.EXPECT().ListAllDomains().Return([]*api.Domain{}, nil)
+
+			response, err := server.GetLaunchInfo(context.TODO(), &cmdv1.EmptyRequest{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.GetResponse().GetSuccess()).To(BeTrue())
+			Expect(response.GetLaunchInfo()).To(MatchJSON(`{}`))
+		})
+
+		It("should fail to return the launch info when the domains can't be listed", func() {
+			domainManager.EXPECT().ListAllDomains().Return(nil, errors.New("connection lost"))
+
+			_, err := client.GetLaunchInfo()
+			Expect(err).To(MatchError(ContainSubstring("connection lost")))
+		})
+
 		It("should call UpdateGuestMemory", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			domainManager.EXPECT().UpdateGuestMemory(vmi).Return(nil)
@@ -438,6 +498,109 @@ var _ = Describe("Virt remote commands", func() {
 
 	})
 
+	Context("with request limits", func() {
+		var limitedClient cmdv1.CmdClient
+		var limitedLauncherClient cmdclient.LauncherClient
+		var release chan struct{}
+
+		BeforeEach(func() {
+			release = make(chan struct{})
+
+			limitedOptions := NewServerOptions(allowEmulation, nil)
+			limitedOptions.maxInFlightRequests = 1
+			limitedOptions.requestTimeout = 200 * time.Millisecond
+			socketPath := filepath.Join(shareDir, "limited-server.sock")
+			_, err := RunServer(socketPath, domainManager, stop, limitedOptions)
+			Expect(err).ToNot(HaveOccurred())
+
+			conn, err := grpcutil.DialSocket(socketPath)
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(conn.Close)
+			limitedClient = cmdv1.NewCmdClient(conn)
+
+			limitedLauncherClient, err = cmdclient.NewClient(socketPath)
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(limitedLauncherClient.Close)
+		})
+
+		AfterEach(func() {
+			select {
+			case <-release:
+			default:
+				close(release)
+			}
+		})
+
+		expectHungGetQemuVersion := func() chan struct{} {
+			entered := make(chan struct{})
+			domainManager.EXPECT().GetQemuVersion().DoAndReturn(func() (string, error) {
+				close(entered)
+				<-release
+				return "7.2.0", nil
+			})
+			return entered
+		}
+
+		It("should time out requests without a deadline", func() {
+			expectHungGetQemuVersion()
+
+			_, err := limitedClient.GetQemuVersion(context.Background(), &cmdv1.EmptyRequest{})
+			Expect(status.Code(err)).To(Equal(codes.DeadlineExceeded))
+		})
+
+		It("should honour the deadline of the caller", func() {
+			expectHungGetQemuVersion()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			_, err := limitedClient.GetQemuVersion(ctx, &cmdv1.EmptyRequest{})
+			Expect(status.Code(err)).To(Equal(codes.DeadlineExceeded))
+			Expect(time.Since(start)).To(BeNumerically("<", 200*time.Millisecond))
+		})
+
+		It("should reject requests while hung requests occupy all slots", func() {
+			entered := expectHungGetQemuVersion()
+			hungErr := make(chan error, 1)
+			go func() {
+				_, err := limitedClient.GetQemuVersion(context.Background(), &cmdv1.EmptyRequest{})
+				hungErr <- err
+			}()
+			Eventually(entered).Should(BeClosed())
+
+			_, err := limitedClient.GetSEVInfo(context.Background(), &cmdv1.EmptyRequest{})
+			Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+
+			By("keeping the slot occupied after the hung request timed out")
+			Eventually(hungErr).Should(Receive(WithTransform(status.Code, Equal(codes.DeadlineExceeded))))
+			_, err = limitedClient.GetSEVInfo(context.Background(), &cmdv1.EmptyRequest{})
+			Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+
+			By("still answering pings")
+			response, err := limitedClient.Ping(context.Background(), &cmdv1.EmptyRequest{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.GetSuccess()).To(BeTrue())
+
+			By("still serving lifecycle requests")
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().KillVMI(vmi)
+			Expect(limitedLauncherClient.KillVirtualMachine(vmi)).To(Succeed())
+
+			By("serving scrapes from their own slots")
+			domainManager.EXPECT().GetDomainStats().Return(&stats.DomainStats{}, nil)
+			_, _, err = limitedLauncherClient.GetDomainStats()
+			Expect(err).ToNot(HaveOccurred())
+
+			By("serving requests again once the hung request returned")
+			close(release)
+			domainManager.EXPECT().GetSEVInfo().Return(&v1.SEVPlatformInfo{}, nil)
+			Eventually(func() error {
+				_, err := limitedClient.GetSEVInfo(context.Background(), &cmdv1.EmptyRequest{})
+				return err
+			}).Should(Succeed())
+		})
+	})
+
 	Describe("Version mismatch", func() {
 
 		var err error
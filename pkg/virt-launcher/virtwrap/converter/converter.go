@@ -233,6 +233,11 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 			disk.FilesystemOverhead = volumeStatus.PersistentVolumeClaimInfo.FilesystemOverhead
 			disk.Capacity = storagetypes.GetDiskCapacity(volumeStatus.PersistentVolumeClaimInfo)
 			disk.ExpandDisksEnabled = c.ExpandDisksEnabled
+			if disk.ReadOnly == nil && isReadOnlyManyOnly(volumeStatus.PersistentVolumeClaimInfo.AccessModes) {
+				log.Log.Infof("Disk %s is backed by the ReadOnlyMany claim %s, setting it readonly",
+					diskDevice.Name, volumeStatus.PersistentVolumeClaimInfo.ClaimName)
+				disk.ReadOnly = toApiReadOnly(true)
+			}
 		}
 	}
 	if numQueues != nil && disk.Target.Bus == v1.DiskBusVirtio {
@@ -560,6 +565,20 @@ func FormatDeviceName(prefix string, index int) string {
 	return prefix + name
 }
 
+// isReadOnlyManyOnly returns true when the claim can only be mounted readonly,
+// as a guest write on such a disk would fail with I/O errors.
+func isReadOnlyManyOnly(accessModes []k8sv1.PersistentVolumeAccessMode) bool {
+	if len(accessModes) == 0 {
+		return false
+	}
+	for _, accessMode := range accessModes {
+		if accessMode != k8sv1.ReadOnlyMany {
+			return false
+		}
+	}
+	return true
+}
+
 func toApiReadOnly(src bool) *api.ReadOnly {
 	if src {
 		return &api.ReadOnly{}
@@ -820,15 +839,16 @@ func Convert_v1_DownwardMetricSource_To_api_Disk(disk *api.Disk, c *ConverterCon
 	return nil
 }
 
-func Convert_v1_EmptyDiskSource_To_api_Disk(volumeName string, _ *v1.EmptyDiskSource, disk *api.Disk) error {
+func Convert_v1_EmptyDiskSource_To_api_Disk(volumeName string, source *v1.EmptyDiskSource, disk *api.Disk) error {
 	if disk.Type == "lun" {
 		return fmt.Errorf(deviceTypeNotCompatibleFmt, disk.Alias.GetName())
 	}
 
 	disk.Type = "file"
-	disk.Driver.Type = "qcow2"
+	format := emptydisk.Format(source)
+	disk.Driver.Type = string(format)
 	disk.Driver.Discard = "unmap"
-	disk.Source.File = emptydisk.NewEmptyDiskCreator().FilePathForVolumeName(volumeName)
+	disk.Source.File = emptydisk.NewEmptyDiskCreator().FilePathForVolumeName(volumeName, format)
 	disk.Driver.ErrorPolicy = v1.DiskErrorPolicyStop
 
 	return nil
@@ -1370,6 +1390,22 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 				Value: c.SMBios.Version,
 			},
 		)
+		// A serial set in the VMI firmware has already been added and takes precedence
+		if c.SMBios.Serial != "" && (vmi.Spec.Domain.Firmware == nil || vmi.Spec.Domain.Firmware.Serial == "") {
+			domain.Spec.SysInfo.System = append(domain.Spec.SysInfo.System, api.Entry{
+				Name:  "serial",
+				Value: c.SMBios.Serial,
+			})
+		}
+		// A chassis set in the VMI spec replaces this one below
+		if c.SMBios.AssetTag != "" {
+			domain.Spec.SysInfo.Chassis = []api.Entry{
+				{
+					Name:  "asset",
+					Value: c.SMBios.AssetTag,
+				},
+			}
+		}
 	}
 
 	// Take SMBios values from the VirtualMachineOptions
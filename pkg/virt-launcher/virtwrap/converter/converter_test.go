@@ -164,6 +164,43 @@ var _ = Describe("Converter", func() {
 			Entry("Lower request than capacity", int64(1111), int64(9999)),
 		)
 
+		DescribeTable("Should set the disk readonly according to the claim access modes", func(diskDevice v1.DiskDevice, accessModes []k8sv1.PersistentVolumeAccessMode, expectReadOnly bool) {
+			context := &ConverterContext{}
+			v1Disk := v1.Disk{
+				Name:       "myvolume",
+				DiskDevice: diskDevice,
+			}
+			apiDisk := api.Disk{}
+			devicePerBus := map[string]deviceNamer{}
+			numQueues := uint(2)
+			volumeStatusMap := make(map[string]v1.VolumeStatus)
+			volumeStatusMap["myvolume"] = v1.VolumeStatus{
+				PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+					ClaimName:   "myclaim",
+					AccessModes: accessModes,
+				},
+			}
+			Expect(Convert_v1_Disk_To_api_Disk(context, &v1Disk, &apiDisk, devicePerBus, &numQueues, volumeStatusMap)).To(Succeed())
+			if expectReadOnly {
+				Expect(apiDisk.ReadOnly).ToNot(BeNil())
+			} else {
+				Expect(apiDisk.ReadOnly).To(BeNil())
+			}
+		},
+			Entry("disk with a ROX claim", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.VirtIO}},
+				[]k8sv1.PersistentVolumeAccessMode{k8sv1.ReadOnlyMany}, true),
+			Entry("disk with a RWO claim", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.VirtIO}},
+				[]k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}, false),
+			Entry("disk with a RWX and ROX claim", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.VirtIO}},
+				[]k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany, k8sv1.ReadOnlyMany}, false),
+			Entry("disk without access modes", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: v1.VirtIO}},
+				nil, false),
+			Entry("LUN with a ROX claim", v1.DiskDevice{LUN: &v1.LunTarget{Bus: "scsi"}},
+				[]k8sv1.PersistentVolumeAccessMode{k8sv1.ReadOnlyMany}, true),
+			Entry("CDRom with a RWO claim", v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}},
+				[]k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}, true),
+		)
+
 		DescribeTable("Should assign scsi controller to", func(diskDevice v1.DiskDevice) {
 			context := &ConverterContext{}
 			v1Disk := v1.Disk{
@@ -2408,6 +2445,54 @@ var _ = Describe("Converter", func() {
 				"should be capped to the maximum number of queues on tap devices")
 		})
 
+		Context("with per-interface settings", func() {
+			BeforeEach(func() {
+				vmi.Spec.Domain.CPU = &v1.CPU{Cores: 4}
+				vmi.Spec.Networks = append(vmi.Spec.Networks, v1.Network{
+					Name:          "mgmt",
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "mgmt-net"}},
+				})
+				vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces, v1.Interface{
+					Name:                   "mgmt",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+				})
+			})
+
+			It("should not assign queues to an interface opting out", func() {
+				vmi.Spec.Domain.Devices.Interfaces[1].MultiQueue = kubevirtpointer.P(false)
+
+				domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+				Expect(*domain.Spec.Devices.Interfaces[0].Driver.Queues).To(Equal(uint(4)))
+				Expect(domain.Spec.Devices.Interfaces[1].Driver).To(BeNil())
+			})
+
+			It("should assign queues only to the interface opting in", func() {
+				vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = nil
+				vmi.Spec.Domain.Devices.Interfaces[0].MultiQueue = kubevirtpointer.P(true)
+
+				domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+				Expect(*domain.Spec.Devices.Interfaces[0].Driver.Queues).To(Equal(uint(4)))
+				Expect(domain.Spec.Devices.Interfaces[1].Driver).To(BeNil())
+			})
+
+			It("should assign the explicit number of queues", func() {
+				vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = nil
+				vmi.Spec.Domain.Devices.Interfaces[1].Queues = kubevirtpointer.P(uint32(2))
+
+				domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+				Expect(domain.Spec.Devices.Interfaces[0].Driver).To(BeNil())
+				Expect(*domain.Spec.Devices.Interfaces[1].Driver.Queues).To(Equal(uint(2)))
+			})
+
+			It("should not assign queues to a non-virtio interface opting in", func() {
+				vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue = nil
+				vmi.Spec.Domain.Devices.Interfaces[1].Model = "e1000"
+				vmi.Spec.Domain.Devices.Interfaces[1].Queues = kubevirtpointer.P(uint32(2))
+
+				domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+				Expect(domain.Spec.Devices.Interfaces[1].Driver).To(BeNil())
+			})
+		})
 	})
 	Context("Realtime", func() {
 		var vmi *v1.VirtualMachineInstance
@@ -2713,6 +2798,21 @@ var _ = Describe("Converter", func() {
 				Entry("block mode DV", Convert_v1_Hotplug_DataVolume_To_api_Disk, "test-block-dv", true, false),
 				Entry("'discard ignore' DV", Convert_v1_Hotplug_DataVolume_To_api_Disk, "test-discard-ignore", false, true),
 			)
+
+			DescribeTable("should convert an emptyDisk with the driver type matching its format",
+				func(format v1.EmptyDiskFormat, expectedType string) {
+					disk := &api.Disk{
+						Driver: &api.DiskDriver{},
+					}
+					Expect(Convert_v1_EmptyDiskSource_To_api_Disk("testdisk", &v1.EmptyDiskSource{Format: format}, disk)).To(Succeed())
+					Expect(disk.Type).To(Equal("file"))
+					Expect(disk.Driver.Type).To(Equal(expectedType))
+					Expect(disk.Source.File).To(Equal("/var/run/libvirt/empty-disks/testdisk." + expectedType))
+				},
+				Entry("with the default format", v1.EmptyDiskFormat(""), "qcow2"),
+				Entry("with qcow2", v1.EmptyDiskFormatQCOW2, "qcow2"),
+				Entry("with raw", v1.EmptyDiskFormatRaw, "raw"),
+			)
 		})
 
 		Context("memory", func() {
@@ -3039,6 +3139,56 @@ var _ = Describe("Converter", func() {
 			Entry("disabled when not set", false),
 		)
 	})
+
+	Context("with SMBIOS serial and asset tag", func() {
+		var (
+			vmi *v1.VirtualMachineInstance
+			c   *ConverterContext
+		)
+
+		BeforeEach(func() {
+			vmi = kvapi.NewMinimalVMI("testvmi")
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			c = &ConverterContext{
+				AllowEmulation: true,
+				Architecture:   "amd64",
+				SMBios:         &cmdv1.SMBios{Manufacturer: "KubeVirt", Serial: "SN-0042", AssetTag: "rack-42"},
+			}
+		})
+
+		It("should place them in the sysinfo of the domain XML", func() {
+			spec := vmiToDomainXMLToDomainSpec(vmi, c)
+			Expect(spec.OS.SMBios).To(Equal(&api.SMBios{Mode: "sysinfo"}))
+			Expect(spec.SysInfo.System).To(ContainElement(api.Entry{Name: "serial", Value: "SN-0042"}))
+			Expect(spec.SysInfo.Chassis).To(Equal([]api.Entry{{Name: "asset", Value: "rack-42"}}))
+		})
+
+		It("should not add them when they are empty", func() {
+			c.SMBios = &cmdv1.SMBios{Manufacturer: "KubeVirt"}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.SysInfo.System).ToNot(ContainElement(HaveField("Name", "serial")))
+			Expect(domain.Spec.SysInfo.Chassis).To(BeEmpty())
+		})
+
+		It("should prefer the serial of the VMI firmware", func() {
+			vmi.Spec.Domain.Firmware = &v1.Firmware{Serial: "firmware-serial"}
+			domain := vmiToDomain(vmi, c)
+			serials := []api.Entry{}
+			for _, entry := range domain.Spec.SysInfo.System {
+				if entry.Name == "serial" {
+					serials = append(serials, entry)
+				}
+			}
+			Expect(serials).To(Equal([]api.Entry{{Name: "serial", Value: "firmware-serial"}}))
+		})
+
+		It("should prefer the chassis of the VMI", func() {
+			vmi.Spec.Domain.Chassis = &v1.Chassis{Asset: "vmi-asset"}
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.SysInfo.Chassis).To(ContainElement(api.Entry{Name: "asset", Value: "vmi-asset"}))
+			Expect(domain.Spec.SysInfo.Chassis).ToNot(ContainElement(api.Entry{Name: "asset", Value: "rack-42"}))
+		})
+	})
 })
 
 var _ = Describe("disk device naming", func() {
@@ -60,7 +60,7 @@ func CreateDomainInterfaces(vmi *v1.VirtualMachineInstance, c *ConverterContext)
 			Alias: api.NewUserDefinedAlias(iface.Name),
 		}
 
-		if queueCount := uint(CalculateNetworkQueues(vmi, ifaceType)); queueCount != 0 {
+		if queueCount := uint(CalculateNetworkQueues(vmi, &nonAbsentIfaces[i])); queueCount != 0 {
 			domainIface.Driver = &api.InterfaceDriver{Name: "vhost", Queues: &queueCount}
 		}
 
@@ -121,24 +121,40 @@ func indexNetworksByName(networks []v1.Network) map[string]*v1.Network {
 	return netsByName
 }
 
-func CalculateNetworkQueues(vmi *v1.VirtualMachineInstance, ifaceType string) uint32 {
-	if ifaceType != v1.VirtIO {
+// CalculateNetworkQueues returns the number of queues of the interface.
+// The MultiQueue and Queues of the interface take precedence over the
+// domain-wide NetworkInterfaceMultiQueue.
+func CalculateNetworkQueues(vmi *v1.VirtualMachineInstance, iface *v1.Interface) uint32 {
+	if GetInterfaceType(iface) != v1.VirtIO {
 		return 0
 	}
-	return NetworkQueuesCapacity(vmi)
+	if iface.MultiQueue != nil && !*iface.MultiQueue {
+		return 0
+	}
+	if iface.Queues != nil {
+		return capNetworkQueues(*iface.Queues)
+	}
+	if iface.MultiQueue == nil {
+		return NetworkQueuesCapacity(vmi)
+	}
+	return capNetworkQueues(requestedVCPUs(vmi))
 }
 
 func NetworkQueuesCapacity(vmi *v1.VirtualMachineInstance) uint32 {
 	if !isTrue(vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue) {
 		return 0
 	}
+	return capNetworkQueues(requestedVCPUs(vmi))
+}
 
-	cpuTopology := vcpu.GetCPUTopology(vmi)
-	queueNumber := vcpu.CalculateRequestedVCPUs(cpuTopology)
+func requestedVCPUs(vmi *v1.VirtualMachineInstance) uint32 {
+	return vcpu.CalculateRequestedVCPUs(vcpu.GetCPUTopology(vmi))
+}
 
+func capNetworkQueues(queueNumber uint32) uint32 {
 	if queueNumber > multiQueueMaxQueues {
 		log.Log.V(3).Infof("Capped the number of queues to be the current maximum of tap device queues: %d", multiQueueMaxQueues)
-		queueNumber = multiQueueMaxQueues
+		return multiQueueMaxQueues
 	}
 	return queueNumber
 }
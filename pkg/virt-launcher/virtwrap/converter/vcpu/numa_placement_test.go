@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	gomegatypes "github.com/onsi/gomega/types"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	v1 "kubevirt.io/api/core/v1"
@@ -180,6 +181,34 @@ var _ = Describe("NumaPlacement", func() {
 			Expect(givenSpec.CPU).To(Equal(expectedSpec.CPU))
 			Expect(givenSpec.MemoryBacking).To(Equal(expectedMemoryBacking))
 		})
+		DescribeTable("should check the free hugepages reported by the host numa nodes", func(freePages []*cmdv1.Pages, matcher gomegatypes.GomegaMatcher) {
+			for _, cell := range givenTopology.NumaCells {
+				cell.FreePages = freePages
+			}
+			Expect(numaMapping(givenVMI, givenSpec, givenTopology)).To(matcher)
+		},
+			Entry("when enough pages of the requested size are free",
+				[]*cmdv1.Pages{{Unit: "KiB", Size: 2048, Count: 16}, {Unit: "KiB", Size: 1048576, Count: 0}},
+				Succeed(),
+			),
+			Entry("when not enough pages of the requested size are free",
+				[]*cmdv1.Pages{{Unit: "KiB", Size: 2048, Count: 15}, {Unit: "KiB", Size: 1048576, Count: 4}},
+				MatchError(ContainSubstring("not enough free hugepages of 2097152 bytes on host numa node 0: 15 free, 16 required")),
+			),
+			Entry("when only pages of other sizes are reported",
+				[]*cmdv1.Pages{{Unit: "KiB", Size: 1048576, Count: 0}},
+				Succeed(),
+			),
+			Entry("when no free pages are reported", nil, Succeed()),
+		)
+
+		It("should check the free hugepages of every host numa node", func() {
+			givenTopology.NumaCells[0].FreePages = []*cmdv1.Pages{{Unit: "KiB", Size: 2048, Count: 16}}
+			givenTopology.NumaCells[1].FreePages = []*cmdv1.Pages{{Unit: "KiB", Size: 2048, Count: 8}}
+			Expect(numaMapping(givenVMI, givenSpec, givenTopology)).To(
+				MatchError(ContainSubstring("host numa node 4: 8 free, 16 required")))
+		})
+
 		It("should process no shared pages when tuned for real time", func() {
 			givenVMI.Spec.Domain.CPU = &v1.CPU{Realtime: &v1.Realtime{}}
 			Expect(numaMapping(givenVMI, givenSpec, givenTopology)).To(Succeed())
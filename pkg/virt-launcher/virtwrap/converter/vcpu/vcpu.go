@@ -626,8 +626,10 @@ func numaMapping(vmi *v12.VirtualMachineInstance, domain *api.DomainSpec, topolo
 	}
 
 	virtualCellID := -1
+	var hostCells []*v1.Cell
 	for _, cell := range topology.NumaCells {
 		if vcpus, exists := numamap[cell.Id]; exists {
+			hostCells = append(hostCells, cell)
 			var cpus []string
 			for _, cpu := range vcpus {
 				cpus = append(cpus, strconv.Itoa(int(cpu)))
@@ -658,6 +660,9 @@ func numaMapping(vmi *v12.VirtualMachineInstance, domain *api.DomainSpec, topolo
 			domain.CPU.NUMA.Cells[i].Memory += hugepagesSize
 		}
 	}
+	if err := checkFreeHugepages(hostCells, domain.CPU.NUMA.Cells, hugepagesSize); err != nil {
+		return err
+	}
 	if vmi.IsRealtimeEnabled() {
 		// RT settings when hugepages are enabled
 		domain.MemoryBacking.NoSharePages = &api.NoSharePages{}
@@ -665,6 +670,47 @@ func numaMapping(vmi *v12.VirtualMachineInstance, domain *api.DomainSpec, topolo
 	return nil
 }
 
+// checkFreeHugepages verifies that every host numa node has enough free hugepages to back the guest numa cell
+// pinned to it. Host numa nodes which do not report free pages of the requested size are not checked.
+func checkFreeHugepages(hostCells []*v1.Cell, guestCells []api.NUMACell, hugepagesSize uint64) error {
+	for i, hostCell := range hostCells {
+		free, reported := freeHugepages(hostCell, hugepagesSize)
+		if !reported {
+			continue
+		}
+		if required := guestCells[i].Memory / hugepagesSize; free < required {
+			return fmt.Errorf("not enough free hugepages of %d bytes on host numa node %d: %d free, %d required",
+				hugepagesSize, hostCell.Id, free, required)
+		}
+	}
+	return nil
+}
+
+func freeHugepages(cell *v1.Cell, hugepagesSize uint64) (count uint64, reported bool) {
+	for _, pages := range cell.FreePages {
+		if pagesSizeToBytes(pages) == hugepagesSize {
+			return pages.Count, true
+		}
+	}
+	return 0, false
+}
+
+// pagesSizeToBytes converts the page size reported by libvirt, which defaults to KiB, into bytes
+func pagesSizeToBytes(pages *v1.Pages) uint64 {
+	size := uint64(pages.Size)
+	switch pages.Unit {
+	case "b", "bytes":
+		return size
+	case "", "k", "KiB":
+		return size * 1024
+	case "M", "MiB":
+		return size * 1024 * 1024
+	case "G", "GiB":
+		return size * 1024 * 1024 * 1024
+	}
+	return 0
+}
+
 func hugePagesInfo(vmi *v12.VirtualMachineInstance, domain *api.DomainSpec) (size uint64, unit string, enabled bool, err error) {
 	if domain.MemoryBacking != nil && domain.MemoryBacking.HugePages != nil {
 		if vmi.Spec.Domain.Memory.Hugepages != nil {
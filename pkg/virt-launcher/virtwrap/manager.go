@@ -776,7 +776,10 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 	if options != nil {
 		interfaceDomainAttachments = options.GetInterfaceDomainAttachment()
 	}
-	err = netsetup.NewVMNetworkConfigurator(vmi, cache.CacheCreator{}, netsetup.WithDomainAttachments(interfaceDomainAttachments)).SetupPodNetworkPhase2(domain, nonAbsentNets)
+	err = netsetup.NewVMNetworkConfigurator(vmi, cache.CacheCreator{},
+		netsetup.WithDomainAttachments(interfaceDomainAttachments),
+		netsetup.WithServiceDomainInfix(options.GetClusterConfig().GetServiceDomainInfix()),
+	).SetupPodNetworkPhase2(domain, nonAbsentNets)
 	if err != nil {
 		return domain, fmt.Errorf("preparing the pod network failed: %v", err)
 	}
@@ -1225,7 +1228,10 @@ func (l *LibvirtDomainManager) syncNetworkHotplug(
 		domainAttachments = options.GetInterfaceDomainAttachment()
 	}
 
-	networkConfigurator := netsetup.NewVMNetworkConfigurator(vmi, cache.CacheCreator{}, netsetup.WithDomainAttachments(domainAttachments))
+	networkConfigurator := netsetup.NewVMNetworkConfigurator(vmi, cache.CacheCreator{},
+		netsetup.WithDomainAttachments(domainAttachments),
+		netsetup.WithServiceDomainInfix(options.GetClusterConfig().GetServiceDomainInfix()),
+	)
 	networkInterfaceManager := newVirtIOInterfaceManager(dom, networkConfigurator)
 	if err := networkInterfaceManager.hotplugVirtioInterface(vmi, &api.Domain{Spec: *oldSpec}, domain); err != nil {
 		return err
@@ -1702,6 +1708,7 @@ func (l *LibvirtDomainManager) FreezeVMI(vmi *v1.VirtualMachineInstance, unfreez
 
 	// idempotent - prevent failuer in case fs is already frozen
 	if fsfreezeStatus == api.FSFrozen {
+		l.refreshFSFreezeStatus(api.FSFrozen)
 		return nil
 	}
 	_, err = l.virConn.QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, domainName)
@@ -1709,6 +1716,7 @@ func (l *LibvirtDomainManager) FreezeVMI(vmi *v1.VirtualMachineInstance, unfreez
 		log.Log.Errorf("Failed to freeze vmi, %s", err.Error())
 		return err
 	}
+	l.refreshFSFreezeStatus(api.FSFrozen)
 
 	l.cancelSafetyUnfreeze()
 	if safetyUnfreezeTimeout != 0 {
@@ -1724,6 +1732,7 @@ func (l *LibvirtDomainManager) UnfreezeVMI(vmi *v1.VirtualMachineInstance) error
 	if err == nil {
 		// prevent initating fs thaw to prevent rerunning the thaw hook
 		if fsfreezeStatus == api.FSThawed {
+			l.refreshFSFreezeStatus(api.FSThawed)
 			return nil
 		}
 	}
@@ -1733,9 +1742,19 @@ func (l *LibvirtDomainManager) UnfreezeVMI(vmi *v1.VirtualMachineInstance) error
 		log.Log.Errorf("Failed to unfreeze vmi, %s", err.Error())
 		return err
 	}
+	l.refreshFSFreezeStatus(api.FSThawed)
 	return nil
 }
 
+// refreshFSFreezeStatus updates the agent store right after a freeze or a thaw, so the domain
+// status and the metrics don't have to wait for the next fsfreeze status poll
+func (l *LibvirtDomainManager) refreshFSFreezeStatus(status string) {
+	if l.agentData == nil {
+		return
+	}
+	l.agentData.Store(agentpoller.GET_FSFREEZE_STATUS, api.FSFreeze{Status: status})
+}
+
 func (l *LibvirtDomainManager) SoftRebootVMI(vmi *v1.VirtualMachineInstance) error {
 	domainRebootFlagValues := libvirt.DOMAIN_REBOOT_GUEST_AGENT
 	condManager := controller.NewVirtualMachineInstanceConditionManager()
@@ -1941,7 +1960,7 @@ func (l *LibvirtDomainManager) GetDomainStats() (*stats.DomainStats, error) {
 }
 
 func (l *LibvirtDomainManager) getDomainStats() ([]*stats.DomainStats, error) {
-	statsTypes := libvirt.DOMAIN_STATS_BALLOON | libvirt.DOMAIN_STATS_CPU_TOTAL | libvirt.DOMAIN_STATS_VCPU | libvirt.DOMAIN_STATS_INTERFACE | libvirt.DOMAIN_STATS_BLOCK | libvirt.DOMAIN_STATS_DIRTYRATE
+	statsTypes := libvirt.DOMAIN_STATS_STATE | libvirt.DOMAIN_STATS_BALLOON | libvirt.DOMAIN_STATS_CPU_TOTAL | libvirt.DOMAIN_STATS_VCPU | libvirt.DOMAIN_STATS_INTERFACE | libvirt.DOMAIN_STATS_BLOCK | libvirt.DOMAIN_STATS_DIRTYRATE
 	flags := libvirt.CONNECT_GET_ALL_DOMAINS_STATS_RUNNING | libvirt.CONNECT_GET_ALL_DOMAINS_STATS_PAUSED
 
 	return l.virConn.GetDomainStats(statsTypes, l.migrateInfoStats, flags)
@@ -610,6 +610,22 @@ var _ = Describe("Manager", func() {
 
 			Expect(manager.UnfreezeVMI(vmi)).To(Succeed())
 		})
+		It("should refresh the fsfreeze status in the agent store on freeze and unfreeze", func() {
+			vmi := newVMI(testNamespace, testVmName)
+			agentStore := agentpoller.NewAsyncAgentStore()
+
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"`+string(agentpoller.GET_FSFREEZE_STATUS)+`"}`, testDomainName).Return(expectedThawedOutput, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-freeze"}`, testDomainName).Return("1", nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"`+string(agentpoller.GET_FSFREEZE_STATUS)+`"}`, testDomainName).Return(expectedFrozenOutput, nil)
+			mockConn.EXPECT().QemuAgentCommand(`{"execute":"guest-fsfreeze-thaw"}`, testDomainName).Return("1", nil)
+			manager, _ := NewLibvirtDomainManager(mockConn, testVirtShareDir, testEphemeralDiskDir, &agentStore, "/usr/share/OVMF", ephemeralDiskCreatorMock, metadataCache)
+
+			Expect(manager.FreezeVMI(vmi, 0)).To(Succeed())
+			Expect(agentStore.GetFSFreezeStatus()).To(Equal(&api.FSFreeze{Status: api.FSFrozen}))
+
+			Expect(manager.UnfreezeVMI(vmi)).To(Succeed())
+			Expect(agentStore.GetFSFreezeStatus()).To(Equal(&api.FSFreeze{Status: api.FSThawed}))
+		})
 		It("should automatically unfreeze after a timeout a frozen VirtualMachineInstance", func() {
 			vmi := newVMI(testNamespace, testVmName)
 
@@ -2078,7 +2094,8 @@ var _ = Describe("Manager", func() {
 	Context("on successful GetAllDomainStats", func() {
 		It("should return content", func() {
 			const (
-				domainStats = libvirt.DOMAIN_STATS_BALLOON |
+				domainStats = libvirt.DOMAIN_STATS_STATE |
+					libvirt.DOMAIN_STATS_BALLOON |
 					libvirt.DOMAIN_STATS_CPU_TOTAL |
 					libvirt.DOMAIN_STATS_VCPU |
 					libvirt.DOMAIN_STATS_INTERFACE |
@@ -48,6 +48,23 @@ const (
 	VCPURunning = 1
 	//  VIR_VCPU_BLOCKED    = 2,    /* the virtual CPU is blocked on resource */
 	VCPUBlocked = 2
+
+	// VIR_DOMAIN_NOSTATE     = 0,    /* no state */
+	DomainNoState = 0
+	// VIR_DOMAIN_RUNNING     = 1,    /* the domain is running */
+	DomainRunning = 1
+	// VIR_DOMAIN_BLOCKED     = 2,    /* the domain is blocked on resource */
+	DomainBlocked = 2
+	// VIR_DOMAIN_PAUSED      = 3,    /* the domain is paused by user */
+	DomainPaused = 3
+	// VIR_DOMAIN_SHUTDOWN    = 4,    /* the domain is being shut down */
+	DomainShutdown = 4
+	// VIR_DOMAIN_SHUTOFF     = 5,    /* the domain is shut off */
+	DomainShutoff = 5
+	// VIR_DOMAIN_CRASHED     = 6,    /* the domain is crashed */
+	DomainCrashed = 6
+	// VIR_DOMAIN_PMSUSPENDED = 7,    /* the domain is suspended by guest power management */
+	DomainPMSuspended = 7
 )
 
 type DomainStats struct {
@@ -56,8 +73,8 @@ type DomainStats struct {
 	Name string
 	UUID string
 	// omitted from libvirt-go: Domain
-	// omitted from libvirt-go: State
-	Cpu *DomainStatsCPU
+	State *DomainStatsState
+	Cpu   *DomainStatsCPU
 	// new, see below
 	Memory *DomainStatsMemory
 	// omitted from libvirt-go: DomainJobInfo
@@ -73,6 +90,13 @@ type DomainStats struct {
 	NrVirtCpu uint
 }
 
+type DomainStatsState struct {
+	StateSet  bool
+	State     int // DomainState
+	ReasonSet bool
+	Reason    int
+}
+
 type DomainStatsCPU struct {
 	TimeSet   bool
 	Time      uint64
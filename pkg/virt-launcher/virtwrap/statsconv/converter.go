@@ -49,6 +49,7 @@ func Convert_libvirt_DomainStats_to_stats_DomainStats(ident DomainIdentifier, in
 		out.NrVirtCpu = inDomInfo.NrVirtCpu
 	}
 
+	out.State = Convert_libvirt_DomainStatsState_To_stats_DomainStatsState(in.State)
 	out.Cpu = Convert_libvirt_DomainStatsCpu_To_stats_DomainStatsCpu(in.Cpu)
 	out.Memory = Convert_libvirt_MemoryStat_to_stats_DomainStatsMemory(inMem, inDomInfo)
 	out.Vcpu = Convert_libvirt_DomainStatsVcpu_To_stats_DomainStatsVcpu(in.Vcpu)
@@ -59,6 +60,19 @@ func Convert_libvirt_DomainStats_to_stats_DomainStats(ident DomainIdentifier, in
 	return nil
 }
 
+func Convert_libvirt_DomainStatsState_To_stats_DomainStatsState(in *libvirt.DomainStatsState) *stats.DomainStatsState {
+	if in == nil {
+		return &stats.DomainStatsState{}
+	}
+
+	return &stats.DomainStatsState{
+		StateSet:  in.StateSet,
+		State:     int(in.State),
+		ReasonSet: in.ReasonSet,
+		Reason:    in.Reason,
+	}
+}
+
 func Convert_libvirt_DomainStatsCpu_To_stats_DomainStatsCpu(in *libvirt.DomainStatsCPU) *stats.DomainStatsCPU {
 	if in == nil {
 		return &stats.DomainStatsCPU{}
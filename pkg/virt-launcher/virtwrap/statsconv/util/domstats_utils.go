@@ -62,7 +62,12 @@ var Testdata = `[
          "Time" : 86393420788
       },
       "Domain" : {},
-      "State" : null,
+      "State" : {
+         "StateSet" : true,
+         "State" : 1,
+         "ReasonSet" : true,
+         "Reason" : 1
+      },
       "Block" : [
          {
             "ErrorsSet" : false,
@@ -226,6 +231,12 @@ var Testdataexpected = `{
        "TxPktsSet": true
      }
    ], 
+   "State": {
+     "StateSet": true,
+     "State": 1,
+     "ReasonSet": true,
+     "Reason": 1
+   },
    "UUID": "testUUID", 
    "Vcpu": [
      {
@@ -22,6 +22,7 @@ package virt_operator
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync/atomic"
 	"time"
@@ -71,6 +72,7 @@ type KubeVirtController struct {
 	operatorNamespace    string
 	aggregatorClient     install.APIServiceInterface
 	hasSynced            func() bool
+	dryRunCache          apply.DryRunCache
 }
 
 func NewKubeVirtController(
@@ -1050,7 +1052,7 @@ func (c *KubeVirtController) syncInstallation(kv *v1.KubeVirt) error {
 		return err
 	}
 
-	reconciler, err := apply.NewReconciler(kv, targetStrategy, c.stores, c.config, c.clientset, c.aggregatorClient, &c.kubeVirtExpectations, c.recorder)
+	reconciler, err := apply.NewReconciler(kv, targetStrategy, c.stores, c.config, c.clientset, c.aggregatorClient, &c.kubeVirtExpectations, c.recorder, &c.dryRunCache)
 	if err != nil {
 		// deployment failed
 		util.UpdateConditionsFailedError(kv, err)
@@ -1060,7 +1062,13 @@ func (c *KubeVirtController) syncInstallation(kv *v1.KubeVirt) error {
 
 	synced, err := reconciler.Sync(c.queue)
 
-	if err != nil {
+	var validationErr *apply.DryRunValidationError
+	if errors.As(err, &validationErr) {
+		// nothing was applied
+		util.UpdateConditionsValidationFailed(kv, validationErr)
+		logger.Errorf("Failed to validate the install strategy: %v", err)
+		return err
+	} else if err != nil {
 		// deployment failed
 		util.UpdateConditionsFailedError(kv, err)
 		logger.Errorf("Failed to create all resources: %v", err)
@@ -34,6 +34,7 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	secv1 "github.com/openshift/api/security/v1"
 	routev1fake "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1/fake"
+	secv1client "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
 	secv1fake "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1/fake"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -118,6 +119,35 @@ type KubeVirtTestData struct {
 
 	defaultConfig     *util.KubeVirtDeploymentConfig
 	mockEnvVarManager util.EnvVarManager
+
+	apiServiceDryRunErr error
+}
+
+// dryRunIgnoringSecClient drops the dry-run SCC writes, as the fake client can't tell them from the real ones
+type dryRunIgnoringSecClient struct {
+	*secv1fake.FakeSecurityV1
+}
+
+func (c dryRunIgnoringSecClient) SecurityContextConstraints() secv1client.SecurityContextConstraintsInterface {
+	return dryRunIgnoringSCCs{c.FakeSecurityV1.SecurityContextConstraints()}
+}
+
+type dryRunIgnoringSCCs struct {
+	secv1client.SecurityContextConstraintsInterface
+}
+
+func (c dryRunIgnoringSCCs) Create(ctx context.Context, scc *secv1.SecurityContextConstraints, opts metav1.CreateOptions) (*secv1.SecurityContextConstraints, error) {
+	if len(opts.DryRun) > 0 {
+		return scc, nil
+	}
+	return c.SecurityContextConstraintsInterface.Create(ctx, scc, opts)
+}
+
+func (c dryRunIgnoringSCCs) Update(ctx context.Context, scc *secv1.SecurityContextConstraints, opts metav1.UpdateOptions) (*secv1.SecurityContextConstraints, error) {
+	if len(opts.DryRun) > 0 {
+		return scc, nil
+	}
+	return c.SecurityContextConstraintsInterface.Update(ctx, scc, opts)
 }
 
 func (k *KubeVirtTestData) BeforeTest() {
@@ -222,7 +252,7 @@ func (k *KubeVirtTestData) BeforeTest() {
 	k.virtClient.EXPECT().BatchV1().Return(k.kubeClient.BatchV1()).AnyTimes()
 	k.virtClient.EXPECT().RbacV1().Return(k.kubeClient.RbacV1()).AnyTimes()
 	k.virtClient.EXPECT().AppsV1().Return(k.kubeClient.AppsV1()).AnyTimes()
-	k.virtClient.EXPECT().SecClient().Return(k.secClient).AnyTimes()
+	k.virtClient.EXPECT().SecClient().Return(dryRunIgnoringSecClient{k.secClient}).AnyTimes()
 	k.virtClient.EXPECT().ExtensionsClient().Return(k.extClient).AnyTimes()
 	k.virtClient.EXPECT().PolicyV1().Return(k.kubeClient.PolicyV1()).AnyTimes()
 	k.virtClient.EXPECT().PrometheusClient().Return(k.promClient).AnyTimes()
@@ -266,6 +296,15 @@ func (k *KubeVirtTestData) BeforeTest() {
 		return true, nil, nil
 	})
 	k.apiServiceClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil, errors.NewNotFound(schema.GroupResource{Group: "", Resource: "apiservices"}, "whatever"))
+	// the dry-run validation of the install strategy doesn't change anything
+	k.apiServiceClient.EXPECT().Create(gomock.Any(), gomock.Any(), metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}).AnyTimes().DoAndReturn(
+		func(ctx context.Context, apiService *apiregv1.APIService, opts metav1.CreateOptions) (*apiregv1.APIService, error) {
+			return apiService, k.apiServiceDryRunErr
+		})
+	k.apiServiceClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}}).AnyTimes().DoAndReturn(
+		func(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, _ ...string) (*apiregv1.APIService, error) {
+			return &apiregv1.APIService{}, k.apiServiceDryRunErr
+		})
 	k.secClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
 		Expect(action).To(BeNil())
 		return true, nil, nil
@@ -2039,6 +2078,62 @@ var _ = Describe("KubeVirt Operator", func() {
 			Expect(kvTestData.totalDeletions).To(Equal(numResources))
 		})
 
+		It("should delete a stale APIService not in the deployed installstrategy", func() {
+			kvTestData := KubeVirtTestData{}
+			kvTestData.BeforeTest()
+			defer kvTestData.AfterTest()
+
+			kv := &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-install",
+					Namespace:  NAMESPACE,
+					Finalizers: []string{util.KubeVirtFinalizer},
+					Generation: int64(1),
+				},
+				Status: v1.KubeVirtStatus{
+					Phase:              v1.KubeVirtPhaseDeployed,
+					OperatorVersion:    version.Get().String(),
+					ObservedGeneration: pointer.P(int64(1)),
+				},
+			}
+			kvTestData.defaultConfig.SetTargetDeploymentConfig(kv)
+			kvTestData.defaultConfig.SetObservedDeploymentConfig(kv)
+			util.UpdateConditionsDeploying(kv)
+			util.UpdateConditionsCreated(kv)
+
+			kvTestData.deleteFromCache = false
+
+			// create all resources which should already exist
+			kubecontroller.SetLatestApiVersionAnnotation(kv)
+			kvTestData.addKubeVirt(kv)
+			kvTestData.addInstallStrategy(kvTestData.defaultConfig)
+			kvTestData.addAll(kvTestData.defaultConfig, kv)
+			// an APIService of a previous version, dropped from the install strategy
+			const staleAPIServiceName = "v1alpha2.subresources.kubevirt.io"
+			kvTestData.addResource(&apiregv1.APIService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: staleAPIServiceName,
+				},
+			}, kvTestData.defaultConfig, nil)
+			kvTestData.addPodsAndPodDisruptionBudgets(kvTestData.defaultConfig, kv)
+
+			kvTestData.makeDeploymentsReady(kv)
+			kvTestData.makeHandlerReady()
+
+			kvTestData.apiServiceClient.EXPECT().Delete(gomock.Any(), staleAPIServiceName, gomock.Any()).Times(1)
+			kvTestData.fakeNamespaceModificationEvent()
+			kvTestData.shouldExpectNamespacePatch()
+			kvTestData.shouldExpectPatchesAndUpdates(kv)
+			kvTestData.shouldExpectKubeVirtUpdateStatus(1)
+
+			kvTestData.controller.Execute()
+
+			kvKey, err := kubecontroller.KeyFunc(kv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kvTestData.controller.kubeVirtExpectations.APIService.SatisfiedExpectations(kvKey)).To(BeFalse(),
+				"the deletion of the stale APIService should be expected until it is observed")
+		})
+
 		It("should fail if KubeVirt object already exists", func() {
 
 			kvTestData := KubeVirtTestData{}
@@ -2419,6 +2514,49 @@ var _ = Describe("KubeVirt Operator", func() {
 
 		})
 
+		It("should not create any resource when the install strategy fails the dry-run validation", func() {
+			kvTestData := KubeVirtTestData{}
+			kvTestData.BeforeTest()
+			defer kvTestData.AfterTest()
+
+			kv := &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-install",
+					Namespace: NAMESPACE,
+				},
+			}
+			kubecontroller.SetLatestApiVersionAnnotation(kv)
+			kvTestData.addKubeVirt(kv)
+			kvTestData.addInstallStrategy(kvTestData.defaultConfig)
+
+			job, err := kvTestData.controller.generateInstallStrategyJob(kv.Spec.Infra, util.GetTargetConfigFromKV(kv))
+			Expect(err).ToNot(HaveOccurred())
+
+			job.Status.CompletionTime = now()
+			kvTestData.addInstallStrategyJob(job)
+
+			kvTestData.apiServiceDryRunErr = errors.NewInvalid(schema.GroupKind{Group: "apiregistration.k8s.io", Kind: "APIService"}, "v1.subresources.kubevirt.io", nil)
+			kvTestData.deleteFromCache = false
+			kvTestData.shouldExpectJobDeletion()
+			kvTestData.shouldExpectKubeVirtFinalizersPatch(1)
+			kvTestData.shouldExpectKubeVirtUpdateStatus(1)
+			kvTestData.shouldExpectCreations()
+
+			kvTestData.controller.Execute()
+
+			Expect(kvTestData.totalAdds).To(BeZero())
+			kv = kvTestData.getLatestKubeVirt(kv)
+			Expect(kv.Status.Conditions).To(ContainElement(And(
+				HaveField("Type", v1.KubeVirtConditionSynchronized),
+				HaveField("Status", k8sv1.ConditionFalse),
+				HaveField("Reason", util.ConditionReasonValidationFailedError),
+			)))
+			Expect(kv.Status.Conditions).ToNot(ContainElement(And(
+				HaveField("Type", v1.KubeVirtConditionAvailable),
+				HaveField("Reason", util.ConditionReasonValidationFailedError),
+			)))
+		})
+
 		It("should pause rollback until api server is rolled over.", func() {
 			defer GinkgoRecover()
 
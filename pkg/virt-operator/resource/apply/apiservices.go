@@ -1,6 +1,7 @@
 package apply
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
@@ -14,6 +15,8 @@ import (
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
+	metrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-operator"
 )
 
 func (r *Reconciler) createOrUpdateAPIServices(caBundle []byte) error {
@@ -52,14 +55,39 @@ func (r *Reconciler) createOrUpdateAPIService(apiService *apiregv1.APIService, c
 	if !exists {
 		r.expectations.APIService.RaiseExpectations(r.kvKey, 1, 0)
 		_, err := r.aggregatorclient.Create(context.Background(), apiService, metav1.CreateOptions{})
-		if err != nil {
-			r.expectations.APIService.LowerExpectations(r.kvKey, 1, 0)
+		if err == nil {
+			recordAPIServiceCABundle(apiService)
+			return nil
+		}
+		r.expectations.APIService.LowerExpectations(r.kvKey, 1, 0)
+		if !errors.IsAlreadyExists(err) {
 			return fmt.Errorf("unable to create apiservice %+v: %v", apiService, err)
 		}
+		// The apiservice was created since it was looked up, e.g. by the aggregator or another
+		// virt-operator during a restart, so it is read again and patched instead
+		log.Log.V(2).Infof("apiservice %s already exists, updating it", apiService.Name)
+		cachedAPIService = nil
+	}
 
-		return nil
+	err = retryOnPatchConflict(func(retried bool) error {
+		if retried || cachedAPIService == nil {
+			cachedAPIService, err = r.aggregatorclient.Get(context.Background(), apiService.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+		}
+		return r.patchAPIService(cachedAPIService, apiService)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to patch apiservice %+v: %v", apiService, err)
 	}
 
+	recordAPIServiceCABundle(apiService)
+	return nil
+}
+
+// patchAPIService patches the existing apiservice if it differs from the desired one
+func (r *Reconciler) patchAPIService(cachedAPIService, apiService *apiregv1.APIService) error {
 	modified := resourcemerge.BoolPtr(false)
 	resourcemerge.EnsureObjectMeta(modified, &cachedAPIService.ObjectMeta, apiService.ObjectMeta)
 	serviceSame := equality.Semantic.DeepEqual(cachedAPIService.Spec.Service, apiService.Spec.Service)
@@ -73,16 +101,71 @@ func (r *Reconciler) createOrUpdateAPIService(apiService *apiregv1.APIService, c
 		return nil
 	}
 
-	patchBytes, err := patch.New(getPatchWithObjectMetaAndSpec([]patch.PatchOption{}, &apiService.ObjectMeta, apiService.Spec)...).GeneratePayload()
+	var patchBytes []byte
+	var err error
+	if !*modified && serviceSame && prioritySame && insecureSame {
+		// only the CA was rotated
+		patchBytes, err = caBundlePatch(cachedAPIService.Spec.CABundle, apiService.Spec.CABundle).GeneratePayload()
+	} else {
+		patchBytes, err = patch.New(getPatchWithObjectMetaAndSpec([]patch.PatchOption{}, &apiService.ObjectMeta, apiService.Spec)...).GeneratePayload()
+	}
 	if err != nil {
 		return err
 	}
 
 	_, err = r.aggregatorclient.Patch(context.Background(), apiService.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
 	if err != nil {
-		return fmt.Errorf("unable to patch apiservice %+v: %v", apiService, err)
+		return err
+	}
+
+	if !certsSame {
+		if err := r.verifyAPIServiceCABundle(apiService); err != nil {
+			return err
+		}
 	}
 	log.Log.V(4).Infof("apiservice %v updated", apiService.GetName())
 
 	return nil
 }
+
+// caBundlePatch replaces the caBundle of an apiservice, testing that it didn't change since it was read
+func caBundlePatch(cachedCABundle, caBundle []byte) *patch.PatchSet {
+	if len(cachedCABundle) == 0 {
+		return patch.New(patch.WithAdd("/spec/caBundle", caBundle))
+	}
+	return patch.New(
+		patch.WithTest("/spec/caBundle", cachedCABundle),
+		patch.WithReplace("/spec/caBundle", caBundle),
+	)
+}
+
+// verifyAPIServiceCABundle re-reads the apiservice to make sure that its caBundle was not overwritten right after
+// it was patched, e.g. by another virt-operator which is still rolling out the previous CA.
+// A conflict is returned if it was, so that the patch is retried.
+func (r *Reconciler) verifyAPIServiceCABundle(apiService *apiregv1.APIService) error {
+	liveAPIService, err := r.aggregatorclient.Get(context.Background(), apiService.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(liveAPIService.Spec.CABundle, apiService.Spec.CABundle) {
+		return errors.NewConflict(apiregv1.Resource("apiservices"), apiService.Name, fmt.Errorf("the caBundle was overwritten after it was patched"))
+	}
+	return nil
+}
+
+// recordAPIServiceCABundle reports the age of the newest CA certificate in the caBundle of the apiservice
+func recordAPIServiceCABundle(apiService *apiregv1.APIService) {
+	certs, err := cert.ParseCertsPEM(apiService.Spec.CABundle)
+	if err != nil {
+		log.Log.Reason(err).Warningf("failed to parse the caBundle of apiservice %s", apiService.Name)
+		return
+	}
+
+	notBefore := certs[0].NotBefore
+	for _, c := range certs[1:] {
+		if c.NotBefore.After(notBefore) {
+			notBefore = c.NotBefore
+		}
+	}
+	metrics.SetAPIServiceCABundleNotBefore(apiService.Name, notBefore)
+}
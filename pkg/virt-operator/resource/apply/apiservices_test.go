@@ -0,0 +1,170 @@
+package apply
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	apiregv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/install"
+	"kubevirt.io/kubevirt/pkg/virt-operator/util"
+)
+
+var _ = Describe("Apply APIServices", func() {
+	const apiServiceName = "v1.subresources.kubevirt.io"
+
+	var aggregatorClient *install.MockAPIServiceInterface
+	var r *Reconciler
+
+	newAPIService := func(caBundle string) *apiregv1.APIService {
+		return &apiregv1.APIService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: apiServiceName,
+			},
+			Spec: apiregv1.APIServiceSpec{
+				Group:    "subresources.kubevirt.io",
+				Version:  "v1",
+				CABundle: []byte(caBundle),
+			},
+		}
+	}
+
+	conflict := errors.NewConflict(schema.GroupResource{Resource: "apiservices"}, apiServiceName, errors.NewBadRequest("changed"))
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		aggregatorClient = install.NewMockAPIServiceInterface(ctrl)
+
+		stores := util.Stores{APIServiceCache: cache.NewStore(cache.MetaNamespaceKeyFunc)}
+		Expect(stores.APIServiceCache.Add(newAPIService("old-ca"))).To(Succeed())
+
+		r = &Reconciler{
+			kv:               &v1.KubeVirt{},
+			stores:           stores,
+			aggregatorclient: aggregatorClient,
+		}
+	})
+
+	It("should re-read the APIService and patch it again after a conflict", func() {
+		gomock.InOrder(
+			aggregatorClient.EXPECT().Patch(gomock.Any(), apiServiceName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(nil, conflict),
+			aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(newAPIService("other-ca"), nil),
+			aggregatorClient.EXPECT().Patch(gomock.Any(), apiServiceName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(&apiregv1.APIService{}, nil),
+			aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(newAPIService("new-ca"), nil),
+		)
+
+		Expect(r.createOrUpdateAPIService(newAPIService("new-ca"), []byte("new-ca"))).To(Succeed())
+	})
+
+	Context("with an APIService matching the version", func() {
+		BeforeEach(func() {
+			upToDate := newAPIService("old-ca")
+			injectOperatorMetadata(r.kv, &upToDate.ObjectMeta, "", "", "", true)
+			Expect(r.stores.APIServiceCache.Update(upToDate)).To(Succeed())
+		})
+
+		It("should not patch it if the caBundle matches", func() {
+			Expect(r.createOrUpdateAPIService(newAPIService("old-ca"), []byte("old-ca"))).To(Succeed())
+		})
+
+		It("should only patch the caBundle when the CA was rotated", func() {
+			expectedPatch, err := patch.New(
+				patch.WithTest("/spec/caBundle", []byte("old-ca")),
+				patch.WithReplace("/spec/caBundle", []byte("new-ca")),
+			).GeneratePayload()
+			Expect(err).ToNot(HaveOccurred())
+
+			gomock.InOrder(
+				aggregatorClient.EXPECT().Patch(gomock.Any(), apiServiceName, types.JSONPatchType, expectedPatch, gomock.Any()).Return(&apiregv1.APIService{}, nil),
+				aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(newAPIService("new-ca"), nil),
+			)
+
+			Expect(r.createOrUpdateAPIService(newAPIService("new-ca"), []byte("new-ca"))).To(Succeed())
+		})
+
+		It("should patch the caBundle again if it was overwritten after the patch", func() {
+			gomock.InOrder(
+				aggregatorClient.EXPECT().Patch(gomock.Any(), apiServiceName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(&apiregv1.APIService{}, nil),
+				aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(newAPIService("old-ca"), nil),
+				aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(newAPIService("old-ca"), nil),
+				aggregatorClient.EXPECT().Patch(gomock.Any(), apiServiceName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(&apiregv1.APIService{}, nil),
+				aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(newAPIService("new-ca"), nil),
+			)
+
+			Expect(r.createOrUpdateAPIService(newAPIService("new-ca"), []byte("new-ca"))).To(Succeed())
+		})
+	})
+
+	It("should not patch the re-read APIService if it is already up to date", func() {
+		upToDate := newAPIService("new-ca")
+		injectOperatorMetadata(r.kv, &upToDate.ObjectMeta, "", "", "", true)
+
+		gomock.InOrder(
+			aggregatorClient.EXPECT().Patch(gomock.Any(), apiServiceName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(nil, conflict),
+			aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(upToDate, nil),
+		)
+
+		Expect(r.createOrUpdateAPIService(newAPIService("new-ca"), []byte("new-ca"))).To(Succeed())
+	})
+
+	It("should give up after the retries when the APIService keeps changing", func() {
+		aggregatorClient.EXPECT().Patch(gomock.Any(), apiServiceName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(nil, conflict).Times(patchConflictBackoff.Steps)
+		aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(newAPIService("other-ca"), nil).Times(patchConflictBackoff.Steps - 1)
+
+		err := r.createOrUpdateAPIService(newAPIService("new-ca"), []byte("new-ca"))
+		Expect(err).To(MatchError(ContainSubstring("giving up after %d conflicting attempts", patchConflictBackoff.Steps)))
+	})
+
+	Context("when the APIService is not known yet", func() {
+		BeforeEach(func() {
+			r.stores.APIServiceCache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+			r.expectations = &util.Expectations{
+				APIService: controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectationsWithName("APIService")),
+			}
+			aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(nil, errors.NewNotFound(schema.GroupResource{Resource: "apiservices"}, apiServiceName))
+		})
+
+		It("should create it", func() {
+			aggregatorClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(&apiregv1.APIService{}, nil)
+
+			Expect(r.createOrUpdateAPIService(newAPIService("new-ca"), []byte("new-ca"))).To(Succeed())
+		})
+
+		It("should read and patch it when it was created in the meantime", func() {
+			gomock.InOrder(
+				aggregatorClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.NewAlreadyExists(schema.GroupResource{Resource: "apiservices"}, apiServiceName)),
+				aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(newAPIService("other-ca"), nil),
+				aggregatorClient.EXPECT().Patch(gomock.Any(), apiServiceName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(&apiregv1.APIService{}, nil),
+				aggregatorClient.EXPECT().Get(gomock.Any(), apiServiceName, gomock.Any()).Return(newAPIService("new-ca"), nil),
+			)
+
+			Expect(r.createOrUpdateAPIService(newAPIService("new-ca"), []byte("new-ca"))).To(Succeed())
+		})
+
+		It("should fail on other create errors", func() {
+			aggregatorClient.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.NewForbidden(schema.GroupResource{Resource: "apiservices"}, apiServiceName, context.Canceled))
+
+			err := r.createOrUpdateAPIService(newAPIService("new-ca"), []byte("new-ca"))
+			Expect(err).To(MatchError(ContainSubstring("unable to create apiservice")))
+		})
+	})
+
+	It("should not retry on other errors", func() {
+		aggregatorClient.EXPECT().Patch(gomock.Any(), apiServiceName, types.JSONPatchType, gomock.Any(), gomock.Any()).Return(nil, errors.NewForbidden(schema.GroupResource{Resource: "apiservices"}, apiServiceName, context.Canceled))
+
+		err := r.createOrUpdateAPIService(newAPIService("new-ca"), []byte("new-ca"))
+		Expect(err).To(MatchError(ContainSubstring("forbidden")))
+		Expect(err).ToNot(MatchError(ContainSubstring("giving up")))
+	})
+})
@@ -44,6 +44,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
 	"kubevirt.io/kubevirt/pkg/controller"
+	metrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-operator"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/install"
 	"kubevirt.io/kubevirt/pkg/virt-operator/util"
 )
@@ -228,6 +229,7 @@ func DeleteAll(kv *v1.KubeVirt,
 					log.Log.Errorf("Failed to delete apiservice %+v: %v", apiservice, err)
 					return err
 				}
+				metrics.DeleteAPIServiceCABundle(apiservice.Name)
 			}
 		} else if !ok {
 			log.Log.Errorf(castFailedFmt, obj)
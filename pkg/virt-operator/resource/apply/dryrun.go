@@ -0,0 +1,145 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	secv1 "github.com/openshift/api/security/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	apiregv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+
+	"kubevirt.io/kubevirt/pkg/apimachinery/patch"
+)
+
+var dryRunAll = []string{metav1.DryRunAll}
+
+// DryRunValidationError is returned when the API server rejects objects of the install strategy in a dry-run,
+// before any of them was applied
+type DryRunValidationError struct {
+	Err error
+}
+
+func (e *DryRunValidationError) Error() string {
+	return fmt.Sprintf("the install strategy failed the dry-run validation: %v", e.Err)
+}
+
+func (e *DryRunValidationError) Unwrap() error {
+	return e.Err
+}
+
+// DryRunCache remembers the last deployment whose install strategy passed the dry-run validation,
+// so that it is validated once and not on every sync of its rollout
+type DryRunCache struct {
+	lock                  sync.Mutex
+	validatedDeploymentID string
+}
+
+func (c *DryRunCache) isValidated(deploymentID string) bool {
+	if c == nil {
+		return false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.validatedDeploymentID == deploymentID
+}
+
+func (c *DryRunCache) markValidated(deploymentID string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.validatedDeploymentID = deploymentID
+}
+
+// shouldValidateWithDryRun returns true when the target deployment was neither observed nor validated yet,
+// so that a new install strategy is validated once before it is rolled out
+func (r *Reconciler) shouldValidateWithDryRun() bool {
+	targetID := r.kv.Status.TargetDeploymentID
+	return targetID != r.kv.Status.ObservedDeploymentID && !r.dryRunCache.isValidated(targetID)
+}
+
+// validateWithDryRun creates or updates the APIServices and SCCs of the install strategy with a server-side dry-run,
+// so that objects the cluster rejects are reported before the cluster is partially updated
+func (r *Reconciler) validateWithDryRun() error {
+	var errs []error
+	for _, apiService := range r.targetStrategy.APIServices() {
+		if err := r.dryRunAPIService(apiService.DeepCopy()); err != nil {
+			errs = append(errs, fmt.Errorf("apiservice %s: %v", apiService.Name, err))
+		}
+	}
+
+	if r.config.IsOnOpenshift {
+		for _, scc := range r.targetStrategy.SCCs() {
+			if err := r.dryRunSCC(scc.DeepCopy()); err != nil {
+				errs = append(errs, fmt.Errorf("scc %s: %v", scc.Name, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &DryRunValidationError{Err: utilerrors.NewAggregate(errs)}
+	}
+	return nil
+}
+
+func (r *Reconciler) dryRunAPIService(apiService *apiregv1.APIService) error {
+	version, imageRegistry, id := getTargetVersionRegistryID(r.kv)
+	injectOperatorMetadata(r.kv, &apiService.ObjectMeta, version, imageRegistry, id, true)
+
+	obj, exists, _ := r.stores.APIServiceCache.Get(apiService)
+	if !exists {
+		_, err := r.aggregatorclient.Create(context.Background(), apiService, metav1.CreateOptions{DryRun: dryRunAll})
+		return err
+	}
+
+	// the caBundle is only known once the certificates are reconciled, keep the current one
+	apiService.Spec.CABundle = obj.(*apiregv1.APIService).Spec.CABundle
+	patchBytes, err := patch.New(getPatchWithObjectMetaAndSpec([]patch.PatchOption{}, &apiService.ObjectMeta, apiService.Spec)...).GeneratePayload()
+	if err != nil {
+		return err
+	}
+	_, err = r.aggregatorclient.Patch(context.Background(), apiService.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{DryRun: dryRunAll})
+	return err
+}
+
+func (r *Reconciler) dryRunSCC(scc *secv1.SecurityContextConstraints) error {
+	sccs := r.clientset.SecClient().SecurityContextConstraints()
+	version, imageRegistry, id := getTargetVersionRegistryID(r.kv)
+
+	obj, exists, _ := r.stores.SCCCache.GetByKey(scc.Name)
+	if exists {
+		scc.ObjectMeta = *obj.(*secv1.SecurityContextConstraints).ObjectMeta.DeepCopy()
+	}
+	injectOperatorMetadata(r.kv, &scc.ObjectMeta, version, imageRegistry, id, true)
+
+	var err error
+	if exists {
+		_, err = sccs.Update(context.Background(), scc, metav1.UpdateOptions{DryRun: dryRunAll})
+	} else {
+		_, err = sccs.Create(context.Background(), scc, metav1.CreateOptions{DryRun: dryRunAll})
+	}
+	return err
+}
@@ -0,0 +1,158 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package apply
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	secv1 "github.com/openshift/api/security/v1"
+	secv1fake "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1/fake"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	apiregv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/kubevirt/fake"
+
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/install"
+	"kubevirt.io/kubevirt/pkg/virt-operator/util"
+)
+
+var _ = Describe("Dry-run validation", func() {
+	const namespace = "kubevirt-test"
+
+	var aggregatorClient *install.MockAPIServiceInterface
+	var secClient *secv1fake.FakeSecurityV1
+	var stores util.Stores
+	var r *Reconciler
+
+	dryRunCreate := metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}
+	dryRunPatch := metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		aggregatorClient = install.NewMockAPIServiceInterface(ctrl)
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		secClient = &secv1fake.FakeSecurityV1{
+			Fake: &fake.NewSimpleClientset().Fake,
+		}
+		virtClient.EXPECT().SecClient().Return(secClient).AnyTimes()
+
+		strategy := install.NewMockStrategyInterface(ctrl)
+		strategy.EXPECT().APIServices().Return([]*apiregv1.APIService{
+			{ObjectMeta: metav1.ObjectMeta{Name: "v1.subresources.kubevirt.io"}},
+		}).AnyTimes()
+		strategy.EXPECT().SCCs().Return([]*secv1.SecurityContextConstraints{
+			components.NewKubeVirtHandlerSCC(namespace),
+			components.NewKubeVirtControllerSCC(namespace),
+		}).AnyTimes()
+
+		stores = util.Stores{
+			APIServiceCache: cache.NewStore(cache.MetaNamespaceKeyFunc),
+			SCCCache:        cache.NewStore(cache.MetaNamespaceKeyFunc),
+		}
+		r = &Reconciler{
+			kv:               &v1.KubeVirt{},
+			targetStrategy:   strategy,
+			stores:           stores,
+			clientset:        virtClient,
+			aggregatorclient: aggregatorClient,
+			config:           util.OperatorConfig{IsOnOpenshift: true},
+		}
+	})
+
+	It("should only validate until the target deployment is observed", func() {
+		r.kv.Status.TargetDeploymentID = "new"
+		r.kv.Status.ObservedDeploymentID = "old"
+		Expect(r.shouldValidateWithDryRun()).To(BeTrue())
+
+		r.kv.Status.ObservedDeploymentID = "new"
+		Expect(r.shouldValidateWithDryRun()).To(BeFalse())
+	})
+
+	It("should only validate a deployment once during its rollout", func() {
+		r.dryRunCache = &DryRunCache{}
+		r.kv.Status.TargetDeploymentID = "new"
+		r.kv.Status.ObservedDeploymentID = "old"
+		Expect(r.shouldValidateWithDryRun()).To(BeTrue())
+
+		r.dryRunCache.markValidated("new")
+		Expect(r.shouldValidateWithDryRun()).To(BeFalse())
+
+		r.kv.Status.TargetDeploymentID = "newer"
+		Expect(r.shouldValidateWithDryRun()).To(BeTrue())
+	})
+
+	It("should dry-run the creation of missing objects and the update of existing ones", func() {
+		existingSCC := components.NewKubeVirtControllerSCC(namespace)
+		existingSCC.ResourceVersion = "42"
+		Expect(stores.SCCCache.Add(existingSCC)).To(Succeed())
+
+		aggregatorClient.EXPECT().Create(gomock.Any(), gomock.Any(), dryRunCreate).Return(&apiregv1.APIService{}, nil)
+
+		var actions []testing.Action
+		secClient.Fake.PrependReactor("*", "securitycontextconstraints", func(action testing.Action) (bool, runtime.Object, error) {
+			actions = append(actions, action)
+			return true, nil, nil
+		})
+
+		Expect(r.validateWithDryRun()).To(Succeed())
+		Expect(actions).To(HaveLen(2))
+		Expect(actions[0].GetVerb()).To(Equal("create"))
+		Expect(actions[1].GetVerb()).To(Equal("update"))
+		Expect(actions[1].(testing.UpdateAction).GetObject().(*secv1.SecurityContextConstraints).ResourceVersion).To(Equal("42"))
+	})
+
+	It("should dry-run a patch of an existing APIService", func() {
+		Expect(stores.APIServiceCache.Add(&apiregv1.APIService{ObjectMeta: metav1.ObjectMeta{Name: "v1.subresources.kubevirt.io"}})).To(Succeed())
+		r.config.IsOnOpenshift = false
+
+		aggregatorClient.EXPECT().Patch(gomock.Any(), "v1.subresources.kubevirt.io", types.JSONPatchType, gomock.Any(), dryRunPatch).Return(&apiregv1.APIService{}, nil)
+
+		Expect(r.validateWithDryRun()).To(Succeed())
+	})
+
+	It("should report all the rejected objects", func() {
+		aggregatorClient.EXPECT().Create(gomock.Any(), gomock.Any(), dryRunCreate).Return(nil,
+			errors.NewInvalid(schema.GroupKind{Kind: "APIService"}, "v1.subresources.kubevirt.io", nil))
+		secClient.Fake.PrependReactor("create", "securitycontextconstraints", func(action testing.Action) (bool, runtime.Object, error) {
+			scc := action.(testing.CreateAction).GetObject().(*secv1.SecurityContextConstraints)
+			if scc.Name == "kubevirt-handler" {
+				return true, nil, errors.NewBadRequest("unknown field")
+			}
+			return true, scc, nil
+		})
+
+		err := r.validateWithDryRun()
+		var validationErr *DryRunValidationError
+		Expect(err).To(BeAssignableToTypeOf(validationErr))
+		Expect(err).To(MatchError(ContainSubstring("apiservice v1.subresources.kubevirt.io")))
+		Expect(err).To(MatchError(ContainSubstring("scc kubevirt-handler: unknown field")))
+		Expect(err).ToNot(MatchError(ContainSubstring("scc kubevirt-controller")))
+	})
+})
@@ -21,6 +21,7 @@ package apply
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -34,9 +35,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
 	apiregv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 
@@ -48,6 +52,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/certificates/triple"
 	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
 	"kubevirt.io/kubevirt/pkg/controller"
+	metrics "kubevirt.io/kubevirt/pkg/monitoring/metrics/virt-operator"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/install"
 	"kubevirt.io/kubevirt/pkg/virt-operator/util"
@@ -293,6 +298,47 @@ func createLabelsAndAnnotationsPatch(objectMeta *metav1.ObjectMeta) []patch.Patc
 		patch.WithAdd("/metadata/ownerReferences", objectMeta.OwnerReferences)}
 }
 
+// patchConflictBackoff is used to retry the writes failing because the object changed since it was read
+var patchConflictBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 10 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// isPatchConflict returns true for writes which failed because the object changed since it was read:
+// update conflicts and JSON patches with failing test operations.
+func isPatchConflict(err error) bool {
+	return errors.IsConflict(err) || isFailedPatchTest(err)
+}
+
+// isFailedPatchTest tells a JSON patch whose test operation failed apart from an object which is invalid.
+// The apiserver reports both as invalid, but a failed patch operation comes without the causes which the
+// validation of an object always lists.
+func isFailedPatchTest(err error) bool {
+	var apiStatus errors.APIStatus
+	if !errors.IsInvalid(err) || !goerrors.As(err, &apiStatus) {
+		return false
+	}
+	details := apiStatus.Status().Details
+	return details == nil || len(details.Causes) == 0
+}
+
+// retryOnPatchConflict runs write until it doesn't fail with a conflict or patchConflictBackoff is exhausted.
+// write is told whether it is retried, in which case it has to re-read the live object and recompute its change.
+// The error returned once the retries are exhausted is wrapped to tell it apart from the other errors.
+func retryOnPatchConflict(write func(retried bool) error) error {
+	attempts := 0
+	err := retry.OnError(patchConflictBackoff, isPatchConflict, func() error {
+		attempts++
+		return write(attempts > 1)
+	})
+	if err != nil && isPatchConflict(err) {
+		return fmt.Errorf("giving up after %d conflicting attempts: %w", attempts, err)
+	}
+	return err
+}
+
 func getPatchWithObjectMetaAndSpec(ops []patch.PatchOption, meta *metav1.ObjectMeta, spec interface{}) []patch.PatchOption {
 	// Add Labels and Annotations Patches
 	ops = append(ops, createLabelsAndAnnotationsPatch(meta)...)
@@ -491,9 +537,10 @@ type Reconciler struct {
 	aggregatorclient install.APIServiceInterface
 	expectations     *util.Expectations
 	recorder         record.EventRecorder
+	dryRunCache      *DryRunCache
 }
 
-func NewReconciler(kv *v1.KubeVirt, targetStrategy install.StrategyInterface, stores util.Stores, config util.OperatorConfig, clientset kubecli.KubevirtClient, aggregatorclient install.APIServiceInterface, expectations *util.Expectations, recorder record.EventRecorder) (*Reconciler, error) {
+func NewReconciler(kv *v1.KubeVirt, targetStrategy install.StrategyInterface, stores util.Stores, config util.OperatorConfig, clientset kubecli.KubevirtClient, aggregatorclient install.APIServiceInterface, expectations *util.Expectations, recorder record.EventRecorder, dryRunCache *DryRunCache) (*Reconciler, error) {
 	kvKey, err := controller.KeyFunc(kv)
 	if err != nil {
 		return nil, err
@@ -519,6 +566,7 @@ func NewReconciler(kv *v1.KubeVirt, targetStrategy install.StrategyInterface, st
 		aggregatorclient: aggregatorclient,
 		expectations:     expectations,
 		recorder:         recorder,
+		dryRunCache:      dryRunCache,
 	}, nil
 }
 
@@ -559,6 +607,14 @@ func (r *Reconciler) Sync(queue workqueue.RateLimitingInterface) (bool, error) {
 		infrastructureRolledOver = true
 	}
 
+	// -------- VALIDATE THE INSTALL STRATEGY --------
+	if r.shouldValidateWithDryRun() {
+		if err := r.validateWithDryRun(); err != nil {
+			return false, err
+		}
+		r.dryRunCache.markValidated(r.kv.Status.TargetDeploymentID)
+	}
+
 	// -------- CREATE AND ROLE OUT UPDATED OBJECTS --------
 
 	// creates a blocking webhook for any new CRDs that don't exist previously.
@@ -874,6 +930,7 @@ func (r *Reconciler) deleteObjectsNotInInstallStrategy() error {
 						log.Log.Errorf("Failed to delete apiService %+v: %v", apiService, err)
 						return err
 					}
+					metrics.DeleteAPIServiceCABundle(apiService.Name)
 				}
 			}
 		}
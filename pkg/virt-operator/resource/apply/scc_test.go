@@ -2,22 +2,33 @@ package apply
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	secv1 "github.com/openshift/api/security/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 
 	secv1fake "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1/fake"
 
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/kubevirt/fake"
 
+	"kubevirt.io/kubevirt/pkg/pointer"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/install"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/rbac"
 	"kubevirt.io/kubevirt/pkg/virt-operator/util"
 )
@@ -103,6 +114,283 @@ var _ = Describe("Apply Security Context Constraints", func() {
 			Entry("Without custom users", []string{}),
 			Entry("With custom users", []string{"someuser"}),
 		)
+
+		It("Should remove Kubevirt service accounts from all the default SCCs and skip the missing ones", func() {
+			kvServiceAccount := "system:serviceaccount:" + namespace + ":kubevirt-handler"
+			stores.SCCCache.Add(generateSCC("privileged", []string{kvServiceAccount, "someuser"}))
+			stores.SCCCache.Add(generateSCC("hostmount-anyuid", []string{kvServiceAccount}))
+
+			patches := map[string]string{}
+			secClient.Fake.PrependReactor("patch", "securitycontextconstraints",
+				func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					patch := action.(testing.PatchAction)
+					patches[patch.GetName()] = string(patch.GetPatch())
+					return true, nil, nil
+				})
+
+			r := &Reconciler{
+				clientset: virtClient,
+				stores:    stores,
+			}
+			Expect(r.removeKvServiceAccountsFromDefaultSCC(namespace)).To(Succeed())
+			Expect(patches).To(HaveLen(2))
+			Expect(patches).To(HaveKeyWithValue("privileged", fmt.Sprintf(
+				`[ { "op": "test", "path": "/users", "value": ["%s","someuser"] }, { "op": "replace", "path": "/users", "value": ["someuser"] } ]`,
+				kvServiceAccount)))
+			Expect(patches).To(HaveKeyWithValue("hostmount-anyuid", fmt.Sprintf(
+				`[ { "op": "test", "path": "/users", "value": ["%s"] }, { "op": "replace", "path": "/users", "value": null } ]`,
+				kvServiceAccount)))
+		})
+
+		It("Should only clean up the given SCCs", func() {
+			kvServiceAccount := "system:serviceaccount:" + namespace + ":kubevirt-handler"
+			stores.SCCCache.Add(generateSCC("privileged", []string{kvServiceAccount}))
+			stores.SCCCache.Add(generateSCC("anyuid", []string{kvServiceAccount}))
+
+			var patched []string
+			secClient.Fake.PrependReactor("patch", "securitycontextconstraints",
+				func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					patched = append(patched, action.(testing.PatchAction).GetName())
+					return true, nil, nil
+				})
+
+			r := &Reconciler{
+				clientset: virtClient,
+				stores:    stores,
+			}
+			Expect(r.removeKvServiceAccountsFromDefaultSCC(namespace, "anyuid", "restricted")).To(Succeed())
+			Expect(patched).To(ConsistOf("anyuid"))
+		})
+
+		It("Should recompute the patch from the live SCC when the users changed since they were read", func() {
+			kvServiceAccount := "system:serviceaccount:" + namespace + ":kubevirt-handler"
+			Expect(rbac.GetKubevirtComponentsServiceAccounts(namespace)).To(HaveKey(kvServiceAccount))
+			stores.SCCCache.Add(generateSCC("privileged", []string{kvServiceAccount, "someuser"}))
+			liveSCC := generateSCC("privileged", []string{kvServiceAccount, "someuser", "otheruser"})
+
+			secClient.Fake.PrependReactor("get", "securitycontextconstraints",
+				func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					return true, liveSCC, nil
+				})
+			var patches []string
+			secClient.Fake.PrependReactor("patch", "securitycontextconstraints",
+				func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					patches = append(patches, string(action.(testing.PatchAction).GetPatch()))
+					if len(patches) == 1 {
+						// what the apiserver returns for a JSON patch whose test operation failed
+						return true, nil, errors.NewGenericServerResponse(http.StatusUnprocessableEntity, "", schema.GroupResource{}, "",
+							"testing value /users failed", 0, false)
+					}
+					return true, nil, nil
+				})
+
+			r := &Reconciler{
+				clientset: virtClient,
+				stores:    stores,
+			}
+			Expect(r.removeKvServiceAccountsFromDefaultSCC(namespace)).To(Succeed())
+			Expect(patches).To(HaveLen(2))
+			Expect(patches[1]).To(Equal(fmt.Sprintf(
+				`[ { "op": "test", "path": "/users", "value": ["%s","someuser","otheruser"] }, { "op": "replace", "path": "/users", "value": ["someuser","otheruser"] } ]`,
+				kvServiceAccount)))
+		})
+
+		It("Should not retry a patch which is invalid on its own", func() {
+			kvServiceAccount := "system:serviceaccount:" + namespace + ":kubevirt-handler"
+			stores.SCCCache.Add(generateSCC("privileged", []string{kvServiceAccount}))
+
+			patches := 0
+			secClient.Fake.PrependReactor("patch", "securitycontextconstraints",
+				func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					patches++
+					return true, nil, errors.NewInvalid(schema.GroupKind{Kind: "SecurityContextConstraints"}, "privileged",
+						field.ErrorList{field.Invalid(field.NewPath("users"), nil, "invalid user")})
+				})
+
+			r := &Reconciler{
+				clientset: virtClient,
+				stores:    stores,
+			}
+			err := r.removeKvServiceAccountsFromDefaultSCC(namespace)
+			Expect(err).To(MatchError(ContainSubstring("invalid user")))
+			Expect(err).ToNot(MatchError(ContainSubstring("giving up")))
+			Expect(patches).To(Equal(1))
+		})
+
+		It("Should give up after the retries when the SCC keeps changing", func() {
+			kvServiceAccount := "system:serviceaccount:" + namespace + ":kubevirt-handler"
+			scc := generateSCC("privileged", []string{kvServiceAccount})
+			stores.SCCCache.Add(scc)
+
+			secClient.Fake.PrependReactor("get", "securitycontextconstraints",
+				func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					return true, scc, nil
+				})
+			patches := 0
+			secClient.Fake.PrependReactor("patch", "securitycontextconstraints",
+				func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					patches++
+					return true, nil, errors.NewConflict(schema.GroupResource{Resource: "securitycontextconstraints"}, "privileged", fmt.Errorf("changed"))
+				})
+
+			r := &Reconciler{
+				clientset: virtClient,
+				stores:    stores,
+			}
+			err := r.removeKvServiceAccountsFromDefaultSCC(namespace)
+			Expect(err).To(MatchError(ContainSubstring("giving up after %d conflicting attempts", patchConflictBackoff.Steps)))
+			Expect(patches).To(Equal(patchConflictBackoff.Steps))
+		})
 	})
 
+	Context("Manage the KubeVirt SCCs", func() {
+		const namespace = "kubevirt-test"
+
+		var stores util.Stores
+		var secClient *secv1fake.FakeSecurityV1
+		var recorder *record.FakeRecorder
+		var r *Reconciler
+		var updated []*secv1.SecurityContextConstraints
+
+		BeforeEach(func() {
+			ctrl := gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			secClient = &secv1fake.FakeSecurityV1{
+				Fake: &fake.NewSimpleClientset().Fake,
+			}
+			virtClient.EXPECT().SecClient().Return(secClient).AnyTimes()
+
+			updated = nil
+			secClient.Fake.PrependReactor("update", "securitycontextconstraints",
+				func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					scc := action.(testing.UpdateAction).GetObject().(*secv1.SecurityContextConstraints)
+					updated = append(updated, scc)
+					return true, scc, nil
+				})
+
+			strategy := install.NewMockStrategyInterface(ctrl)
+			strategy.EXPECT().SCCs().Return([]*secv1.SecurityContextConstraints{components.NewKubeVirtControllerSCC(namespace)}).AnyTimes()
+
+			stores = util.Stores{SCCCache: cache.NewStore(cache.MetaNamespaceKeyFunc)}
+			recorder = record.NewFakeRecorder(10)
+			r = &Reconciler{
+				kv:             &v1.KubeVirt{},
+				targetStrategy: strategy,
+				stores:         stores,
+				clientset:      virtClient,
+				config:         util.OperatorConfig{IsOnOpenshift: true},
+				recorder:       recorder,
+			}
+		})
+
+		existingSCC := func() *secv1.SecurityContextConstraints {
+			scc := components.NewKubeVirtControllerSCC(namespace)
+			version, imageRegistry, id := getTargetVersionRegistryID(r.kv)
+			injectOperatorMetadata(r.kv, &scc.ObjectMeta, version, imageRegistry, id, true)
+			return scc
+		}
+
+		It("should list the names of the managed SCCs", func() {
+			strategy := install.NewMockStrategyInterface(gomock.NewController(GinkgoT()))
+			strategy.EXPECT().SCCs().Return(components.GetAllSCC(namespace)).AnyTimes()
+			r.targetStrategy = strategy
+
+			Expect(r.ManagedSCCNames()).To(ConsistOf("kubevirt-handler", "kubevirt-controller"))
+		})
+
+		It("should not update an up to date SCC", func() {
+			Expect(stores.SCCCache.Add(existingSCC())).To(Succeed())
+
+			Expect(r.createOrUpdateSCC()).To(Succeed())
+			Expect(updated).To(BeEmpty())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should revert the manual changes to the managed fields", func() {
+			scc := existingSCC()
+			scc.AllowedCapabilities = append(scc.AllowedCapabilities, "SYS_ADMIN")
+			scc.Users = append(scc.Users, "someuser")
+			Expect(stores.SCCCache.Add(scc)).To(Succeed())
+
+			Expect(r.createOrUpdateSCC()).To(Succeed())
+			Expect(updated).To(HaveLen(1))
+			Expect(updated[0].AllowedCapabilities).To(Equal(components.NewKubeVirtControllerSCC(namespace).AllowedCapabilities))
+			Expect(updated[0].Users).To(Equal(components.NewKubeVirtControllerSCC(namespace).Users))
+			Expect(recorder.Events).To(Receive(Equal(
+				"Warning SCCDriftReverted Reverted the manual changes to users, allowedCapabilities of SecurityContextConstraints kubevirt-controller")))
+		})
+
+		It("should not report the defaults of the server for unset fields as drift", func() {
+			scc := existingSCC()
+			scc.Volumes = []secv1.FSType{secv1.FSTypeAll}
+			scc.SELinuxContext.SELinuxOptions = &corev1.SELinuxOptions{Level: "s0:c1,c0"}
+			scc.RunAsUser.UIDRangeMin = pointer.P(int64(1000))
+			Expect(stores.SCCCache.Add(scc)).To(Succeed())
+
+			Expect(r.createOrUpdateSCC()).To(Succeed())
+			Expect(updated).To(BeEmpty())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should revert the manual changes to the volumes when they are managed", func() {
+			strategy := install.NewMockStrategyInterface(gomock.NewController(GinkgoT()))
+			strategy.EXPECT().SCCs().Return([]*secv1.SecurityContextConstraints{components.NewKubeVirtHandlerSCC(namespace)}).AnyTimes()
+			r.targetStrategy = strategy
+
+			scc := components.NewKubeVirtHandlerSCC(namespace)
+			version, imageRegistry, id := getTargetVersionRegistryID(r.kv)
+			injectOperatorMetadata(r.kv, &scc.ObjectMeta, version, imageRegistry, id, true)
+			scc.Volumes = []secv1.FSType{secv1.FSTypeConfigMap}
+			Expect(stores.SCCCache.Add(scc)).To(Succeed())
+
+			Expect(r.createOrUpdateSCC()).To(Succeed())
+			Expect(updated).To(HaveLen(1))
+			Expect(updated[0].Volumes).To(Equal([]secv1.FSType{secv1.FSTypeAll}))
+			Expect(recorder.Events).To(Receive(Equal(
+				"Warning SCCDriftReverted Reverted the manual changes to volumes of SecurityContextConstraints kubevirt-handler")))
+		})
+
+		It("should revert the manual changes to the security flags", func() {
+			scc := existingSCC()
+			scc.AllowPrivilegedContainer = true
+			scc.AllowHostNetwork = true
+			Expect(stores.SCCCache.Add(scc)).To(Succeed())
+
+			Expect(r.createOrUpdateSCC()).To(Succeed())
+			Expect(updated).To(HaveLen(1))
+			Expect(updated[0].AllowPrivilegedContainer).To(BeFalse())
+			Expect(updated[0].AllowHostNetwork).To(BeFalse())
+			Expect(recorder.Events).To(Receive(Equal(
+				"Warning SCCDriftReverted Reverted the manual changes to allowPrivilegedContainer, allowHostNetwork of SecurityContextConstraints kubevirt-controller")))
+		})
+
+		It("should re-enable the privileged containers when they were disabled", func() {
+			strategy := install.NewMockStrategyInterface(gomock.NewController(GinkgoT()))
+			strategy.EXPECT().SCCs().Return([]*secv1.SecurityContextConstraints{components.NewKubeVirtHandlerSCC(namespace)}).AnyTimes()
+			r.targetStrategy = strategy
+
+			scc := components.NewKubeVirtHandlerSCC(namespace)
+			version, imageRegistry, id := getTargetVersionRegistryID(r.kv)
+			injectOperatorMetadata(r.kv, &scc.ObjectMeta, version, imageRegistry, id, true)
+			scc.AllowPrivilegedContainer = false
+			Expect(stores.SCCCache.Add(scc)).To(Succeed())
+
+			Expect(r.createOrUpdateSCC()).To(Succeed())
+			Expect(updated).To(HaveLen(1))
+			Expect(updated[0].AllowPrivilegedContainer).To(BeTrue())
+			Expect(recorder.Events).To(Receive(Equal(
+				"Warning SCCDriftReverted Reverted the manual changes to allowPrivilegedContainer of SecurityContextConstraints kubevirt-handler")))
+		})
+
+		It("should keep the manual changes when the SCC opted out", func() {
+			scc := existingSCC()
+			scc.Annotations[v1.IgnoreSCCDriftAnnotation] = ""
+			scc.AllowedCapabilities = append(scc.AllowedCapabilities, "SYS_ADMIN")
+			Expect(stores.SCCCache.Add(scc)).To(Succeed())
+
+			Expect(r.createOrUpdateSCC()).To(Succeed())
+			Expect(updated).To(BeEmpty())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
 })
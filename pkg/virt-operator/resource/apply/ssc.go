@@ -4,16 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	secv1 "github.com/openshift/api/security/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	v1 "kubevirt.io/api/core/v1"
 	"kubevirt.io/client-go/log"
 
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/rbac"
 )
 
+// sccDriftRevertedReason is the reason of the event emitted when manual changes to a KubeVirt SCC are reverted
+const sccDriftRevertedReason = "SCCDriftReverted"
+
 func (r *Reconciler) createOrUpdateSCC() error {
 	sec := r.clientset.SecClient()
 
@@ -41,12 +48,27 @@ func (r *Reconciler) createOrUpdateSCC() error {
 			}
 
 			log.Log.V(2).Infof("SCC %v created", scc.Name)
-		} else if !objectMatchesVersion(&cachedSCC.ObjectMeta, version, imageRegistry, id, r.kv.GetGeneration()) {
-			scc.ObjectMeta = *cachedSCC.ObjectMeta.DeepCopy()
-			injectOperatorMetadata(r.kv, &scc.ObjectMeta, version, imageRegistry, id, true)
-			_, err := sec.SecurityContextConstraints().Update(context.Background(), scc, metav1.UpdateOptions{})
+		} else if driftedFields := sccDriftedFields(cachedSCC, scc); len(driftedFields) > 0 || !objectMatchesVersion(&cachedSCC.ObjectMeta, version, imageRegistry, id, r.kv.GetGeneration()) {
+			err := retryOnPatchConflict(func(retried bool) error {
+				if retried {
+					liveSCC, err := sec.SecurityContextConstraints().Get(context.Background(), scc.Name, metav1.GetOptions{})
+					if err != nil {
+						return err
+					}
+					cachedSCC = liveSCC
+				}
+				scc.ObjectMeta = *cachedSCC.ObjectMeta.DeepCopy()
+				injectOperatorMetadata(r.kv, &scc.ObjectMeta, version, imageRegistry, id, true)
+				_, err := sec.SecurityContextConstraints().Update(context.Background(), scc, metav1.UpdateOptions{})
+				return err
+			})
 			if err != nil {
-				return fmt.Errorf("Unable to update %s SecurityContextConstraints", scc.Name)
+				return fmt.Errorf("Unable to update %s SecurityContextConstraints: %v", scc.Name, err)
+			}
+
+			if len(driftedFields) > 0 {
+				r.recorder.Eventf(scc, corev1.EventTypeWarning, sccDriftRevertedReason,
+					"Reverted the manual changes to %s of SecurityContextConstraints %s", strings.Join(driftedFields, ", "), scc.Name)
 			}
 
 			log.Log.V(2).Infof("SecurityContextConstraints %s updated", scc.Name)
@@ -59,10 +81,94 @@ func (r *Reconciler) createOrUpdateSCC() error {
 	return nil
 }
 
-func (r *Reconciler) removeKvServiceAccountsFromDefaultSCC(targetNamespace string) error {
-	var remainedUsersList []string
+// ManagedSCCNames returns the names of the SCCs virt-operator manages for the target install strategy.
+func (r *Reconciler) ManagedSCCNames() []string {
+	var names []string
+	for _, scc := range r.targetStrategy.SCCs() {
+		names = append(names, scc.Name)
+	}
+	return names
+}
+
+// sccDriftedFields returns the fields managed by virt-operator which differ between the existing and the desired scc.
+// Nothing is reported when the existing scc opted out with the IgnoreSCCDriftAnnotation.
+func sccDriftedFields(cachedSCC, scc *secv1.SecurityContextConstraints) []string {
+	if _, ignoreDrift := cachedSCC.Annotations[v1.IgnoreSCCDriftAnnotation]; ignoreDrift {
+		return nil
+	}
+
+	var drifted []string
+	if cachedSCC.AllowPrivilegedContainer != scc.AllowPrivilegedContainer {
+		drifted = append(drifted, "allowPrivilegedContainer")
+	}
+	if cachedSCC.AllowHostNetwork != scc.AllowHostNetwork {
+		drifted = append(drifted, "allowHostNetwork")
+	}
+	if cachedSCC.AllowHostPID != scc.AllowHostPID {
+		drifted = append(drifted, "allowHostPID")
+	}
+	if cachedSCC.AllowHostIPC != scc.AllowHostIPC {
+		drifted = append(drifted, "allowHostIPC")
+	}
+	if cachedSCC.AllowHostPorts != scc.AllowHostPorts {
+		drifted = append(drifted, "allowHostPorts")
+	}
+	if cachedSCC.AllowHostDirVolumePlugin != scc.AllowHostDirVolumePlugin {
+		drifted = append(drifted, "allowHostDirVolumePlugin")
+	}
+	// The apiserver fills in defaults for the fields the desired scc leaves unset, e.g. OpenShift sets the
+	// volumes of an scc without any to ["*"]. Only the fields set on the desired scc are compared.
+	if scc.Users != nil && !equality.Semantic.DeepEqual(cachedSCC.Users, scc.Users) {
+		drifted = append(drifted, "users")
+	}
+	if scc.AllowedCapabilities != nil && !equality.Semantic.DeepEqual(cachedSCC.AllowedCapabilities, scc.AllowedCapabilities) {
+		drifted = append(drifted, "allowedCapabilities")
+	}
+	if !runAsUserMatches(cachedSCC.RunAsUser, scc.RunAsUser) {
+		drifted = append(drifted, "runAsUser")
+	}
+	if !seLinuxContextMatches(cachedSCC.SELinuxContext, scc.SELinuxContext) {
+		drifted = append(drifted, "seLinuxContext")
+	}
+	if scc.Volumes != nil && !equality.Semantic.DeepEqual(cachedSCC.Volumes, scc.Volumes) {
+		drifted = append(drifted, "volumes")
+	}
+	return drifted
+}
+
+func runAsUserMatches(cached, desired secv1.RunAsUserStrategyOptions) bool {
+	return (desired.Type == "" || cached.Type == desired.Type) &&
+		(desired.UID == nil || equality.Semantic.DeepEqual(cached.UID, desired.UID)) &&
+		(desired.UIDRangeMin == nil || equality.Semantic.DeepEqual(cached.UIDRangeMin, desired.UIDRangeMin)) &&
+		(desired.UIDRangeMax == nil || equality.Semantic.DeepEqual(cached.UIDRangeMax, desired.UIDRangeMax))
+}
 
-	SCCObj, exists, err := r.stores.SCCCache.GetByKey("privileged")
+func seLinuxContextMatches(cached, desired secv1.SELinuxContextStrategyOptions) bool {
+	return (desired.Type == "" || cached.Type == desired.Type) &&
+		(desired.SELinuxOptions == nil || equality.Semantic.DeepEqual(cached.SELinuxOptions, desired.SELinuxOptions))
+}
+
+// defaultSCCs are the default SCCs kubevirt service accounts were added to by previous releases
+var defaultSCCs = []string{"privileged", "anyuid", "hostmount-anyuid"}
+
+// removeKvServiceAccountsFromDefaultSCC removes the kubevirt service accounts from the users of the given SCCs,
+// or of the defaultSCCs if none is given. SCCs which don't exist are skipped.
+func (r *Reconciler) removeKvServiceAccountsFromDefaultSCC(targetNamespace string, sccNames ...string) error {
+	if len(sccNames) == 0 {
+		sccNames = defaultSCCs
+	}
+
+	for _, sccName := range sccNames {
+		if err := r.removeKvServiceAccountsFromSCC(sccName, targetNamespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) removeKvServiceAccountsFromSCC(sccName, targetNamespace string) error {
+	SCCObj, exists, err := r.stores.SCCCache.GetByKey(sccName)
 	if err != nil {
 		return err
 	} else if !exists {
@@ -74,6 +180,27 @@ func (r *Reconciler) removeKvServiceAccountsFromDefaultSCC(targetNamespace strin
 		return fmt.Errorf("couldn't cast object to SecurityContextConstraints: %+v", SCCObj)
 	}
 
+	err = retryOnPatchConflict(func(retried bool) error {
+		if retried {
+			SCC, err = r.clientset.SecClient().SecurityContextConstraints().Get(context.Background(), sccName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+		}
+		return r.patchKvServiceAccountsOutOfSCC(SCC, targetNamespace)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to patch scc %s: %v", sccName, err)
+	}
+
+	return nil
+}
+
+// patchKvServiceAccountsOutOfSCC removes the kubevirt service accounts from the users of the scc, if any.
+// The patch tests that the users didn't change since the scc was read.
+func (r *Reconciler) patchKvServiceAccountsOutOfSCC(SCC *secv1.SecurityContextConstraints, targetNamespace string) error {
+	var remainedUsersList []string
+
 	modified := false
 	kvServiceAccounts := rbac.GetKubevirtComponentsServiceAccounts(targetNamespace)
 	for _, acc := range SCC.Users {
@@ -97,9 +224,9 @@ func (r *Reconciler) removeKvServiceAccountsFromDefaultSCC(targetNamespace strin
 		test := fmt.Sprintf(`{ "op": "test", "path": "/users", "value": %s }`, string(oldUserBytes))
 		patch := fmt.Sprintf(`{ "op": "replace", "path": "/users", "value": %s }`, string(userBytes))
 
-		_, err = r.clientset.SecClient().SecurityContextConstraints().Patch(context.Background(), "privileged", types.JSONPatchType, []byte(fmt.Sprintf("[ %s, %s ]", test, patch)), metav1.PatchOptions{})
+		_, err = r.clientset.SecClient().SecurityContextConstraints().Patch(context.Background(), SCC.Name, types.JSONPatchType, []byte(fmt.Sprintf("[ %s, %s ]", test, patch)), metav1.PatchOptions{})
 		if err != nil {
-			return fmt.Errorf("unable to patch scc: %v", err)
+			return err
 		}
 	}
 
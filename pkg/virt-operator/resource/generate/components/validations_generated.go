@@ -1180,6 +1180,31 @@ var CRDsValidation map[string]string = map[string]string{
             memBalloonStatsPeriod:
               format: int32
               type: integer
+            metricsConfiguration:
+              description: MetricsConfiguration controls the metrics exposed by virt-handler
+                about the VMIs of its node
+              properties:
+                vmiLabelAllowlist:
+                  description: |-
+                    VMILabelAllowlist holds the VMI label keys which are turned into metric labels, a trailing * matches a key prefix.
+                    All the VMI labels are turned into metric labels when empty.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: set
+                vmiLabelDenylist:
+                  description: |-
+                    VMILabelDenylist holds the VMI label keys which are never turned into metric labels, a trailing * matches a key prefix.
+                    It takes precedence over the VMILabelAllowlist.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: set
+                vmiLabelPrefix:
+                  description: VMILabelPrefix is prepended to the VMI label keys turned
+                    into metric labels, defaults to kubernetes_vmi_label_.
+                  type: string
+              type: object
             migrations:
               description: |-
                 MigrationConfiguration holds migration options.
@@ -1374,6 +1399,12 @@ var CRDsValidation map[string]string = map[string]string{
                     DeprecatedPermitSlirpInterface is an alias for the deprecated PermitSlirpInterface.
                     Deprecated: Removed in v1.3.
                   type: boolean
+                serviceDomainInfix:
+                  description: |-
+                    ServiceDomainInfix identifies the Kubernetes service domains among the search domains of the
+                    virt-launcher pod, which are advertised to the guest by the DHCP server.
+                    Defaults to ".svc.".
+                  type: string
               type: object
             obsoleteCPUModels:
               additionalProperties:
@@ -1483,6 +1514,8 @@ var CRDsValidation map[string]string = map[string]string{
               type: string
             smbios:
               properties:
+                assetTag:
+                  type: string
                 family:
                   type: string
                 manufacturer:
@@ -6425,6 +6458,11 @@ var CRDsValidation map[string]string = map[string]string{
                                   Defaults to virtio.
                                   TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51
                                 type: string
+                              multiQueue:
+                                description: |-
+                                  If specified, overrides Devices.NetworkInterfaceMultiQueue for this interface.
+                                  Only applies to virtio interfaces.
+                                type: boolean
                               name:
                                 description: |-
                                   Logical name of the interface as well as a reference to the associated networks.
@@ -6464,13 +6502,19 @@ var CRDsValidation map[string]string = map[string]string{
                                       type: integer
                                     protocol:
                                       description: |-
-                                        Protocol for port. Must be UDP or TCP.
+                                        Protocol for port. Must be UDP, TCP or SCTP.
                                         Defaults to "TCP".
                                       type: string
                                   required:
                                   - port
                                   type: object
                                 type: array
+                              queues:
+                                description: |-
+                                  If specified, the number of queues of the interface. Implies MultiQueue.
+                                  Must not exceed the number of vCPUs. Defaults to the number of vCPUs.
+                                format: int32
+                                type: integer
                               slirp:
                                 description: |-
                                   DeprecatedSlirp is an alias to the deprecated Slirp interface
@@ -7873,6 +7917,14 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Capacity of the sparse disk.
                             pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                             x-kubernetes-int-or-string: true
+                          format:
+                            description: Format of the disk image, qcow2 or raw. Defaults
+                              to qcow2.
+                            type: string
+                          preallocation:
+                            description: Preallocation mode of the disk image, off, falloc
+                              or full. Defaults to off.
+                            type: string
                         required:
                         - capacity
                         type: object
@@ -11631,6 +11683,11 @@ var CRDsValidation map[string]string = map[string]string{
                           Defaults to virtio.
                           TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51
                         type: string
+                      multiQueue:
+                        description: |-
+                          If specified, overrides Devices.NetworkInterfaceMultiQueue for this interface.
+                          Only applies to virtio interfaces.
+                        type: boolean
                       name:
                         description: |-
                           Logical name of the interface as well as a reference to the associated networks.
@@ -11670,13 +11727,19 @@ var CRDsValidation map[string]string = map[string]string{
                               type: integer
                             protocol:
                               description: |-
-                                Protocol for port. Must be UDP or TCP.
+                                Protocol for port. Must be UDP, TCP or SCTP.
                                 Defaults to "TCP".
                               type: string
                           required:
                           - port
                           type: object
                         type: array
+                      queues:
+                        description: |-
+                          If specified, the number of queues of the interface. Implies MultiQueue.
+                          Must not exceed the number of vCPUs. Defaults to the number of vCPUs.
+                        format: int32
+                        type: integer
                       slirp:
                         description: |-
                           DeprecatedSlirp is an alias to the deprecated Slirp interface
@@ -13062,6 +13125,14 @@ var CRDsValidation map[string]string = map[string]string{
                     description: Capacity of the sparse disk.
                     pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                     x-kubernetes-int-or-string: true
+                  format:
+                    description: Format of the disk image, qcow2 or raw. Defaults
+                      to qcow2.
+                    type: string
+                  preallocation:
+                    description: Preallocation mode of the disk image, off, falloc
+                      or full. Defaults to off.
+                    type: string
                 required:
                 - capacity
                 type: object
@@ -14772,6 +14843,11 @@ var CRDsValidation map[string]string = map[string]string{
                           Defaults to virtio.
                           TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51
                         type: string
+                      multiQueue:
+                        description: |-
+                          If specified, overrides Devices.NetworkInterfaceMultiQueue for this interface.
+                          Only applies to virtio interfaces.
+                        type: boolean
                       name:
                         description: |-
                           Logical name of the interface as well as a reference to the associated networks.
@@ -14811,13 +14887,19 @@ var CRDsValidation map[string]string = map[string]string{
                               type: integer
                             protocol:
                               description: |-
-                                Protocol for port. Must be UDP or TCP.
+                                Protocol for port. Must be UDP, TCP or SCTP.
                                 Defaults to "TCP".
                               type: string
                           required:
                           - port
                           type: object
                         type: array
+                      queues:
+                        description: |-
+                          If specified, the number of queues of the interface. Implies MultiQueue.
+                          Must not exceed the number of vCPUs. Defaults to the number of vCPUs.
+                        format: int32
+                        type: integer
                       slirp:
                         description: |-
                           DeprecatedSlirp is an alias to the deprecated Slirp interface
@@ -17178,6 +17260,11 @@ var CRDsValidation map[string]string = map[string]string{
                                   Defaults to virtio.
                                   TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51
                                 type: string
+                              multiQueue:
+                                description: |-
+                                  If specified, overrides Devices.NetworkInterfaceMultiQueue for this interface.
+                                  Only applies to virtio interfaces.
+                                type: boolean
                               name:
                                 description: |-
                                   Logical name of the interface as well as a reference to the associated networks.
@@ -17217,13 +17304,19 @@ var CRDsValidation map[string]string = map[string]string{
                                       type: integer
                                     protocol:
                                       description: |-
-                                        Protocol for port. Must be UDP or TCP.
+                                        Protocol for port. Must be UDP, TCP or SCTP.
                                         Defaults to "TCP".
                                       type: string
                                   required:
                                   - port
                                   type: object
                                 type: array
+                              queues:
+                                description: |-
+                                  If specified, the number of queues of the interface. Implies MultiQueue.
+                                  Must not exceed the number of vCPUs. Defaults to the number of vCPUs.
+                                format: int32
+                                type: integer
                               slirp:
                                 description: |-
                                   DeprecatedSlirp is an alias to the deprecated Slirp interface
@@ -18626,6 +18719,14 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Capacity of the sparse disk.
                             pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                             x-kubernetes-int-or-string: true
+                          format:
+                            description: Format of the disk image, qcow2 or raw. Defaults
+                              to qcow2.
+                            type: string
+                          preallocation:
+                            description: Preallocation mode of the disk image, off, falloc
+                              or full. Defaults to off.
+                            type: string
                         required:
                         - capacity
                         type: object
@@ -21671,6 +21772,11 @@ var CRDsValidation map[string]string = map[string]string{
                                           Defaults to virtio.
                                           TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51
                                         type: string
+                                      multiQueue:
+                                        description: |-
+                                          If specified, overrides Devices.NetworkInterfaceMultiQueue for this interface.
+                                          Only applies to virtio interfaces.
+                                        type: boolean
                                       name:
                                         description: |-
                                           Logical name of the interface as well as a reference to the associated networks.
@@ -21711,13 +21817,19 @@ var CRDsValidation map[string]string = map[string]string{
                                               type: integer
                                             protocol:
                                               description: |-
-                                                Protocol for port. Must be UDP or TCP.
+                                                Protocol for port. Must be UDP, TCP or SCTP.
                                                 Defaults to "TCP".
                                               type: string
                                           required:
                                           - port
                                           type: object
                                         type: array
+                                      queues:
+                                        description: |-
+                                          If specified, the number of queues of the interface. Implies MultiQueue.
+                                          Must not exceed the number of vCPUs. Defaults to the number of vCPUs.
+                                        format: int32
+                                        type: integer
                                       slirp:
                                         description: |-
                                           DeprecatedSlirp is an alias to the deprecated Slirp interface
@@ -23140,6 +23252,14 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: Capacity of the sparse disk.
                                     pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                                     x-kubernetes-int-or-string: true
+                                  format:
+                                    description: Format of the disk image, qcow2 or raw. Defaults
+                                      to qcow2.
+                                    type: string
+                                  preallocation:
+                                    description: Preallocation mode of the disk image, off, falloc
+                                      or full. Defaults to off.
+                                    type: string
                                 required:
                                 - capacity
                                 type: object
@@ -26857,6 +26977,11 @@ var CRDsValidation map[string]string = map[string]string{
                                               Defaults to virtio.
                                               TODO:(ihar) switch to enums once opengen-api supports them. See: https://github.com/kubernetes/kube-openapi/issues/51
                                             type: string
+                                          multiQueue:
+                                            description: |-
+                                              If specified, overrides Devices.NetworkInterfaceMultiQueue for this interface.
+                                              Only applies to virtio interfaces.
+                                            type: boolean
                                           name:
                                             description: |-
                                               Logical name of the interface as well as a reference to the associated networks.
@@ -26897,13 +27022,19 @@ var CRDsValidation map[string]string = map[string]string{
                                                   type: integer
                                                 protocol:
                                                   description: |-
-                                                    Protocol for port. Must be UDP or TCP.
+                                                    Protocol for port. Must be UDP, TCP or SCTP.
                                                     Defaults to "TCP".
                                                   type: string
                                               required:
                                               - port
                                               type: object
                                             type: array
+                                          queues:
+                                            description: |-
+                                              If specified, the number of queues of the interface. Implies MultiQueue.
+                                              Must not exceed the number of vCPUs. Defaults to the number of vCPUs.
+                                            format: int32
+                                            type: integer
                                           slirp:
                                             description: |-
                                               DeprecatedSlirp is an alias to the deprecated Slirp interface
@@ -28338,6 +28469,14 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: Capacity of the sparse disk.
                                         pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                                         x-kubernetes-int-or-string: true
+                                      format:
+                                        description: Format of the disk image, qcow2 or raw. Defaults
+                                          to qcow2.
+                                        type: string
+                                      preallocation:
+                                        description: Preallocation mode of the disk image, off, falloc
+                                          or full. Defaults to off.
+                                        type: string
                                     required:
                                     - capacity
                                     type: object
@@ -40,6 +40,7 @@ const (
 
 	ConditionReasonDeploymentFailedExisting = "ExistingDeployment"
 	ConditionReasonDeploymentFailedError    = "DeploymentFailed"
+	ConditionReasonValidationFailedError    = "ValidationFailed"
 	ConditionReasonDeletionFailedError      = "DeletionFailed"
 	ConditionReasonDeploymentCreated        = "AllResourcesCreated"
 	ConditionReasonDeploymentReady          = "AllComponentsReady"
@@ -95,6 +96,15 @@ func UpdateConditionsFailedError(kv *virtv1.KubeVirt, err error) {
 	updateCondition(kv, virtv1.KubeVirtConditionDegraded, k8sv1.ConditionTrue, ConditionReasonDeploymentFailedError, msg)
 }
 
+// UpdateConditionsValidationFailed leaves the Available condition alone, nothing was applied and
+// the previous deployment keeps serving
+func UpdateConditionsValidationFailed(kv *virtv1.KubeVirt, err error) {
+	msg := fmt.Sprintf("The install strategy was rejected by the cluster: %v", err)
+	updateCondition(kv, virtv1.KubeVirtConditionSynchronized, k8sv1.ConditionFalse, ConditionReasonValidationFailedError, msg)
+	updateCondition(kv, virtv1.KubeVirtConditionProgressing, k8sv1.ConditionFalse, ConditionReasonValidationFailedError, msg)
+	updateCondition(kv, virtv1.KubeVirtConditionDegraded, k8sv1.ConditionTrue, ConditionReasonValidationFailedError, msg)
+}
+
 func UpdateConditionsDeleting(kv *virtv1.KubeVirt) {
 	removeCondition(kv, virtv1.KubeVirtConditionCreated)
 	removeCondition(kv, virtv1.KubeVirtConditionSynchronized)
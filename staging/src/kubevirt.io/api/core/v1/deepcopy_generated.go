@@ -2148,6 +2148,16 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 		*out = new(DHCPOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MultiQueue != nil {
+		in, out := &in.MultiQueue, &out.MultiQueue
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Queues != nil {
+		in, out := &in.Queues, &out.Queues
+		*out = new(uint32)
+		**out = **in
+	}
 	return
 }
 
@@ -2632,6 +2642,11 @@ func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
 		*out = new(CommonInstancetypesDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MetricsConfiguration != nil {
+		in, out := &in.MetricsConfiguration, &out.MetricsConfiguration
+		*out = new(MetricsConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3053,6 +3068,32 @@ func (in *MemoryStatus) DeepCopy() *MemoryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsConfiguration) DeepCopyInto(out *MetricsConfiguration) {
+	*out = *in
+	if in.VMILabelAllowlist != nil {
+		in, out := &in.VMILabelAllowlist, &out.VMILabelAllowlist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VMILabelDenylist != nil {
+		in, out := &in.VMILabelDenylist, &out.VMILabelDenylist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsConfiguration.
+func (in *MetricsConfiguration) DeepCopy() *MetricsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MigrateOptions) DeepCopyInto(out *MigrateOptions) {
 	*out = *in
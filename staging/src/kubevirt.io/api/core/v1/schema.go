@@ -894,8 +894,29 @@ type EphemeralVolumeSource struct {
 type EmptyDiskSource struct {
 	// Capacity of the sparse disk.
 	Capacity resource.Quantity `json:"capacity"`
+	// Format of the disk image, qcow2 or raw. Defaults to qcow2.
+	// +optional
+	Format EmptyDiskFormat `json:"format,omitempty"`
+	// Preallocation mode of the disk image, off, falloc or full. Defaults to off.
+	// +optional
+	Preallocation EmptyDiskPreallocation `json:"preallocation,omitempty"`
 }
 
+type EmptyDiskFormat string
+
+const (
+	EmptyDiskFormatQCOW2 EmptyDiskFormat = "qcow2"
+	EmptyDiskFormatRaw   EmptyDiskFormat = "raw"
+)
+
+type EmptyDiskPreallocation string
+
+const (
+	EmptyDiskPreallocationOff    EmptyDiskPreallocation = "off"
+	EmptyDiskPreallocationFalloc EmptyDiskPreallocation = "falloc"
+	EmptyDiskPreallocationFull   EmptyDiskPreallocation = "full"
+)
+
 // Represents a docker image with an embedded disk.
 type ContainerDiskSource struct {
 	// Image is the name of the image with the embedded disk.
@@ -1280,6 +1301,14 @@ type Interface struct {
 	// The (only) value supported is `absent`, expressing a request to remove the interface.
 	// +optional
 	State InterfaceState `json:"state,omitempty"`
+	// If specified, overrides Devices.NetworkInterfaceMultiQueue for this interface.
+	// Only applies to virtio interfaces.
+	// +optional
+	MultiQueue *bool `json:"multiQueue,omitempty"`
+	// If specified, the number of queues of the interface. Implies MultiQueue.
+	// Must not exceed the number of vCPUs. Defaults to the number of vCPUs.
+	// +optional
+	Queues *uint32 `json:"queues,omitempty"`
 }
 
 type InterfaceState string
@@ -1392,7 +1421,7 @@ type Port struct {
 	// referred to by services.
 	// +optional
 	Name string `json:"name,omitempty"`
-	// Protocol for port. Must be UDP or TCP.
+	// Protocol for port. Must be UDP, TCP or SCTP.
 	// Defaults to "TCP".
 	// +optional
 	Protocol string `json:"protocol,omitempty"`
@@ -486,8 +486,10 @@ func (EphemeralVolumeSource) SwaggerDoc() map[string]string {
 
 func (EmptyDiskSource) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "EmptyDisk represents a temporary disk which shares the vmis lifecycle.",
-		"capacity": "Capacity of the sparse disk.",
+		"":              "EmptyDisk represents a temporary disk which shares the vmis lifecycle.",
+		"capacity":      "Capacity of the sparse disk.",
+		"format":        "Format of the disk image, qcow2 or raw. Defaults to qcow2.\n+optional",
+		"preallocation": "Preallocation mode of the disk image, off, falloc or full. Defaults to off.\n+optional",
 	}
 }
 
@@ -678,6 +680,8 @@ func (Interface) SwaggerDoc() map[string]string {
 		"tag":         "If specified, the virtual network interface address and its tag will be provided to the guest via config drive\n+optional",
 		"acpiIndex":   "If specified, the ACPI index is used to provide network interface device naming, that is stable across changes\nin PCI addresses assigned to the device.\nThis value is required to be unique across all devices and be between 1 and (16*1024-1).\n+optional",
 		"state":       "State represents the requested operational state of the interface.\nThe (only) value supported is `absent`, expressing a request to remove the interface.\n+optional",
+		"multiQueue":  "If specified, overrides Devices.NetworkInterfaceMultiQueue for this interface.\nOnly applies to virtio interfaces.\n+optional",
+		"queues":      "If specified, the number of queues of the interface. Implies MultiQueue.\nMust not exceed the number of vCPUs. Defaults to the number of vCPUs.\n+optional",
 	}
 }
 
@@ -755,7 +759,7 @@ func (Port) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "Port represents a port to expose from the virtual machine.\nDefault protocol TCP.\nThe port field is mandatory",
 		"name":     "If specified, this must be an IANA_SVC_NAME and unique within the pod. Each\nnamed port in a pod must have a unique name. Name for the port that can be\nreferred to by services.\n+optional",
-		"protocol": "Protocol for port. Must be UDP or TCP.\nDefaults to \"TCP\".\n+optional",
+		"protocol": "Protocol for port. Must be UDP, TCP or SCTP.\nDefaults to \"TCP\".\n+optional",
 		"port":     "Number of port to expose for the virtual machine.\nThis must be a valid port number, 0 < x < 65536.",
 	}
 }
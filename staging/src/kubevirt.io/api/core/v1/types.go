@@ -580,6 +580,12 @@ const (
 
 	// Indicates whether the VMI is live migratable
 	VirtualMachineInstanceIsStorageLiveMigratable VirtualMachineInstanceConditionType = "StorageLiveMigratable"
+
+	// Reports how the qemu process of a failed VMI exited
+	VirtualMachineInstanceQemuExited VirtualMachineInstanceConditionType = "QemuExited"
+
+	// Indicates that the process monitor of virt-launcher stopped reporting its heartbeat
+	VirtualMachineInstanceLauncherUnresponsive VirtualMachineInstanceConditionType = "LauncherUnresponsive"
 )
 
 // These are valid reasons for VMI conditions.
@@ -612,6 +618,12 @@ const (
 	VirtualMachineInstanceReasonNotMigratable = "NotMigratable"
 	// Reason means that the volume update change was cancelled
 	VirtualMachineInstanceReasonVolumesChangeCancellation = "VolumesChangeCancellation"
+	// Reason means that the qemu process exited with a non-zero exit code
+	VirtualMachineInstanceReasonQemuExitCode = "QemuExitCode"
+	// Reason means that the qemu process was terminated by a signal
+	VirtualMachineInstanceReasonQemuSignaled = "QemuSignaled"
+	// Reason means that the heartbeat of the virt-launcher process monitor is outdated
+	VirtualMachineInstanceReasonLauncherHeartbeatExpired = "LauncherHeartbeatExpired"
 )
 
 const (
@@ -945,6 +957,8 @@ const (
 	EphemeralBackupObject = "kubevirt.io/ephemeral-backup-object"
 	// This annotation represents that the annotated object is for temporary use during pod/volume provisioning
 	EphemeralProvisioningObject string = "kubevirt.io/ephemeral-provisioning"
+	// This annotation stops virt-operator from reverting the manual changes to the fields it manages on a KubeVirt SCC
+	IgnoreSCCDriftAnnotation = "kubevirt.io/ignore-scc-drift"
 
 	// This label indicates the object is a part of the install strategy retrieval process.
 	InstallStrategyLabel = "kubevirt.io/install-strategy"
@@ -1010,6 +1024,14 @@ const (
 	// For more info: https://libvirt.org/kbase/debuglogs.html
 	CustomLibvirtLogFiltersAnnotation string = "kubevirt.io/libvirt-log-filters"
 
+	// MemBalloonStatsPeriodAnnotation overrides, for a single VMI, the cluster wide period in seconds at which
+	// the memory balloon statistics are collected. A period of 0 disables the collection.
+	MemBalloonStatsPeriodAnnotation string = "kubevirt.io/memballoon-stats-period-seconds"
+
+	// SMBiosSerialAnnotation sets the SMBIOS system serial number of a VMI whose firmware doesn't specify one.
+	// The VMI UID is used when it is absent.
+	SMBiosSerialAnnotation string = "kubevirt.io/smbios-serial"
+
 	// RealtimeLabel marks the node as capable of running realtime workloads
 	RealtimeLabel string = "kubevirt.io/realtime"
 
@@ -2531,6 +2553,10 @@ type KubeVirtConfiguration struct {
 	// CommonInstancetypesDeployment controls the deployment of common-instancetypes resources
 	// +nullable
 	CommonInstancetypesDeployment *CommonInstancetypesDeployment `json:"commonInstancetypesDeployment,omitempty"`
+
+	// MetricsConfiguration controls the metrics exposed by virt-handler about the VMIs of its node
+	// +optional
+	MetricsConfiguration *MetricsConfiguration `json:"metricsConfiguration,omitempty"`
 }
 
 type CommonInstancetypesDeployment struct {
@@ -2539,6 +2565,23 @@ type CommonInstancetypesDeployment struct {
 	Enabled *bool `json:"enabled,omitempty"`
 }
 
+// MetricsConfiguration holds the metrics options
+type MetricsConfiguration struct {
+	// VMILabelPrefix is prepended to the VMI label keys turned into metric labels, defaults to kubernetes_vmi_label_.
+	// +optional
+	VMILabelPrefix string `json:"vmiLabelPrefix,omitempty"`
+	// VMILabelAllowlist holds the VMI label keys which are turned into metric labels, a trailing * matches a key prefix.
+	// All the VMI labels are turned into metric labels when empty.
+	// +optional
+	// +listType=set
+	VMILabelAllowlist []string `json:"vmiLabelAllowlist,omitempty"`
+	// VMILabelDenylist holds the VMI label keys which are never turned into metric labels, a trailing * matches a key prefix.
+	// It takes precedence over the VMILabelAllowlist.
+	// +optional
+	// +listType=set
+	VMILabelDenylist []string `json:"vmiLabelDenylist,omitempty"`
+}
+
 type VMRolloutStrategy string
 
 const (
@@ -2568,6 +2611,7 @@ type SMBiosConfiguration struct {
 	Version      string `json:"version,omitempty"`
 	Sku          string `json:"sku,omitempty"`
 	Family       string `json:"family,omitempty"`
+	AssetTag     string `json:"assetTag,omitempty"`
 }
 
 type SupportContainerType string
@@ -2856,6 +2900,11 @@ type NetworkConfiguration struct {
 	DeprecatedPermitSlirpInterface    *bool                             `json:"permitSlirpInterface,omitempty"`
 	PermitBridgeInterfaceOnPodNetwork *bool                             `json:"permitBridgeInterfaceOnPodNetwork,omitempty"`
 	Binding                           map[string]InterfaceBindingPlugin `json:"binding,omitempty"`
+	// ServiceDomainInfix identifies the Kubernetes service domains among the search domains of the
+	// virt-launcher pod, which are advertised to the guest by the DHCP server.
+	// Defaults to ".svc.".
+	// +optional
+	ServiceDomainInfix string `json:"serviceDomainInfix,omitempty"`
 }
 
 type InterfaceBindingPlugin struct {
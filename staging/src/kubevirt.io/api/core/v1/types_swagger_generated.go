@@ -769,6 +769,7 @@ func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 		"liveUpdateConfiguration":            "LiveUpdateConfiguration holds defaults for live update features",
 		"vmRolloutStrategy":                  "VMRolloutStrategy defines how changes to a VM object propagate to its VMI\n+nullable\n+kubebuilder:validation:Enum=Stage;LiveUpdate",
 		"commonInstancetypesDeployment":      "CommonInstancetypesDeployment controls the deployment of common-instancetypes resources\n+nullable",
+		"metricsConfiguration":               "MetricsConfiguration controls the metrics exposed by virt-handler about the VMIs of its node\n+optional",
 	}
 }
 
@@ -778,6 +779,15 @@ func (CommonInstancetypesDeployment) SwaggerDoc() map[string]string {
 	}
 }
 
+func (MetricsConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "MetricsConfiguration holds the metrics options",
+		"vmiLabelPrefix":    "VMILabelPrefix is prepended to the VMI label keys turned into metric labels, defaults to kubernetes_vmi_label_.\n+optional",
+		"vmiLabelAllowlist": "VMILabelAllowlist holds the VMI label keys which are turned into metric labels, a trailing * matches a key prefix.\nAll the VMI labels are turned into metric labels when empty.\n+optional\n+listType=set",
+		"vmiLabelDenylist":  "VMILabelDenylist holds the VMI label keys which are never turned into metric labels, a trailing * matches a key prefix.\nIt takes precedence over the VMILabelAllowlist.\n+optional\n+listType=set",
+	}
+}
+
 func (ArchConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{}
 }
@@ -949,6 +959,7 @@ func (NetworkConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                     "NetworkConfiguration holds network options",
 		"permitSlirpInterface": "DeprecatedPermitSlirpInterface is an alias for the deprecated PermitSlirpInterface.\nDeprecated: Removed in v1.3.",
+		"serviceDomainInfix":   "ServiceDomainInfix identifies the Kubernetes service domains among the search domains of the\nvirt-launcher pod, which are advertised to the guest by the DHCP server.\nDefaults to \".svc.\".\n+optional",
 	}
 }
 
@@ -0,0 +1,142 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package libwait
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+const (
+	diagnosticsMaxEvents     = 20
+	diagnosticsLogLines      = int64(50)
+	launcherComputeContainer = "compute"
+)
+
+// collectVMIDiagnostics gathers the VMI conditions and the recent events and compute container logs
+// of its virt-launcher pod into a human-readable report.
+// Errors are reported inline, as the diagnostics are best effort and are only used to enrich a failure message.
+func collectVMIDiagnostics(ctx context.Context, virtClient kubecli.KubevirtClient, namespace, name string) string {
+	var report strings.Builder
+	fmt.Fprintf(&report, "Diagnostics for VMI %s/%s:\n", namespace, name)
+
+	vmi, err := virtClient.VirtualMachineInstance(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		fmt.Fprintf(&report, "failed to get VMI: %v\n", err)
+		return report.String()
+	}
+
+	fmt.Fprintf(&report, "Phase: %s\n", vmi.Status.Phase)
+	report.WriteString("Conditions:\n")
+	if len(vmi.Status.Conditions) == 0 {
+		report.WriteString("  <none>\n")
+	}
+	for _, cond := range vmi.Status.Conditions {
+		fmt.Fprintf(&report, "  %s=%s reason=%q message=%q\n", cond.Type, cond.Status, cond.Reason, cond.Message)
+	}
+
+	pod, err := lookupLauncherPod(ctx, virtClient, vmi)
+	if err != nil {
+		fmt.Fprintf(&report, "failed to find virt-launcher pod: %v\n", err)
+	}
+
+	involvedObjects := []string{vmi.Name}
+	if pod != nil {
+		fmt.Fprintf(&report, "virt-launcher pod: %s (phase %s)\n", pod.Name, pod.Status.Phase)
+		involvedObjects = append(involvedObjects, pod.Name)
+	}
+	writeEvents(ctx, &report, virtClient, namespace, involvedObjects)
+
+	if pod != nil {
+		writeLauncherLogs(ctx, &report, virtClient, pod)
+	}
+
+	return report.String()
+}
+
+func lookupLauncherPod(ctx context.Context, virtClient kubecli.KubevirtClient, vmi *v1.VirtualMachineInstance) (*k8sv1.Pod, error) {
+	pods, err := virtClient.CoreV1().Pods(vmi.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", v1.CreatedByLabel, string(vmi.UID)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no pod labeled %s=%s", v1.CreatedByLabel, string(vmi.UID))
+	}
+
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[j].CreationTimestamp.Before(&pods.Items[i].CreationTimestamp)
+	})
+	return &pods.Items[0], nil
+}
+
+func writeEvents(ctx context.Context, report *strings.Builder, virtClient kubecli.KubevirtClient, namespace string, involvedObjects []string) {
+	events, err := virtClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		fmt.Fprintf(report, "failed to list events: %v\n", err)
+		return
+	}
+
+	var relevant []k8sv1.Event
+	for _, event := range events.Items {
+		for _, name := range involvedObjects {
+			if event.InvolvedObject.Name == name {
+				relevant = append(relevant, event)
+				break
+			}
+		}
+	}
+	sort.SliceStable(relevant, func(i, j int) bool {
+		return relevant[i].LastTimestamp.Before(&relevant[j].LastTimestamp)
+	})
+	if len(relevant) > diagnosticsMaxEvents {
+		relevant = relevant[len(relevant)-diagnosticsMaxEvents:]
+	}
+
+	report.WriteString("Events:\n")
+	if len(relevant) == 0 {
+		report.WriteString("  <none>\n")
+	}
+	for _, event := range relevant {
+		fmt.Fprintf(report, "  %s %s/%s %s: %s\n",
+			event.Type, event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Reason, event.Message)
+	}
+}
+
+func writeLauncherLogs(ctx context.Context, report *strings.Builder, virtClient kubecli.KubevirtClient, pod *k8sv1.Pod) {
+	tailLines := diagnosticsLogLines
+	logs, err := virtClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &k8sv1.PodLogOptions{
+		Container: launcherComputeContainer,
+		TailLines: &tailLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		fmt.Fprintf(report, "failed to get %s container logs: %v\n", launcherComputeContainer, err)
+		return
+	}
+	fmt.Fprintf(report, "Last %d lines of the %s container logs:\n%s\n", tailLines, launcherComputeContainer, logs)
+}
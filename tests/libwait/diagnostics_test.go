@@ -0,0 +1,143 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright the KubeVirt Authors.
+ *
+ */
+
+package libwait
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/api/core/v1"
+	"kubevirt.io/client-go/kubecli"
+	kubevirtfake "kubevirt.io/client-go/kubevirt/fake"
+)
+
+var _ = Describe("VMI start diagnostics", func() {
+	const (
+		namespace = "default"
+		vmiName   = "testvmi"
+		vmiUID    = "1234"
+		podName   = "virt-launcher-testvmi-abcde"
+	)
+
+	var (
+		virtClient *kubecli.MockKubevirtClient
+		kubeClient *fake.Clientset
+	)
+
+	stuckVMI := func() *v1.VirtualMachineInstance {
+		return &v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: vmiName, Namespace: namespace, UID: vmiUID},
+			Status: v1.VirtualMachineInstanceStatus{
+				Phase: v1.Scheduling,
+				Conditions: []v1.VirtualMachineInstanceCondition{{
+					Type:    v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled),
+					Status:  k8sv1.ConditionFalse,
+					Reason:  "Unschedulable",
+					Message: "0/3 nodes are available",
+				}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	})
+
+	It("should report the VMI conditions and the launcher pod events and logs", func() {
+		fakeVirtClient := kubevirtfake.NewSimpleClientset(stuckVMI())
+		virtClient.EXPECT().VirtualMachineInstance(namespace).
+			Return(fakeVirtClient.KubevirtV1().VirtualMachineInstances(namespace)).AnyTimes()
+
+		_, err := kubeClient.CoreV1().Pods(namespace).Create(context.Background(), &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName,
+				Namespace: namespace,
+				Labels:    map[string]string{v1.CreatedByLabel: vmiUID},
+			},
+			Status: k8sv1.PodStatus{Phase: k8sv1.PodPending},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, event := range []k8sv1.Event{
+			newEvent("pod-event", "Pod", podName, "FailedScheduling", "0/3 nodes are available"),
+			newEvent("vmi-event", "VirtualMachineInstance", vmiName, "SuccessfulCreate", "Created virtual machine pod"),
+			newEvent("unrelated-event", "Pod", "some-other-pod", "Pulled", "should not be reported"),
+		} {
+			_, err = kubeClient.CoreV1().Events(namespace).Create(context.Background(), &event, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		report := collectVMIDiagnostics(context.Background(), virtClient, namespace, vmiName)
+
+		Expect(report).To(ContainSubstring("Phase: Scheduling"))
+		Expect(report).To(ContainSubstring(`PodScheduled=False reason="Unschedulable" message="0/3 nodes are available"`))
+		Expect(report).To(ContainSubstring("virt-launcher pod: " + podName + " (phase Pending)"))
+		Expect(report).To(ContainSubstring("Warning Pod/" + podName + " FailedScheduling: 0/3 nodes are available"))
+		Expect(report).To(ContainSubstring("VirtualMachineInstance/" + vmiName + " SuccessfulCreate"))
+		Expect(report).ToNot(ContainSubstring("should not be reported"))
+		Expect(report).To(ContainSubstring("compute container logs:\nfake logs"))
+	})
+
+	It("should report a missing launcher pod", func() {
+		fakeVirtClient := kubevirtfake.NewSimpleClientset(stuckVMI())
+		virtClient.EXPECT().VirtualMachineInstance(namespace).
+			Return(fakeVirtClient.KubevirtV1().VirtualMachineInstances(namespace)).AnyTimes()
+
+		report := collectVMIDiagnostics(context.Background(), virtClient, namespace, vmiName)
+
+		Expect(report).To(ContainSubstring("failed to find virt-launcher pod"))
+		Expect(report).To(ContainSubstring("Events:\n  <none>"))
+		Expect(report).ToNot(ContainSubstring("container logs"))
+	})
+
+	It("should report a VMI that cannot be fetched", func() {
+		fakeVirtClient := kubevirtfake.NewSimpleClientset()
+		virtClient.EXPECT().VirtualMachineInstance(namespace).
+			Return(fakeVirtClient.KubevirtV1().VirtualMachineInstances(namespace)).AnyTimes()
+
+		report := collectVMIDiagnostics(context.Background(), virtClient, namespace, vmiName)
+
+		Expect(report).To(ContainSubstring("failed to get VMI"))
+	})
+})
+
+func newEvent(name, kind, involvedObject, reason, message string) k8sv1.Event {
+	eventType := k8sv1.EventTypeNormal
+	if reason == "FailedScheduling" {
+		eventType = k8sv1.EventTypeWarning
+	}
+	return k8sv1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name},
+		InvolvedObject: k8sv1.ObjectReference{Kind: kind, Name: involvedObject},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+	}
+}
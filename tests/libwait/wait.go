@@ -51,6 +51,7 @@ type Waiting struct {
 	wp          *watcher.WarningsPolicy
 	timeout     int
 	waitForFail bool
+	diagnostics bool
 	phases      []v1.VirtualMachineInstancePhase
 }
 
@@ -128,6 +129,12 @@ func WithWaitForFail(waitForFail bool) Option {
 	}
 }
 
+func withDiagnostics() Option {
+	return func(waiting *Waiting) {
+		waiting.diagnostics = true
+	}
+}
+
 // watchVMIForPhase looks at the vmi object and, after it is started, waits for it to satisfy the phases with the passed parameters
 func (w *Waiting) watchVMIForPhase(vmi *v1.VirtualMachineInstance) *v1.VirtualMachineInstance {
 	virtClient, err := kubecli.GetKubevirtClient()
@@ -174,8 +181,13 @@ func (w *Waiting) watchVMIForPhase(vmi *v1.VirtualMachineInstance) *v1.VirtualMa
 				retrievedVMI.Status.Phase)
 		}
 		return retrievedVMI.Status.Phase
-	}, time.Duration(w.timeout)*time.Second, 1*time.Second).Should(gomega.BeElementOf(w.phases),
-		fmt.Sprintf("Timed out waiting for VMI %s to enter %s phase(s)", vmi.Name, w.phases))
+	}, time.Duration(w.timeout)*time.Second, 1*time.Second).Should(gomega.BeElementOf(w.phases), func() string {
+		msg := fmt.Sprintf("Timed out waiting for VMI %s to enter %s phase(s)", vmi.Name, w.phases)
+		if w.diagnostics {
+			msg += "\n" + collectVMIDiagnostics(context.Background(), virtClient, vmi.Namespace, vmi.Name)
+		}
+		return msg
+	})
 
 	return retrievedVMI
 }
@@ -189,6 +201,15 @@ func WaitForSuccessfulVMIStart(vmi *v1.VirtualMachineInstance, opts ...Option) *
 	)
 }
 
+// WaitForSuccessfulVMIStartWithDiagnostics behaves like WaitForSuccessfulVMIStart, but on timeout the failure
+// message also carries the VMI conditions and the recent events and logs of its virt-launcher pod
+func WaitForSuccessfulVMIStartWithDiagnostics(vmi *v1.VirtualMachineInstance, opts ...Option) *v1.VirtualMachineInstance {
+	return WaitForVMIPhase(vmi,
+		[]v1.VirtualMachineInstancePhase{v1.Running},
+		append(opts, withDiagnostics())...,
+	)
+}
+
 // WaitUntilVMIReady blocks until the specified VirtualMachineInstance reaches the Running state using the passed
 // options, and the login succeed
 func WaitUntilVMIReady(vmi *v1.VirtualMachineInstance, loginTo console.LoginToFunction, opts ...Option) *v1.VirtualMachineInstance {